@@ -0,0 +1,653 @@
+// Package main implements admin cli for node registry contract
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/1inch/p2p-network/contracts"
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/1inch/p2p-network/internal/txflags"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	errContractAddressRequired = errors.New("contract address required")
+	errIpRequired              = errors.New("ip required")
+	errNodeRequired            = errors.New("either public_key or relayer required")
+	errNothingToUpdate         = errors.New("ip or capabilities required")
+	errInvalidAddress          = errors.New("invalid relayer owner address")
+	errInvalidChainId          = errors.New("invalid chain id")
+	errRelayerNotFound         = errors.New("relayer not found")
+	errRelayerRequired         = errors.New("relayer required")
+	errInvalidOutput           = errors.New("output must be table or json")
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "registry",
+		Usage: "Node registry administration",
+		Commands: []cli.Command{
+			cliCommandDeploy(),
+			cliCommandRegisterRelayer(),
+			cliCommandApproveRelayer(),
+			cliCommandSetPrimaryRelayer(),
+			cliCommandRegisterResolver(),
+			cliCommandList(),
+			cliCommandGet(),
+			cliCommandUpdate(),
+			cliCommandRemove(),
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		newLogger().Error("failed to run registry CLI interface", slog.Any("err", err))
+		os.Exit(1)
+	}
+}
+
+func cliCommandDeploy() cli.Command {
+	return cli.Command{
+		Name:  "deploy",
+		Usage: "Deploy node registry contract, the signing account is the contract owner",
+		Flags: joinFlags(keyFlags(), txFlags()),
+		Action: func(c *cli.Context) error {
+			cfg, err := registryConfig(c)
+			if err != nil {
+				return err
+			}
+
+			address, client, err := registry.DeployNodeRegistry(context.Background(), cfg)
+			if txflags.LogDryRun(newLogger(), "deployment", err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			return printDeployment(c, deploymentView{Address: address.Hex(), Owner: client.Auth.From.Hex()})
+		},
+	}
+}
+
+func cliCommandRegisterRelayer() cli.Command {
+	return cli.Command{
+		Name:    "register_relayer",
+		Aliases: []string{"register-relayer"},
+		Usage:   "Register or update relayer of the signing account, the account must be approved by the contract owner",
+		Flags: joinFlags(keyFlags(), []cli.Flag{
+			ipFlag,
+			&cli.StringFlag{
+				Name:  "region",
+				Usage: "Region of the relayer, a hint for clients",
+			},
+			&cli.UintFlag{
+				Name:  "capacity",
+				Usage: "Max count of client connections",
+			},
+			&cli.StringFlag{
+				Name:  "public_key",
+				Usage: "Compressed secp256k1 public key of relayer in hex, public key of the signing key by default",
+			},
+		}, txFlags()),
+		Action: func(c *cli.Context) error {
+			if c.String("ip") == "" {
+				return errIpRequired
+			}
+
+			cfg, err := registryConfig(c)
+			if err != nil {
+				return err
+			}
+
+			publicKey, err := publicKeyByFlag(c, cfg.Signer)
+			if err != nil {
+				return err
+			}
+
+			return sendRegistryTx(c, cfg, "relayer registration", func(ctx context.Context, client *registry.Client) error {
+				return client.RegisterRelayer(ctx, c.String("ip"), registry.RelayerMetadata{
+					Region:    c.String("region"),
+					Capacity:  uint32(c.Uint("capacity")),
+					PublicKey: publicKey,
+				})
+			})
+		},
+	}
+}
+
+func cliCommandApproveRelayer() cli.Command {
+	return cli.Command{
+		Name:    "approve_relayer",
+		Aliases: []string{"approve-relayer"},
+		Usage:   "Allow account to register relayer, must be signed by the contract owner",
+		Flags: joinFlags(keyFlags(), []cli.Flag{
+			relayerFlag,
+			&cli.BoolFlag{
+				Name:  "revoke",
+				Usage: "Forbid account to register relayer, its registered relayer is removed",
+			},
+		}, txFlags()),
+		Action: func(c *cli.Context) error {
+			relayerOwner, err := requiredRelayer(c)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := registryConfig(c)
+			if err != nil {
+				return err
+			}
+
+			return sendRegistryTx(c, cfg, "relayer approval", func(ctx context.Context, client *registry.Client) error {
+				return client.SetRelayerApproval(ctx, relayerOwner, !c.Bool("revoke"))
+			})
+		},
+	}
+}
+
+func cliCommandSetPrimaryRelayer() cli.Command {
+	return cli.Command{
+		Name:    "set_primary_relayer",
+		Aliases: []string{"set-primary-relayer"},
+		Usage:   "Choose relayer returned to clients by getRelayer, must be signed by the contract owner",
+		Flags:   joinFlags(keyFlags(), []cli.Flag{relayerFlag}, txFlags()),
+		Action: func(c *cli.Context) error {
+			relayerOwner, err := requiredRelayer(c)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := registryConfig(c)
+			if err != nil {
+				return err
+			}
+
+			return sendRegistryTx(c, cfg, "primary relayer update", func(ctx context.Context, client *registry.Client) error {
+				return client.SetPrimaryRelayer(ctx, relayerOwner)
+			})
+		},
+	}
+}
+
+func cliCommandRegisterResolver() cli.Command {
+	return cli.Command{
+		Name:    "register_resolver",
+		Aliases: []string{"register-resolver"},
+		Usage:   "Register resolver, the signing account is the resolver owner",
+		Flags: joinFlags(keyFlags(), []cli.Flag{
+			ipFlag,
+			&cli.StringFlag{
+				Name:  "public_key",
+				Usage: "Compressed secp256k1 public key of resolver in hex, public key of the signing key by default",
+			},
+			&cli.StringFlag{
+				Name:  "stake",
+				Usage: "Stake in wei sent on registration",
+			},
+		}, capabilitiesFlags, txFlags()),
+		Action: func(c *cli.Context) error {
+			if c.String("ip") == "" {
+				return errIpRequired
+			}
+
+			cfg, err := registryConfig(c)
+			if err != nil {
+				return err
+			}
+
+			publicKey, err := publicKeyByFlag(c, cfg.Signer)
+			if err != nil {
+				return err
+			}
+
+			capabilities, err := capabilitiesByFlags(c)
+			if err != nil {
+				return err
+			}
+
+			options := registry.ResolverOptions{Capabilities: capabilities}
+			if stake := c.String("stake"); stake != "" {
+				if options.Stake, err = txflags.ParseWei(stake); err != nil {
+					return err
+				}
+			}
+
+			return sendRegistryTx(c, cfg, "resolver registration", func(ctx context.Context, client *registry.Client) error {
+				return client.RegisterResolverWithOptions(ctx, c.String("ip"), publicKey, options)
+			})
+		},
+	}
+}
+
+func cliCommandList() cli.Command {
+	return cli.Command{
+		Name:  "list",
+		Usage: "List registered relayers and resolvers",
+		Flags: append(connectionFlags(),
+			&cli.StringFlag{
+				Name:  "nodes",
+				Value: "all",
+				Usage: "Listed nodes: all, relayers or resolvers",
+			},
+		),
+		Action: func(c *cli.Context) error {
+			client, err := dialReadOnly(c)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			var nodes nodesView
+			nodesFlag := c.String("nodes")
+			if nodesFlag == "all" || nodesFlag == "relayers" {
+				relayers, err := client.GetRelayers()
+				if err != nil {
+					return err
+				}
+				nodes.Relayers = relayerViews(relayers)
+			}
+			if nodesFlag == "all" || nodesFlag == "resolvers" {
+				resolvers, err := client.GetResolvers(context.Background())
+				if err != nil {
+					return err
+				}
+				nodes.Resolvers = resolverViews(resolvers)
+			}
+
+			return printNodes(c, nodes)
+		},
+	}
+}
+
+func cliCommandGet() cli.Command {
+	return cli.Command{
+		Name:  "get",
+		Usage: "Print resolver by public key or relayer by owner address",
+		Flags: append(connectionFlags(), publicKeyFlag, relayerFlag),
+		Action: func(c *cli.Context) error {
+			client, err := dialReadOnly(c)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			if relayerOwner := c.String("relayer"); relayerOwner != "" {
+				owner, err := addressByFlag(relayerOwner)
+				if err != nil {
+					return err
+				}
+
+				relayers, err := client.GetRelayers()
+				if err != nil {
+					return err
+				}
+				for _, relayer := range relayers {
+					if relayer.Owner == owner {
+						return printNodes(c, nodesView{Relayers: relayerViews([]contracts.NodeRegistryRelayer{relayer})})
+					}
+				}
+
+				return errRelayerNotFound
+			}
+
+			publicKey, err := requiredPublicKey(c)
+			if err != nil {
+				return err
+			}
+
+			resolver, err := getResolver(client, publicKey)
+			if err != nil {
+				return err
+			}
+
+			return printNodes(c, nodesView{Resolvers: []resolverView{resolver}})
+		},
+	}
+}
+
+func cliCommandUpdate() cli.Command {
+	return cli.Command{
+		Name:  "update",
+		Usage: "Update resolver endpoint or capabilities, relayers are updated by register_relayer",
+		Flags: joinFlags(keyFlags(), []cli.Flag{
+			publicKeyFlag,
+			&cli.StringFlag{
+				Name:  "ip",
+				Usage: "New resolver endpoint, not changed if empty",
+			},
+		}, capabilitiesFlags, txFlags()),
+		Action: func(c *cli.Context) error {
+			publicKey, err := requiredPublicKey(c)
+			if err != nil {
+				return err
+			}
+
+			updateCapabilities := false
+			for _, flag := range capabilitiesFlags {
+				updateCapabilities = updateCapabilities || c.IsSet(flag.GetName())
+			}
+			if c.String("ip") == "" && !updateCapabilities {
+				return errNothingToUpdate
+			}
+
+			capabilities, err := capabilitiesByFlags(c)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := registryConfig(c)
+			if err != nil {
+				return err
+			}
+
+			return sendRegistryTx(c, cfg, "resolver update", func(ctx context.Context, client *registry.Client) error {
+				ip := c.String("ip")
+				switch {
+				case ip != "" && updateCapabilities:
+					return client.UpdateResolverWithCapabilities(ctx, publicKey, ip, capabilities)
+				case ip != "":
+					return client.UpdateResolver(ctx, publicKey, ip)
+				default:
+					return client.SetResolverCapabilities(ctx, publicKey, capabilities)
+				}
+			})
+		},
+	}
+}
+
+func cliCommandRemove() cli.Command {
+	return cli.Command{
+		Name:  "remove",
+		Usage: "Remove resolver by public key or relayer by owner address, allowed for the node owner and the contract owner",
+		Flags: joinFlags(keyFlags(), []cli.Flag{publicKeyFlag, relayerFlag}, txFlags()),
+		Action: func(c *cli.Context) error {
+			cfg, err := registryConfig(c)
+			if err != nil {
+				return err
+			}
+
+			if relayerOwner := c.String("relayer"); relayerOwner != "" {
+				owner, err := addressByFlag(relayerOwner)
+				if err != nil {
+					return err
+				}
+
+				return sendRegistryTx(c, cfg, "relayer removal", func(ctx context.Context, client *registry.Client) error {
+					return client.RemoveRelayer(ctx, owner)
+				})
+			}
+
+			publicKey, err := requiredPublicKey(c)
+			if err != nil {
+				return err
+			}
+
+			return sendRegistryTx(c, cfg, "resolver removal", func(ctx context.Context, client *registry.Client) error {
+				return client.DeregisterResolver(ctx, publicKey)
+			})
+		},
+	}
+}
+
+// sendRegistryTx dials registry with the signing key and sends transactions, dry run estimate is logged
+func sendRegistryTx(c *cli.Context, cfg *registry.Config, name string, send func(ctx context.Context, client *registry.Client) error) error {
+	logger := newLogger()
+	if cfg.ContractAddress == "" {
+		return errContractAddressRequired
+	}
+
+	ctx := context.Background()
+	client, err := registry.Dial(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = send(ctx, client)
+	if txflags.LogDryRun(logger, name, err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info(name+" is confirmed", slog.String("account", client.Auth.From.Hex()))
+	return nil
+}
+
+// registryConfig returns registry client config with signing key and transaction policy from flags
+func registryConfig(c *cli.Context) (*registry.Config, error) {
+	nodeSigner, err := signer.New(c.String("private_key"), signer.KeystoreConfig{
+		Path:           c.String("keystore"),
+		PassphraseFile: c.String("keystore_passphrase_file"),
+		PassphraseEnv:  c.String("keystore_passphrase_env"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &registry.Config{
+		DialURI:         c.String("rpc_url"),
+		Signer:          nodeSigner,
+		ContractAddress: c.String("contract_address"),
+	}
+	if err := txflags.Apply(c, &cfg.Tx); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// dialReadOnly dials registry without key
+func dialReadOnly(c *cli.Context) (*registry.Client, error) {
+	if c.String("contract_address") == "" {
+		return nil, errContractAddressRequired
+	}
+
+	return registry.Dial(context.Background(), &registry.Config{
+		DialURI:         c.String("rpc_url"),
+		ContractAddress: c.String("contract_address"),
+	})
+}
+
+func getResolver(client *registry.Client, publicKey []byte) (resolverView, error) {
+	ip, err := client.GetResolver(publicKey)
+	if err != nil {
+		return resolverView{}, err
+	}
+
+	owner, err := client.GetResolverOwner(publicKey)
+	if err != nil {
+		return resolverView{}, err
+	}
+
+	stake, err := client.GetResolverStake(publicKey)
+	if err != nil {
+		return resolverView{}, err
+	}
+
+	capabilities, err := client.GetResolverCapabilities(publicKey)
+	if err != nil {
+		return resolverView{}, err
+	}
+
+	view := newResolverView(contracts.NodeRegistryResolverInfo{
+		PublicKey:    publicKey,
+		Ip:           ip,
+		Owner:        owner,
+		Stake:        stake.Stake,
+		Capabilities: capabilities,
+	})
+	if stake.Unbonding.Sign() > 0 {
+		view.Unbonding = stake.Unbonding.String()
+		view.AvailableAt = stake.AvailableAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+
+	return view, nil
+}
+
+// publicKeyByFlag returns public key from flag or compressed public key of the signing key
+func publicKeyByFlag(c *cli.Context, nodeSigner signer.Signer) ([]byte, error) {
+	if c.String("public_key") == "" {
+		return signer.CompressedPublicKey(nodeSigner), nil
+	}
+
+	return requiredPublicKey(c)
+}
+
+func requiredPublicKey(c *cli.Context) ([]byte, error) {
+	publicKeyHex := c.String("public_key")
+	if publicKeyHex == "" {
+		return nil, errNodeRequired
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(publicKeyHex, "0x"))
+}
+
+func requiredRelayer(c *cli.Context) (common.Address, error) {
+	if c.String("relayer") == "" {
+		return common.Address{}, errRelayerRequired
+	}
+
+	return addressByFlag(c.String("relayer"))
+}
+
+func addressByFlag(address string) (common.Address, error) {
+	if !common.IsHexAddress(address) {
+		return common.Address{}, errInvalidAddress
+	}
+
+	return common.HexToAddress(address), nil
+}
+
+func capabilitiesByFlags(c *cli.Context) (contracts.NodeRegistryCapabilities, error) {
+	chainIds := make([]*big.Int, 0, len(c.StringSlice("chain_ids")))
+	for _, chainIdFlag := range c.StringSlice("chain_ids") {
+		for _, chainIdString := range strings.Split(chainIdFlag, ",") {
+			chainId, err := strconv.ParseUint(strings.TrimSpace(chainIdString), 10, 64)
+			if err != nil {
+				return contracts.NodeRegistryCapabilities{}, errInvalidChainId
+			}
+			chainIds = append(chainIds, new(big.Int).SetUint64(chainId))
+		}
+	}
+
+	var methods []string
+	for _, methodsFlag := range c.StringSlice("methods") {
+		for _, method := range strings.Split(methodsFlag, ",") {
+			methods = append(methods, strings.TrimSpace(method))
+		}
+	}
+
+	return contracts.NodeRegistryCapabilities{
+		Methods:         methods,
+		ChainIds:        chainIds,
+		ProtocolVersion: uint32(c.Uint("protocol_version")),
+		Encrypted:       c.Bool("encrypted"),
+	}, nil
+}
+
+// newLogger returns logger which writes to stderr, so stdout has only command output
+func newLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, nil))
+}
+
+func connectionFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:   "rpc_url",
+			Value:  "http://127.0.0.1:8545",
+			Usage:  "Ethereum node RPC endpoint",
+			EnvVar: "REGISTRY_RPC_URL",
+		},
+		&cli.StringFlag{
+			Name:   "contract_address",
+			Usage:  "Node registry contract address",
+			EnvVar: "REGISTRY_CONTRACT_ADDRESS",
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Value: outputTable,
+			Usage: "Output format: table or json",
+		},
+	}
+}
+
+// txFlags are flags of transaction policy and dry run
+func txFlags() []cli.Flag {
+	return joinFlags(txflags.Flags, []cli.Flag{txflags.DryRun})
+}
+
+func joinFlags(groups ...[]cli.Flag) []cli.Flag {
+	var flags []cli.Flag
+	for _, group := range groups {
+		flags = append(flags, group...)
+	}
+	return flags
+}
+
+// keyFlags are connection flags with signing key
+func keyFlags() []cli.Flag {
+	return append(connectionFlags(),
+		&cli.StringFlag{
+			Name:   "private_key",
+			Usage:  "Secp256k1 private key of the signing account in hex",
+			EnvVar: "REGISTRY_PRIVATE_KEY",
+		},
+		&cli.StringFlag{
+			Name:  "keystore",
+			Usage: "Path to encrypted keystore file with signing key, takes precedence over private key",
+		},
+		&cli.StringFlag{
+			Name:  "keystore_passphrase_file",
+			Usage: "Path to file with keystore passphrase",
+		},
+		&cli.StringFlag{
+			Name:  "keystore_passphrase_env",
+			Usage: "Name of environment variable with keystore passphrase",
+		},
+	)
+}
+
+var (
+	ipFlag = &cli.StringFlag{
+		Name:  "ip",
+		Usage: "Node endpoint",
+	}
+	publicKeyFlag = &cli.StringFlag{
+		Name:  "public_key",
+		Usage: "Compressed secp256k1 public key of resolver in hex",
+	}
+	relayerFlag = &cli.StringFlag{
+		Name:  "relayer",
+		Usage: "Address of the account which registered relayer",
+	}
+	capabilitiesFlags = []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "methods",
+			Usage: "Methods served by resolver, comma separated or repeated",
+		},
+		&cli.StringSliceFlag{
+			Name:  "chain_ids",
+			Usage: "Chain IDs served by resolver, comma separated or repeated",
+		},
+		&cli.UintFlag{
+			Name:  "protocol_version",
+			Usage: "Protocol version of resolver, capabilities are not published if zero",
+		},
+		&cli.BoolFlag{
+			Name:  "encrypted",
+			Usage: "Resolver accepts encrypted requests",
+		},
+	}
+)
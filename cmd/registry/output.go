@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/1inch/p2p-network/contracts"
+	"github.com/urfave/cli"
+)
+
+const (
+	outputTable = "table"
+	outputJson  = "json"
+)
+
+type deploymentView struct {
+	Address string `json:"address"`
+	Owner   string `json:"owner"`
+}
+
+type relayerView struct {
+	Owner     string `json:"owner"`
+	IP        string `json:"ip"`
+	Region    string `json:"region"`
+	Capacity  uint32 `json:"capacity"`
+	PublicKey string `json:"publicKey"`
+}
+
+type resolverView struct {
+	PublicKey string `json:"publicKey"`
+	IP        string `json:"ip"`
+	Owner     string `json:"owner"`
+	// Stake is stake in wei, it's string so big values are not rounded by JSON parsers
+	Stake           string   `json:"stake"`
+	Unbonding       string   `json:"unbonding,omitempty"`
+	AvailableAt     string   `json:"availableAt,omitempty"`
+	Methods         []string `json:"methods"`
+	ChainIds        []string `json:"chainIds"`
+	ProtocolVersion uint32   `json:"protocolVersion"`
+	Encrypted       bool     `json:"encrypted"`
+}
+
+type nodesView struct {
+	Relayers  []relayerView  `json:"relayers,omitempty"`
+	Resolvers []resolverView `json:"resolvers,omitempty"`
+}
+
+func relayerViews(relayers []contracts.NodeRegistryRelayer) []relayerView {
+	views := make([]relayerView, len(relayers))
+	for i, relayer := range relayers {
+		views[i] = relayerView{
+			Owner:     relayer.Owner.Hex(),
+			IP:        relayer.Ip,
+			Region:    relayer.Region,
+			Capacity:  relayer.Capacity,
+			PublicKey: hex.EncodeToString(relayer.PublicKey),
+		}
+	}
+	return views
+}
+
+func resolverViews(resolvers []contracts.NodeRegistryResolverInfo) []resolverView {
+	views := make([]resolverView, len(resolvers))
+	for i, resolver := range resolvers {
+		views[i] = newResolverView(resolver)
+	}
+	return views
+}
+
+func newResolverView(resolver contracts.NodeRegistryResolverInfo) resolverView {
+	chainIds := make([]string, len(resolver.Capabilities.ChainIds))
+	for i, chainId := range resolver.Capabilities.ChainIds {
+		chainIds[i] = chainId.String()
+	}
+
+	methods := resolver.Capabilities.Methods
+	if methods == nil {
+		methods = []string{}
+	}
+
+	return resolverView{
+		PublicKey:       hex.EncodeToString(resolver.PublicKey),
+		IP:              resolver.Ip,
+		Owner:           resolver.Owner.Hex(),
+		Stake:           resolver.Stake.String(),
+		Methods:         methods,
+		ChainIds:        chainIds,
+		ProtocolVersion: resolver.Capabilities.ProtocolVersion,
+		Encrypted:       resolver.Capabilities.Encrypted,
+	}
+}
+
+func printDeployment(c *cli.Context, deployment deploymentView) error {
+	return printOutput(c, deployment, func(w io.Writer) {
+		fmt.Fprintln(w, "ADDRESS\tOWNER")
+		fmt.Fprintf(w, "%s\t%s\n", deployment.Address, deployment.Owner)
+	})
+}
+
+func printNodes(c *cli.Context, nodes nodesView) error {
+	return printOutput(c, nodes, func(w io.Writer) {
+		if nodes.Relayers != nil {
+			fmt.Fprintln(w, "RELAYER OWNER\tIP\tREGION\tCAPACITY\tPUBLIC KEY")
+			for _, relayer := range nodes.Relayers {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", relayer.Owner, relayer.IP, relayer.Region, relayer.Capacity, relayer.PublicKey)
+			}
+		}
+
+		if nodes.Relayers != nil && nodes.Resolvers != nil {
+			fmt.Fprintln(w)
+		}
+
+		if nodes.Resolvers != nil {
+			fmt.Fprintln(w, "RESOLVER PUBLIC KEY\tIP\tOWNER\tSTAKE\tUNBONDING\tMETHODS\tCHAIN IDS\tVERSION\tENCRYPTED")
+			for _, resolver := range nodes.Resolvers {
+				unbonding := "-"
+				if resolver.Unbonding != "" {
+					unbonding = resolver.Unbonding + " until " + resolver.AvailableAt
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%t\n", resolver.PublicKey, resolver.IP, resolver.Owner, resolver.Stake, unbonding,
+					orDash(strings.Join(resolver.Methods, ",")), orDash(strings.Join(resolver.ChainIds, ",")), resolver.ProtocolVersion, resolver.Encrypted)
+			}
+		}
+	})
+}
+
+// printOutput writes value as JSON or as table which is written by printTable
+func printOutput(c *cli.Context, value any, printTable func(w io.Writer)) error {
+	switch c.String("output") {
+	case outputJson:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case outputTable:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		printTable(w)
+		return w.Flush()
+	default:
+		return errInvalidOutput
+	}
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -10,6 +10,9 @@ import (
 
 	"github.com/1inch/p2p-network/internal/configs"
 	"github.com/1inch/p2p-network/internal/log"
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/1inch/p2p-network/internal/txflags"
 	"github.com/1inch/p2p-network/relayer"
 	"github.com/urfave/cli"
 )
@@ -22,13 +25,13 @@ func main() {
 			{
 				Name:  "run",
 				Usage: "Runs the relayer node",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:     "config",
 						Usage:    "Path to the configuration file",
 						Required: true,
 					},
-				},
+				}, txflags.Flags...),
 				Action: func(c *cli.Context) error {
 					leveler := new(slog.LevelVar)
 					leveler.Set(slog.LevelInfo)
@@ -49,6 +52,11 @@ func main() {
 					}
 					leveler.Set(logLevel)
 
+					if err := txflags.Apply(c, &cfg.DiscoveryConfig.Transaction); err != nil {
+						logger.Error("invalid transaction flags", slog.Any("err", err))
+						return err
+					}
+
 					logger.Info("config file loaded", slog.String("path", configPath))
 
 					node, err := relayer.New(cfg, logger)
@@ -76,6 +84,89 @@ func main() {
 					return nil
 				},
 			},
+			{
+				Name:  "deregister",
+				Usage: "Removes the relayer node from node registry",
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:     "config",
+						Usage:    "Path to the configuration file",
+						Required: true,
+					},
+					txflags.DryRun,
+				}, txflags.Flags...),
+				Action: func(c *cli.Context) error {
+					handler := slog.NewTextHandler(os.Stdout, nil)
+					logger := slog.New(handler)
+
+					configPath := c.String("config")
+					cfg, err := configs.LoadConfig[relayer.Config](configPath)
+					if err != nil {
+						logger.Error("failed to load relayer node configuration", slog.String("path", configPath), slog.Any("err", err))
+						return err
+					}
+
+					if err := txflags.Apply(c, &cfg.DiscoveryConfig.Transaction); err != nil {
+						logger.Error("invalid transaction flags", slog.Any("err", err))
+						return err
+					}
+
+					node := &relayer.Relayer{Config: cfg, Logger: logger}
+					err = node.DeregisterRelayer(context.Background())
+					if txflags.LogDryRun(logger, "relayer deregistration", err) {
+						return nil
+					}
+					if err != nil {
+						logger.Error("failed to deregister relayer node", slog.Any("err", err))
+						return err
+					}
+
+					logger.Info("relayer node deregistered")
+					return nil
+				},
+			},
+			{
+				Name:  "sign_node_list",
+				Usage: "Signs node list file with the relayer key, signature is written to the node list path with .sig suffix",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "config",
+						Usage:    "Path to the configuration file",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "node_list",
+						Usage:    "Path to the node list file",
+						Required: true,
+					},
+				},
+				Action: func(c *cli.Context) error {
+					handler := slog.NewTextHandler(os.Stdout, nil)
+					logger := slog.New(handler)
+
+					configPath := c.String("config")
+					cfg, err := configs.LoadConfig[relayer.Config](configPath)
+					if err != nil {
+						logger.Error("failed to load relayer node configuration", slog.String("path", configPath), slog.Any("err", err))
+						return err
+					}
+
+					nodeSigner, err := signer.New(cfg.PrivateKey, cfg.Keystore)
+					if err != nil {
+						logger.Error("failed to load relayer key", slog.Any("err", err))
+						return err
+					}
+
+					nodeListPath := c.String("node_list")
+					if err := registry.SignNodeListFile(nodeSigner, nodeListPath); err != nil {
+						logger.Error("failed to sign node list", slog.String("path", nodeListPath), slog.Any("err", err))
+						return err
+					}
+
+					logger.Info("node list signed", slog.String("path", nodeListPath), slog.String("signer", nodeSigner.Address().Hex()))
+					return nil
+				},
+			},
 		},
 	}
 
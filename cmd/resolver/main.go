@@ -3,22 +3,32 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"log/slog"
+	"math/big"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/1inch/p2p-network/internal/configs"
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/txflags"
 	"github.com/1inch/p2p-network/resolver"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
 )
 
 var (
 	errContractAddressRequired = errors.New("contract address required")
 	errRpcUrlRequired          = errors.New("rpc url required")
 	errGrpcEndpointRequired    = errors.New("grpc endpoint required")
-	errPrivateKeyRequired      = errors.New("private key required")
+	errPrivateKeyRequired      = errors.New("private key or keystore required")
+	errConfigFileRequired      = errors.New("config file required")
+	errNewPublicKeyRequired    = errors.New("new public key required")
+	errAmountRequired          = errors.New("amount required")
 )
 
 // TODO: setup cli interface
@@ -38,7 +48,7 @@ func main() {
 					&cli.StringFlag{
 						Name:  "api",
 						Value: "default",
-						Usage: "Supported API (default,infura)",
+						Usage: "Supported APIs, comma separated (default,infura,1inch)",
 					},
 
 					&cli.StringFlag{
@@ -46,6 +56,9 @@ func main() {
 						Value: "",
 						Usage: "Secp256k1 private key in hex",
 					},
+					keystoreFlag,
+					keystorePassphraseFileFlag,
+					keystorePassphraseEnvFlag,
 					&cli.StringFlag{
 						Name:   "infura_key",
 						Value:  "",
@@ -81,20 +94,23 @@ func main() {
 					if len(privateKey) > 0 {
 						cfg.PrivateKey = privateKey
 					}
+					setKeystoreByFlags(c, &cfg)
 					// Override config file value for apis
 					if !isApiHandlerSet(&cfg) {
 						api := c.String("api")
 						if len(api) > 0 {
 							var apiConfigs resolver.ApiConfigs
-							switch api {
-							case "default":
-								apiConfigs.Default.Enabled = true
-							case "infura":
-								apiConfigs.Infura.Enabled = true
-								apiConfigs.Infura.Key = c.String("infura_key")
-							case "1inch":
-								apiConfigs.OneInch.Enabled = true
-								apiConfigs.OneInch.Key = c.String("1inch_key")
+							for _, api := range strings.Split(api, ",") {
+								switch strings.TrimSpace(api) {
+								case "default":
+									apiConfigs.Default.Enabled = true
+								case "infura":
+									apiConfigs.Infura.Enabled = true
+									apiConfigs.Infura.Key = c.String("infura_key")
+								case "1inch":
+									apiConfigs.OneInch.Enabled = true
+									apiConfigs.OneInch.Key = c.String("1inch_key")
+								}
 							}
 							cfg.Apis = apiConfigs
 						}
@@ -118,6 +134,15 @@ func main() {
 				},
 			},
 			cliCommandRegister(),
+			cliCommandRotateKey(),
+			cliCommandUpdate(),
+			cliCommandUpdateCapabilities(),
+			cliCommandDeregister(),
+			cliCommandStake(),
+			cliCommandUnstake(),
+			cliCommandWithdrawStake(),
+			cliCommandNodeListEntry(),
+			cliCommandSettle(),
 		},
 	}
 	err := app.Run(os.Args)
@@ -130,7 +155,7 @@ func cliCommandRegister() cli.Command {
 	return cli.Command{
 		Name:  "register",
 		Usage: "Register resolver in node registry",
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			&cli.StringFlag{
 				Name:  "rpc_url",
 				Usage: "rpc url to blockchain node",
@@ -143,15 +168,22 @@ func cliCommandRegister() cli.Command {
 				Name:  "private_key",
 				Usage: "account private key in hex which pay fee for register resolver",
 			},
+			keystoreFlag,
+			keystorePassphraseFileFlag,
+			keystorePassphraseEnvFlag,
 			&cli.StringFlag{
 				Name:  "grpc_endpoint",
 				Usage: "this endpoint will set for resolver node",
 			},
+			&cli.StringFlag{
+				Name:  "stake",
+				Usage: "stake in wei which is sent on registration, overrides stake from config file",
+			},
 			&cli.StringFlag{
 				Name:  "config_file",
 				Usage: "Path to the configuration file",
 			},
-		},
+		}, append(txflags.Flags, txflags.DryRun)...),
 		Action: func(c *cli.Context) error {
 			loggerHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 				Level: slog.LevelInfo,
@@ -187,14 +219,23 @@ func cliCommandRegister() cli.Command {
 					return errGrpcEndpointRequired
 				}
 
+				setKeystoreByFlags(c, cfg)
 				privKey := c.String("private_key")
 				if privKey != "" {
 					cfg.PrivateKey = privKey
-				} else {
+				} else if cfg.Keystore.Path == "" {
 					return errPrivateKeyRequired
 				}
 			}
 
+			if stake := c.String("stake"); stake != "" {
+				cfg.Stake = stake
+			}
+
+			if err := txflags.Apply(c, &cfg.Transaction); err != nil {
+				return err
+			}
+
 			regResolver, err := resolver.NewRegistrationResolver(logger, cfg)
 			if err != nil {
 				logger.Info("error when try create registration resolver", slog.Any("err", err.Error()))
@@ -202,16 +243,327 @@ func cliCommandRegister() cli.Command {
 			}
 
 			txHash, err := regResolver.Register(context.Background())
+			return reportTx(logger, "registration new resolver", txHash, err)
+		},
+	}
+}
+
+func cliCommandRotateKey() cli.Command {
+	return cli.Command{
+		Name:  "rotate_key",
+		Usage: "Replace resolver public key in node registry, key from config file is used as old key",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+			&cli.StringFlag{
+				Name:  "new_public_key",
+				Usage: "New compressed secp256k1 public key of resolver in hex",
+			},
+		}, append(txflags.Flags, txflags.DryRun)...),
+		Action: func(c *cli.Context) error {
+			newPublicKeyHex := c.String("new_public_key")
+			if newPublicKeyHex == "" {
+				return errNewPublicKeyRequired
+			}
+			newPublicKey, err := hex.DecodeString(newPublicKeyHex)
 			if err != nil {
 				return err
 			}
 
-			logger.Info("tx hash for registration new resolver", slog.Any("tx-hash", txHash))
-			return nil
+			return sendRegistryTx(c, "rotation resolver key", func(r *resolver.RegistrationResolver) (*common.Hash, error) {
+				return r.RotateKey(context.Background(), newPublicKey)
+			})
+		},
+	}
+}
+
+func cliCommandUpdate() cli.Command {
+	return cli.Command{
+		Name:  "update",
+		Usage: "Update resolver endpoint in node registry to grpc_endpoint from config file",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+		}, append(txflags.Flags, txflags.DryRun)...),
+		Action: func(c *cli.Context) error {
+			return sendRegistryTx(c, "update resolver", func(r *resolver.RegistrationResolver) (*common.Hash, error) {
+				return r.Update(context.Background())
+			})
 		},
 	}
 }
 
+func cliCommandUpdateCapabilities() cli.Command {
+	return cli.Command{
+		Name:  "update_capabilities",
+		Usage: "Update resolver capabilities in node registry to capabilities of api handler from config file",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+		}, append(txflags.Flags, txflags.DryRun)...),
+		Action: func(c *cli.Context) error {
+			return sendRegistryTx(c, "update resolver capabilities", func(r *resolver.RegistrationResolver) (*common.Hash, error) {
+				return r.UpdateCapabilities(context.Background())
+			})
+		},
+	}
+}
+
+func cliCommandDeregister() cli.Command {
+	return cli.Command{
+		Name:  "deregister",
+		Usage: "Remove resolver from node registry",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+		}, append(txflags.Flags, txflags.DryRun)...),
+		Action: func(c *cli.Context) error {
+			return sendRegistryTx(c, "deregister resolver", func(r *resolver.RegistrationResolver) (*common.Hash, error) {
+				return r.Deregister(context.Background())
+			})
+		},
+	}
+}
+
+func cliCommandStake() cli.Command {
+	return cli.Command{
+		Name:  "stake",
+		Usage: "Add amount to resolver stake in node registry",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+			amountFlag,
+		}, append(txflags.Flags, txflags.DryRun)...),
+		Action: func(c *cli.Context) error {
+			amount, err := amountByFlag(c)
+			if err != nil {
+				return err
+			}
+
+			return sendRegistryTx(c, "stake resolver", func(r *resolver.RegistrationResolver) (*common.Hash, error) {
+				return r.Stake(context.Background(), amount)
+			})
+		},
+	}
+}
+
+func cliCommandUnstake() cli.Command {
+	return cli.Command{
+		Name:  "unstake",
+		Usage: "Start unbonding of amount of resolver stake in node registry",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+			amountFlag,
+		}, append(txflags.Flags, txflags.DryRun)...),
+		Action: func(c *cli.Context) error {
+			amount, err := amountByFlag(c)
+			if err != nil {
+				return err
+			}
+
+			return sendRegistryTx(c, "unstake resolver", func(r *resolver.RegistrationResolver) (*common.Hash, error) {
+				return r.Unstake(context.Background(), amount)
+			})
+		},
+	}
+}
+
+func cliCommandWithdrawStake() cli.Command {
+	return cli.Command{
+		Name:  "withdraw_stake",
+		Usage: "Withdraw unbonded resolver stake from node registry",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+		}, append(txflags.Flags, txflags.DryRun)...),
+		Action: func(c *cli.Context) error {
+			return sendRegistryTx(c, "withdraw resolver stake", func(r *resolver.RegistrationResolver) (*common.Hash, error) {
+				return r.WithdrawStake(context.Background())
+			})
+		},
+	}
+}
+
+func cliCommandNodeListEntry() cli.Command {
+	return cli.Command{
+		Name:  "node_list_entry",
+		Usage: "Print resolver entry for node list file which is used by relayers instead of node registry",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+				Level: slog.LevelWarn,
+			}))
+
+			cfg := loadConfigByPath(c.String("config_file"))
+			if cfg == nil {
+				return errConfigFileRequired
+			}
+
+			entry, err := resolver.NodeListEntry(logger, cfg)
+			if err != nil {
+				return err
+			}
+
+			encoder := yaml.NewEncoder(os.Stdout)
+			defer encoder.Close()
+			return encoder.Encode([]registry.ResolverEntry{entry})
+		},
+	}
+}
+
+func cliCommandSettle() cli.Command {
+	return cli.Command{
+		Name:  "settle",
+		Usage: "Settle payment vouchers from vouchers file in payment channel contract",
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "config_file",
+				Usage: "Path to the configuration file",
+			},
+			&cli.StringFlag{
+				Name:  "min_payout",
+				Usage: "Min payout of settlement in wei, vouchers with less payout are settled later",
+			},
+		}, append(txflags.Flags, txflags.DryRun)...),
+		Action: func(c *cli.Context) error {
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+				Level: slog.LevelInfo,
+			}))
+
+			cfg := loadConfigByPath(c.String("config_file"))
+			if cfg == nil {
+				return errConfigFileRequired
+			}
+			if err := txflags.Apply(c, &cfg.Transaction); err != nil {
+				return err
+			}
+
+			var minPayout *big.Int
+			if value := c.String("min_payout"); value != "" {
+				var err error
+				if minPayout, err = txflags.ParseWei(value); err != nil {
+					return err
+				}
+			}
+
+			settlements, err := resolver.SettleVouchers(context.Background(), logger, cfg, minPayout)
+			var errs []error
+			for _, settlement := range settlements {
+				name := "settle voucher of " + settlement.Payer.Hex()
+				if settlement.Err == nil {
+					logger.Info("voucher settled", slog.String("payer", settlement.Payer.Hex()), slog.String("payout", settlement.Payout.String()))
+				}
+				errs = append(errs, reportTx(logger, name, &settlement.TxHash, settlement.Err))
+			}
+			if len(settlements) == 0 && err == nil {
+				logger.Info("no vouchers to settle")
+			}
+
+			return errors.Join(append(errs, err)...)
+		},
+	}
+}
+
+func amountByFlag(c *cli.Context) (*big.Int, error) {
+	amount := c.String("amount")
+	if amount == "" {
+		return nil, errAmountRequired
+	}
+
+	return resolver.ParseWei(amount)
+}
+
+// sendRegistryTx sends node registry transaction with resolver key and config from config file
+func sendRegistryTx(c *cli.Context, name string, send func(r *resolver.RegistrationResolver) (*common.Hash, error)) error {
+	loggerHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelInfo,
+	})
+	logger := slog.New(loggerHandler)
+
+	cfg := loadConfigByPath(c.String("config_file"))
+	if cfg == nil {
+		return errConfigFileRequired
+	}
+
+	if err := txflags.Apply(c, &cfg.Transaction); err != nil {
+		return err
+	}
+
+	regResolver, err := resolver.NewRegistrationResolver(logger, cfg)
+	if err != nil {
+		logger.Info("error when try create registration resolver", slog.Any("err", err.Error()))
+		return err
+	}
+
+	txHash, err := send(regResolver)
+	return reportTx(logger, name, txHash, err)
+}
+
+// reportTx logs hash of mined transaction or estimate of transaction in dry run
+func reportTx(logger *slog.Logger, name string, txHash *common.Hash, err error) error {
+	if txflags.LogDryRun(logger, name, err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("tx hash for "+name, slog.Any("tx-hash", txHash))
+	return nil
+}
+
+var (
+	amountFlag = &cli.StringFlag{
+		Name:  "amount",
+		Usage: "Amount of stake in wei",
+	}
+	keystoreFlag = &cli.StringFlag{
+		Name:  "keystore",
+		Usage: "Path to encrypted keystore file with node key, takes precedence over private key",
+	}
+	keystorePassphraseFileFlag = &cli.StringFlag{
+		Name:  "keystore_passphrase_file",
+		Usage: "Path to file with keystore passphrase",
+	}
+	keystorePassphraseEnvFlag = &cli.StringFlag{
+		Name:  "keystore_passphrase_env",
+		Usage: "Name of environment variable with keystore passphrase",
+	}
+)
+
+// setKeystoreByFlags overrides config file value for keystore
+func setKeystoreByFlags(c *cli.Context, cfg *resolver.Config) {
+	if path := c.String(keystoreFlag.Name); path != "" {
+		cfg.Keystore.Path = path
+	}
+	if passphraseFile := c.String(keystorePassphraseFileFlag.Name); passphraseFile != "" {
+		cfg.Keystore.PassphraseFile = passphraseFile
+	}
+	if passphraseEnv := c.String(keystorePassphraseEnvFlag.Name); passphraseEnv != "" {
+		cfg.Keystore.PassphraseEnv = passphraseEnv
+	}
+}
+
 func loadConfigByPath(configPath string) *resolver.Config {
 	if configPath != "" {
 		cfgFromFile, err := configs.LoadConfig[resolver.Config](configPath)
@@ -235,5 +587,5 @@ func setupLogger(cfg resolver.Config) *slog.Logger {
 }
 
 func isApiHandlerSet(cfg *resolver.Config) bool {
-	return cfg.Apis.Default.Enabled || cfg.Apis.Infura.Enabled || cfg.Apis.OneInch.Enabled
+	return cfg.Apis.Default.Enabled || cfg.Apis.Infura.Enabled || cfg.Apis.OneInch.Enabled || cfg.Apis.Evm.Enabled || cfg.Apis.TxRelay.Enabled || len(cfg.Apis.Custom) > 0
 }
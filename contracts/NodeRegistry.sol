@@ -2,53 +2,590 @@
 pragma solidity ^0.8.0;
 
 contract NodeRegistry {
+
+    // secp256k1 field modulus
+    uint256 private constant FIELD_MODULUS = 0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f;
+
     struct Resolver {
         string ip;
+        // for rotated key: time until which key is still resolved
+        uint256 validUntil;
+        // account which registered the resolver
+        address owner;
+        // stake of the resolver in wei, can be slashed
+        uint256 stake;
+    }
+
+    struct Capabilities {
+        // methods of resolver API
+        string[] methods;
+        // chain IDs served by the resolver, empty if requests are not chain specific
+        uint256[] chainIds;
+        // version of resolver request protocol, zero if capabilities are not published
+        uint32 protocolVersion;
+        // whether the resolver accepts encrypted requests
+        bool encrypted;
+    }
+
+    struct ResolverInfo {
+        bytes publicKey;
+        string ip;
+        address owner;
+        uint256 stake;
+        Capabilities capabilities;
+    }
+
+    struct Unbonding {
+        // account which can withdraw the stake
+        address owner;
+        uint256 amount;
+        // time after which the stake can be withdrawn
+        uint256 availableAt;
+    }
+
+    struct Relayer {
+        string ip;
+        // region hint for clients, e.g. "eu-west"
+        string region;
+        // max count of client connections
+        uint32 capacity;
+        bytes publicKey;
+        // account which registered the relayer, every approved account can register one relayer
+        address owner;
     }
 
-    string private relayerIP;
+    address public owner;
+
+    /// @notice Min stake required to register resolver, zero if stake is not required
+    uint256 public minResolverStake;
+
+    /// @notice Delay between unstake and withdrawal, stake can still be slashed during it
+    uint256 public unbondingPeriod = 7 days;
+
+    /// @notice Period during which rotated resolver key is still resolved
+    uint256 public keyRotationGracePeriod = 1 hours;
+
+    /// @notice Accounts which can slash resolver stakes in addition to the owner
+    mapping(address => bool) public slashers;
+
+    /// @notice Accounts which can register relayer in addition to the owner
+    mapping(address => bool) public approvedRelayers;
+
+    /// @notice Owner of the relayer returned by getRelayer, zero address if not set
+    address public primaryRelayer;
+
+    mapping(address => Relayer) private relayers;
+
+    // owners of relayers in order of registration
+    address[] private relayerOwners;
 
     mapping(bytes => Resolver) private resolvers;
 
     bytes[] private resolverKeys;
 
-    /// @notice Register the relayer node with its IP
+    mapping(bytes => Unbonding) private unbondings;
+
+    mapping(bytes => Capabilities) private capabilities;
+
+    event RelayerRegistered(address indexed owner, string ip);
+    event RelayerRemoved(address indexed owner);
+    event RelayerApprovalUpdated(address indexed relayerOwner, bool approved);
+    event PrimaryRelayerUpdated(address indexed relayerOwner);
+    event ResolverRegistered(bytes publicKey, address indexed owner, string ip);
+    event ResolverUpdated(bytes publicKey, string ip);
+    event ResolverRemoved(bytes publicKey);
+    event ResolverKeyRotated(bytes oldPublicKey, bytes newPublicKey, uint256 validUntil);
+    event ResolverCapabilitiesUpdated(bytes publicKey);
+    event ResolverStaked(bytes publicKey, uint256 amount, uint256 stake);
+    event ResolverUnstaked(bytes publicKey, uint256 amount, uint256 availableAt);
+    event ResolverStakeWithdrawn(bytes publicKey, address indexed owner, uint256 amount);
+    event ResolverSlashed(bytes publicKey, address indexed slasher, uint256 amount);
+    event StakeConfigUpdated(uint256 minResolverStake, uint256 unbondingPeriod);
+    event SlasherUpdated(address indexed slasher, bool allowed);
+    event KeyRotationGracePeriodUpdated(uint256 period);
+
+    constructor() {
+        owner = msg.sender;
+    }
+
+    /// @notice Register the relayer node of the sender, registered relayer is updated
+    /// @dev Must be called by the owner or an approved account
     /// @param ip The IP address of the relayer node
-    function registerRelayer(string calldata ip) external {
+    /// @param region The region of the relayer node
+    /// @param capacity The max count of client connections of the relayer node
+    /// @param publicKey The public key of the relayer node as bytes
+    function registerRelayer(string calldata ip, string calldata region, uint32 capacity, bytes calldata publicKey) external {
         require(bytes(ip).length > 0, "Relayer IP cannot be empty");
-        relayerIP = ip;
+        require(msg.sender == owner || approvedRelayers[msg.sender], "Relayer not approved");
+        if (relayers[msg.sender].owner == address(0)) {
+            relayerOwners.push(msg.sender);
+        }
+
+        relayers[msg.sender] = Relayer({
+            ip: ip,
+            region: region,
+            capacity: capacity,
+            publicKey: publicKey,
+            owner: msg.sender
+        });
+
+        emit RelayerRegistered(msg.sender, ip);
+    }
+
+    /// @notice Remove the relayer node, order of other relayers is kept
+    /// @dev Must be called by the owner of the relayer or the owner of the contract
+    /// @param relayerOwner The account which registered the relayer node
+    function deregisterRelayer(address relayerOwner) external {
+        require(relayers[relayerOwner].owner != address(0), "Relayer not found");
+        require(msg.sender == relayerOwner || msg.sender == owner, "Not authorized");
+
+        removeRelayer(relayerOwner);
+    }
+
+    /// @notice Allow or forbid an account to register relayer, relayer of forbidden account is removed
+    /// @dev Must be called by the owner
+    /// @param relayerOwner The account of the relayer
+    /// @param approved Whether the account can register relayer
+    function setRelayerApproval(address relayerOwner, bool approved) external {
+        require(msg.sender == owner, "Not authorized");
+
+        approvedRelayers[relayerOwner] = approved;
+
+        emit RelayerApprovalUpdated(relayerOwner, approved);
+
+        if (!approved && relayers[relayerOwner].owner != address(0)) {
+            removeRelayer(relayerOwner);
+        }
     }
 
-    /// @notice Register a resolver node with its IP and public key
+    /// @notice Choose the relayer returned by getRelayer
+    /// @dev Must be called by the owner, primary relayer is unset when it is removed
+    /// @param relayerOwner The account which registered the relayer node, zero address unsets primary relayer
+    function setPrimaryRelayer(address relayerOwner) external {
+        require(msg.sender == owner, "Not authorized");
+        require(relayerOwner == address(0) || relayers[relayerOwner].owner != address(0), "Relayer not found");
+
+        primaryRelayer = relayerOwner;
+
+        emit PrimaryRelayerUpdated(relayerOwner);
+    }
+
+    /// @notice Register a resolver node with its IP, public key and capabilities, sent value is staked
+    /// @dev Rotated key can be registered again after its grace period
     /// @param ip The IP address of the resolver node
     /// @param publicKey The public key of the resolver node as bytes
-    function registerResolver(string calldata ip, bytes calldata publicKey) external {
+    /// @param resolverCapabilities The requests served by the resolver node
+    function registerResolver(
+        string calldata ip,
+        bytes calldata publicKey,
+        Capabilities calldata resolverCapabilities
+    ) external payable {
         require(bytes(ip).length > 0, "Resolver IP cannot be empty");
         require(publicKey.length > 0, "Public key cannot be empty");
-        require(bytes(resolvers[publicKey].ip).length == 0, "Resolver already registered");
+        require(!isRegistered(publicKey), "Resolver already registered");
+        require(msg.value >= minResolverStake, "Insufficient stake");
+        require(unbondings[publicKey].amount == 0 || unbondings[publicKey].owner == msg.sender, "Stake is unbonding");
 
         resolvers[publicKey] = Resolver({
-            ip: ip
+            ip: ip,
+            validUntil: 0,
+            owner: msg.sender,
+            stake: msg.value
         });
 
+        capabilities[publicKey] = resolverCapabilities;
         resolverKeys.push(publicKey);
+
+        emit ResolverRegistered(publicKey, msg.sender, ip);
+    }
+
+    /// @notice Change capabilities of a resolver node
+    /// @dev Must be called by the owner of the resolver or the owner of the contract
+    /// @param publicKey The public key of the resolver node as bytes
+    /// @param resolverCapabilities The requests served by the resolver node
+    function setResolverCapabilities(bytes calldata publicKey, Capabilities calldata resolverCapabilities) external {
+        Resolver storage resolver = activeResolver(publicKey);
+        require(msg.sender == resolver.owner || msg.sender == owner, "Not authorized");
+
+        capabilities[publicKey] = resolverCapabilities;
+
+        emit ResolverCapabilitiesUpdated(publicKey);
+    }
+
+    /// @notice Change the IP address of a resolver node
+    /// @dev Must be called by the owner of the resolver or the owner of the contract
+    /// @param publicKey The public key of the resolver node as bytes
+    /// @param ip The new IP address of the resolver node
+    function updateResolver(bytes calldata publicKey, string calldata ip) external {
+        require(bytes(ip).length > 0, "Resolver IP cannot be empty");
+        Resolver storage resolver = activeResolver(publicKey);
+        require(msg.sender == resolver.owner || msg.sender == owner, "Not authorized");
+
+        resolver.ip = ip;
+
+        emit ResolverUpdated(publicKey, ip);
+    }
+
+    /// @notice Change the IP address and capabilities of a resolver node in one transaction
+    /// @dev Must be called by the owner of the resolver or the owner of the contract
+    /// @param publicKey The public key of the resolver node as bytes
+    /// @param ip The new IP address of the resolver node
+    /// @param resolverCapabilities The requests served by the resolver node
+    function updateResolverWithCapabilities(
+        bytes calldata publicKey,
+        string calldata ip,
+        Capabilities calldata resolverCapabilities
+    ) external {
+        require(bytes(ip).length > 0, "Resolver IP cannot be empty");
+        Resolver storage resolver = activeResolver(publicKey);
+        require(msg.sender == resolver.owner || msg.sender == owner, "Not authorized");
+
+        resolver.ip = ip;
+        capabilities[publicKey] = resolverCapabilities;
+
+        emit ResolverUpdated(publicKey, ip);
+        emit ResolverCapabilitiesUpdated(publicKey);
+    }
+
+    /// @notice Remove a resolver node, its public key can be registered again
+    /// @dev Must be called by the owner of the resolver or the owner of the contract
+    /// @param publicKey The public key of the resolver node as bytes
+    function deregisterResolver(bytes calldata publicKey) external {
+        Resolver storage resolver = activeResolver(publicKey);
+        require(msg.sender == resolver.owner || msg.sender == owner, "Not authorized");
+
+        uint256 stake = resolver.stake;
+        address resolverOwner = resolver.owner;
+        delete resolvers[publicKey];
+        delete capabilities[publicKey];
+        removeResolverKey(publicKey);
+
+        emit ResolverRemoved(publicKey);
+
+        if (stake > 0) {
+            startUnbonding(publicKey, resolverOwner, stake);
+        }
+    }
+
+    /// @notice Replace public key of registered resolver, old key is still resolved during grace period
+    /// @dev Must be called by the owner or by the account of the old key
+    /// @param oldPublicKey The current compressed public key of the resolver node
+    /// @param newPublicKey The new compressed public key of the resolver node
+    function rotateResolverKey(bytes calldata oldPublicKey, bytes calldata newPublicKey) external {
+        Resolver storage resolver = activeResolver(oldPublicKey);
+        require(newPublicKey.length > 0, "Public key cannot be empty");
+        require(!isRegistered(newPublicKey), "Resolver already registered");
+        require(
+            msg.sender == owner || msg.sender == resolver.owner || msg.sender == keyToAddress(oldPublicKey),
+            "Not authorized"
+        );
+        require(
+            unbondings[newPublicKey].amount == 0 || unbondings[newPublicKey].owner == resolver.owner,
+            "Stake is unbonding"
+        );
+
+        uint256 validUntil = block.timestamp + keyRotationGracePeriod;
+        resolvers[newPublicKey] = Resolver({
+            ip: resolver.ip,
+            validUntil: 0,
+            owner: resolver.owner,
+            stake: resolver.stake
+        });
+        resolver.validUntil = validUntil;
+        resolver.stake = 0;
+        capabilities[newPublicKey] = capabilities[oldPublicKey];
+
+        for (uint256 i = 0; i < resolverKeys.length; i++) {
+            if (keccak256(resolverKeys[i]) == keccak256(oldPublicKey)) {
+                resolverKeys[i] = newPublicKey;
+                break;
+            }
+        }
+
+        emit ResolverKeyRotated(oldPublicKey, newPublicKey, validUntil);
+    }
+
+    /// @notice Remove rotated key after its grace period
+    /// @dev Can be called by any account, expired key is also replaced by its registration
+    /// @param publicKey The rotated public key of the resolver node
+    function pruneResolverKey(bytes calldata publicKey) external {
+        Resolver storage resolver = resolvers[publicKey];
+        require(bytes(resolver.ip).length > 0 && resolver.validUntil != 0, "Resolver key not rotated");
+        require(block.timestamp >= resolver.validUntil, "Resolver key not expired");
+
+        delete resolvers[publicKey];
+        delete capabilities[publicKey];
+
+        emit ResolverRemoved(publicKey);
+    }
+
+    /// @notice Add sent value to the stake of a resolver node
+    /// @dev Must be called by the owner of the resolver
+    /// @param publicKey The public key of the resolver node as bytes
+    function stakeResolver(bytes calldata publicKey) external payable {
+        Resolver storage resolver = activeResolver(publicKey);
+        require(msg.sender == resolver.owner, "Not authorized");
+        require(msg.value > 0, "Stake cannot be empty");
+
+        resolver.stake += msg.value;
+
+        emit ResolverStaked(publicKey, msg.value, resolver.stake);
+    }
+
+    /// @notice Start unbonding of a part of resolver stake, the rest must not be less than min stake
+    /// @dev Must be called by the owner of the resolver, unbonding period is restarted
+    /// @param publicKey The public key of the resolver node as bytes
+    /// @param amount The amount of stake in wei
+    function unstakeResolver(bytes calldata publicKey, uint256 amount) external {
+        Resolver storage resolver = activeResolver(publicKey);
+        require(msg.sender == resolver.owner, "Not authorized");
+        require(amount > 0 && amount <= resolver.stake, "Invalid amount");
+        require(resolver.stake - amount >= minResolverStake, "Stake below minimum");
+
+        resolver.stake -= amount;
+        startUnbonding(publicKey, resolver.owner, amount);
+    }
+
+    /// @notice Withdraw unbonded stake of a resolver node to the resolver owner
+    /// @dev Must be called by the owner of the resolver after unbonding period
+    /// @param publicKey The public key of the resolver node as bytes
+    function withdrawResolverStake(bytes calldata publicKey) external {
+        Unbonding memory unbonding = unbondings[publicKey];
+        require(unbonding.amount > 0, "Nothing to withdraw");
+        require(msg.sender == unbonding.owner, "Not authorized");
+        require(block.timestamp >= unbonding.availableAt, "Stake is unbonding");
+
+        delete unbondings[publicKey];
+        (bool success, ) = payable(unbonding.owner).call{value: unbonding.amount}("");
+        require(success, "Transfer failed");
+
+        emit ResolverStakeWithdrawn(publicKey, unbonding.owner, unbonding.amount);
+    }
+
+    /// @notice Slash stake of a resolver node, unbonding stake is slashed after active stake
+    /// @dev Must be called by the owner or a slasher, slashed value is sent to the owner
+    /// @param publicKey The public key of the resolver node as bytes
+    /// @param amount The max amount of stake in wei
+    function slash(bytes calldata publicKey, uint256 amount) external {
+        require(msg.sender == owner || slashers[msg.sender], "Not authorized");
+
+        Resolver storage resolver = resolvers[publicKey];
+        uint256 fromStake = amount < resolver.stake ? amount : resolver.stake;
+        resolver.stake -= fromStake;
+
+        Unbonding storage unbonding = unbondings[publicKey];
+        uint256 fromUnbonding = amount - fromStake < unbonding.amount ? amount - fromStake : unbonding.amount;
+        unbonding.amount -= fromUnbonding;
+
+        uint256 slashed = fromStake + fromUnbonding;
+        require(slashed > 0, "Nothing to slash");
+
+        (bool success, ) = payable(owner).call{value: slashed}("");
+        require(success, "Transfer failed");
+
+        emit ResolverSlashed(publicKey, msg.sender, slashed);
+    }
+
+    /// @notice Change stake requirements, registered resolvers keep their stakes
+    /// @dev Must be called by the owner
+    /// @param minStake The min stake required to register resolver in wei, zero disables stake requirement
+    /// @param period The unbonding period in seconds
+    function setStakeConfig(uint256 minStake, uint256 period) external {
+        require(msg.sender == owner, "Not authorized");
+
+        minResolverStake = minStake;
+        unbondingPeriod = period;
+
+        emit StakeConfigUpdated(minStake, period);
     }
 
-    /// @notice Get the IP address of the relayer node and all resolver public keys
-    /// @return ip The IP address of the relayer node
+    /// @notice Allow or forbid an account to slash resolver stakes
+    /// @dev Must be called by the owner
+    /// @param slasher The account of the slasher
+    /// @param allowed Whether the account can slash
+    function setSlasher(address slasher, bool allowed) external {
+        require(msg.sender == owner, "Not authorized");
+
+        slashers[slasher] = allowed;
+
+        emit SlasherUpdated(slasher, allowed);
+    }
+
+    /// @notice Change grace period of rotated keys, keys rotated before keep their grace period
+    /// @dev Must be called by the owner
+    /// @param period The grace period in seconds
+    function setKeyRotationGracePeriod(uint256 period) external {
+        require(msg.sender == owner, "Not authorized");
+
+        keyRotationGracePeriod = period;
+
+        emit KeyRotationGracePeriodUpdated(period);
+    }
+
+    /// @notice Get the stake of a resolver node
+    /// @param publicKey The public key of the resolver node as bytes
+    /// @return stake The active stake in wei
+    /// @return unbonding The unbonding stake in wei
+    /// @return availableAt The time after which unbonding stake can be withdrawn
+    function getResolverStake(bytes calldata publicKey) external view returns (uint256 stake, uint256 unbonding, uint256 availableAt) {
+        stake = resolvers[publicKey].stake;
+        unbonding = unbondings[publicKey].amount;
+        availableAt = unbondings[publicKey].availableAt;
+    }
+
+    /// @notice Get the IP address of the primary relayer node and all resolver public keys
+    /// @dev Response grows with the count of resolvers, use getResolvers for large registries
+    /// @return ip The IP address of the primary relayer node, empty if primary relayer is not set
     /// @return publicKeys An array of all resolver public keys
     function getRelayer() external view returns (string memory ip, bytes[] memory publicKeys) {
-        //require(bytes(relayerIP).length > 0, "No relayer registered");
-        ip = relayerIP;
+        ip = relayers[primaryRelayer].ip;
         publicKeys = resolverKeys;
     }
 
+    /// @notice Get the count of registered resolver nodes, rotated keys are not counted
+    /// @return count The count of resolver nodes
+    function getResolverCount() external view returns (uint256 count) {
+        return resolverKeys.length;
+    }
+
+    /// @notice Get a page of registered resolver nodes with metadata
+    /// @param offset The index of the first resolver node
+    /// @param limit The max count of resolver nodes in the page
+    /// @return result An array of resolver nodes, empty if offset is out of range
+    function getResolvers(uint256 offset, uint256 limit) external view returns (ResolverInfo[] memory result) {
+        if (offset >= resolverKeys.length) {
+            return new ResolverInfo[](0);
+        }
+
+        uint256 end = resolverKeys.length - offset < limit ? resolverKeys.length : offset + limit;
+        result = new ResolverInfo[](end - offset);
+        for (uint256 i = offset; i < end; i++) {
+            bytes storage publicKey = resolverKeys[i];
+            Resolver storage resolver = resolvers[publicKey];
+            result[i - offset] = ResolverInfo({
+                publicKey: publicKey,
+                ip: resolver.ip,
+                owner: resolver.owner,
+                stake: resolver.stake,
+                capabilities: capabilities[publicKey]
+            });
+        }
+    }
+
+    /// @notice Get all registered relayer nodes in order of registration
+    /// @return result An array of relayer nodes with metadata
+    function getRelayers() external view returns (Relayer[] memory result) {
+        result = new Relayer[](relayerOwners.length);
+        for (uint256 i = 0; i < relayerOwners.length; i++) {
+            result[i] = relayers[relayerOwners[i]];
+        }
+    }
+
     /// @notice Get the IP address of a resolver node by its public key
     /// @param publicKey The public key of the resolver node as bytes
     /// @return ip The IP address of the resolver node
     function getResolver(bytes calldata publicKey) external view returns (string memory ip) {
-        string memory resolverIP = resolvers[publicKey].ip;
-        require(bytes(resolverIP).length > 0, "Resolver not found");
-        return resolverIP;
+        Resolver storage resolver = resolvers[publicKey];
+        require(bytes(resolver.ip).length > 0, "Resolver not found");
+        require(resolver.validUntil == 0 || block.timestamp < resolver.validUntil, "Resolver key expired");
+        return resolver.ip;
+    }
+
+    /// @notice Get capabilities of a resolver node by its public key
+    /// @param publicKey The public key of the resolver node as bytes
+    /// @return resolverCapabilities The requests served by the resolver node
+    function getResolverCapabilities(bytes calldata publicKey) external view returns (Capabilities memory resolverCapabilities) {
+        require(isRegistered(publicKey), "Resolver not found");
+        return capabilities[publicKey];
+    }
+
+    /// @notice Get the owner of a resolver node by its public key
+    /// @param publicKey The public key of the resolver node as bytes
+    /// @return resolverOwner The account which registered the resolver node
+    function getResolverOwner(bytes calldata publicKey) external view returns (address resolverOwner) {
+        return activeResolver(publicKey).owner;
+    }
+
+    /// @notice Get registered resolver which key is not rotated
+    function activeResolver(bytes calldata publicKey) internal view returns (Resolver storage resolver) {
+        resolver = resolvers[publicKey];
+        require(bytes(resolver.ip).length > 0 && resolver.validUntil == 0, "Resolver not found");
+    }
+
+    /// @notice Check that resolver is registered with the key, rotated key is registered during grace period
+    function isRegistered(bytes calldata publicKey) internal view returns (bool) {
+        Resolver storage resolver = resolvers[publicKey];
+        return bytes(resolver.ip).length > 0 && (resolver.validUntil == 0 || block.timestamp < resolver.validUntil);
+    }
+
+    /// @notice Remove relayer and keep order of other relayers
+    function removeRelayer(address relayerOwner) internal {
+        delete relayers[relayerOwner];
+        for (uint256 i = 0; i < relayerOwners.length; i++) {
+            if (relayerOwners[i] == relayerOwner) {
+                for (uint256 j = i; j + 1 < relayerOwners.length; j++) {
+                    relayerOwners[j] = relayerOwners[j + 1];
+                }
+                relayerOwners.pop();
+                break;
+            }
+        }
+
+        emit RelayerRemoved(relayerOwner);
+
+        if (primaryRelayer == relayerOwner) {
+            primaryRelayer = address(0);
+            emit PrimaryRelayerUpdated(address(0));
+        }
+    }
+
+    /// @notice Move stake to unbonding, unbonding period is restarted
+    function startUnbonding(bytes calldata publicKey, address resolverOwner, uint256 amount) internal {
+        Unbonding storage unbonding = unbondings[publicKey];
+        unbonding.owner = resolverOwner;
+        unbonding.amount += amount;
+        unbonding.availableAt = block.timestamp + unbondingPeriod;
+
+        emit ResolverUnstaked(publicKey, amount, unbonding.availableAt);
+    }
+
+    /// @notice Remove public key from the list of resolver keys
+    function removeResolverKey(bytes calldata publicKey) internal {
+        for (uint256 i = 0; i < resolverKeys.length; i++) {
+            if (keccak256(resolverKeys[i]) == keccak256(publicKey)) {
+                resolverKeys[i] = resolverKeys[resolverKeys.length - 1];
+                resolverKeys.pop();
+                return;
+            }
+        }
+    }
+
+    /// @notice Derive account address from compressed secp256k1 public key
+    /// @param publicKey The compressed public key, 33 bytes
+    /// @return account The address of the account, zero address if key can't be decompressed
+    function keyToAddress(bytes calldata publicKey) internal view returns (address account) {
+        if (publicKey.length != 33 || (publicKey[0] != 0x02 && publicKey[0] != 0x03)) {
+            return address(0);
+        }
+
+        uint256 x = uint256(bytes32(publicKey[1:33]));
+        uint256 ySquare = addmod(mulmod(mulmod(x, x, FIELD_MODULUS), x, FIELD_MODULUS), 7, FIELD_MODULUS);
+        // modulus is 3 mod 4, so square root is ySquare^((p+1)/4)
+        (bool success, bytes memory result) = address(0x05).staticcall(
+            abi.encode(uint256(32), uint256(32), uint256(32), ySquare, (FIELD_MODULUS + 1) / 4, FIELD_MODULUS)
+        );
+        if (!success) {
+            return address(0);
+        }
+
+        uint256 y = abi.decode(result, (uint256));
+        if (mulmod(y, y, FIELD_MODULUS) != ySquare) {
+            return address(0);
+        }
+        if (y % 2 != uint8(publicKey[0]) % 2) {
+            y = FIELD_MODULUS - y;
+        }
+
+        account = address(uint160(uint256(keccak256(abi.encodePacked(x, y)))));
     }
 }
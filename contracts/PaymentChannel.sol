@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+/// @notice Unidirectional payment channels from clients to resolvers.
+/// Payer deposits funds for a payee and signs EIP-712 vouchers with cumulative amount for every request,
+/// payee settles the latest voucher. Payer can withdraw unsettled deposit after withdrawal delay.
+contract PaymentChannel {
+    bytes32 public constant VOUCHER_TYPEHASH = keccak256("Voucher(address payer,address payee,uint256 amount)");
+
+    bytes32 private constant DOMAIN_TYPEHASH =
+        keccak256("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)");
+
+    // upper bound of s of non-malleable signatures, secp256k1 order divided by 2
+    uint256 private constant MAX_SIGNATURE_S = 0x7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a0;
+
+    struct Channel {
+        // total deposit of the payer in wei
+        uint256 deposit;
+        // cumulative amount paid to the payee in wei
+        uint256 settled;
+        // time after which unsettled deposit can be withdrawn, zero if withdrawal is not requested
+        uint256 withdrawableAt;
+    }
+
+    bytes32 public immutable DOMAIN_SEPARATOR;
+
+    /// @notice Time during which payee can settle vouchers after payer requested withdrawal
+    uint256 public immutable withdrawalDelay;
+
+    // payer => payee => channel
+    mapping(address => mapping(address => Channel)) public channels;
+
+    event Deposited(address indexed payer, address indexed payee, uint256 amount, uint256 deposit);
+    event Settled(address indexed payer, address indexed payee, uint256 amount, uint256 settled);
+    event WithdrawalRequested(address indexed payer, address indexed payee, uint256 withdrawableAt);
+    event Withdrawn(address indexed payer, address indexed payee, uint256 amount);
+
+    /// @param delay The withdrawal delay in seconds
+    constructor(uint256 delay) {
+        withdrawalDelay = delay;
+        DOMAIN_SEPARATOR = keccak256(
+            abi.encode(DOMAIN_TYPEHASH, keccak256("PaymentChannel"), keccak256("1"), block.chainid, address(this))
+        );
+    }
+
+    /// @notice Add sent value to the deposit of the sender for the payee, requested withdrawal is cancelled
+    /// @param payee The account which is paid by vouchers of the sender
+    function deposit(address payee) external payable {
+        require(payee != address(0), "Payee cannot be empty");
+        require(msg.value > 0, "Deposit cannot be empty");
+
+        Channel storage channel = channels[msg.sender][payee];
+        channel.deposit += msg.value;
+        channel.withdrawableAt = 0;
+
+        emit Deposited(msg.sender, payee, msg.value, channel.deposit);
+    }
+
+    /// @notice Pay the difference between voucher amount and settled amount to the payee
+    /// @dev Can be called by anyone, funds are always sent to the payee
+    /// @param payer The account which signed the voucher
+    /// @param payee The account which is paid
+    /// @param amount The cumulative amount of the voucher in wei
+    /// @param signature The EIP-712 signature of the voucher in [R || S || V] format
+    function settle(address payer, address payee, uint256 amount, bytes calldata signature) external {
+        Channel storage channel = channels[payer][payee];
+        require(amount > channel.settled, "Nothing to settle");
+        require(amount <= channel.deposit, "Amount exceeds deposit");
+        require(recoverSigner(voucherHash(payer, payee, amount), signature) == payer, "Invalid signature");
+
+        uint256 payout = amount - channel.settled;
+        channel.settled = amount;
+        (bool success, ) = payable(payee).call{value: payout}("");
+        require(success, "Transfer failed");
+
+        emit Settled(payer, payee, payout, amount);
+    }
+
+    /// @notice Start withdrawal delay of unsettled deposit of the sender
+    /// @param payee The account which is paid by vouchers of the sender
+    function requestWithdrawal(address payee) external {
+        Channel storage channel = channels[msg.sender][payee];
+        require(channel.deposit > channel.settled, "Nothing to withdraw");
+
+        channel.withdrawableAt = block.timestamp + withdrawalDelay;
+
+        emit WithdrawalRequested(msg.sender, payee, channel.withdrawableAt);
+    }
+
+    /// @notice Withdraw unsettled deposit of the sender after withdrawal delay
+    /// @param payee The account which is paid by vouchers of the sender
+    function withdraw(address payee) external {
+        Channel storage channel = channels[msg.sender][payee];
+        require(channel.withdrawableAt != 0 && block.timestamp >= channel.withdrawableAt, "Withdrawal is delayed");
+
+        uint256 amount = channel.deposit - channel.settled;
+        require(amount > 0, "Nothing to withdraw");
+
+        // settled amount is kept, so vouchers stay cumulative after next deposit
+        channel.deposit = channel.settled;
+        channel.withdrawableAt = 0;
+        (bool success, ) = payable(msg.sender).call{value: amount}("");
+        require(success, "Transfer failed");
+
+        emit Withdrawn(msg.sender, payee, amount);
+    }
+
+    /// @notice Get EIP-712 hash of a voucher which is signed by the payer
+    function voucherHash(address payer, address payee, uint256 amount) public view returns (bytes32 hash) {
+        bytes32 structHash = keccak256(abi.encode(VOUCHER_TYPEHASH, payer, payee, amount));
+        return keccak256(abi.encodePacked("\x19\x01", DOMAIN_SEPARATOR, structHash));
+    }
+
+    function recoverSigner(bytes32 hash, bytes calldata signature) internal pure returns (address signer) {
+        require(signature.length == 65, "Invalid signature length");
+
+        bytes32 r = bytes32(signature[0:32]);
+        bytes32 s = bytes32(signature[32:64]);
+        uint8 v = uint8(signature[64]);
+        if (v < 27) {
+            v += 27;
+        }
+        require(v == 27 || v == 28, "Invalid signature");
+        require(uint256(s) <= MAX_SIGNATURE_S, "Invalid signature");
+
+        signer = ecrecover(hash, v, r, s);
+        require(signer != address(0), "Invalid signature");
+    }
+}
@@ -5,13 +5,18 @@ package contracts_test
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
+	"github.com/1inch/p2p-network/contracts"
 	registry "github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
 )
 
 const (
@@ -60,8 +65,510 @@ func TestRegisterNodes(t *testing.T) {
 
 	require.Equal(t, resolverIP, ip)
 
-	err = client.RegisterRelayer(ctx, relayerIP)
+	err = client.RegisterRelayer(ctx, relayerIP, registry.RelayerMetadata{})
 	require.NoError(t, err, "relayer registration failed")
 
+	err = client.SetPrimaryRelayer(ctx, client.Auth.From)
+	require.NoError(t, err, "primary relayer update failed")
+
 	t.Log("resolver successfully registered")
 }
+
+func TestRotateResolverKey(t *testing.T) {
+	ctx := context.Background()
+	resolverIP := "127.0.0.1:8001"
+	// resolver key is used for sending rotation transaction
+	resolverPrivateKey := "5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a"
+	otherPrivateKey := "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+
+	contractAddress, ownerClient, err := registry.DeployNodeRegistry(ctx, &registry.Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	privKey, err := crypto.HexToECDSA(resolverPrivateKey)
+	require.NoError(t, err, "invalid private key")
+	oldPublicKey := crypto.CompressPubkey(&privKey.PublicKey)
+
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newPublicKey := crypto.CompressPubkey(&newKey.PublicKey)
+
+	err = ownerClient.RegisterResolver(ctx, resolverIP, oldPublicKey)
+	require.NoError(t, err, "resolver registration failed")
+
+	otherClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:         rpcURL,
+		PrivateKey:      otherPrivateKey,
+		ContractAddress: contractAddress.Hex(),
+	})
+	require.NoError(t, err)
+	err = otherClient.RotateResolverKey(ctx, oldPublicKey, newPublicKey)
+	require.ErrorContains(t, err, "Not authorized")
+
+	resolverClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:         rpcURL,
+		PrivateKey:      resolverPrivateKey,
+		ContractAddress: contractAddress.Hex(),
+	})
+	require.NoError(t, err)
+	err = resolverClient.RotateResolverKey(ctx, oldPublicKey, newPublicKey)
+	require.NoError(t, err, "key rotation failed")
+
+	_, publicKeys, err := resolverClient.GetRelayer()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{newPublicKey}, publicKeys)
+
+	for _, publicKey := range [][]byte{oldPublicKey, newPublicKey} {
+		ip, err := resolverClient.GetResolver(publicKey)
+		require.NoError(t, err, "key must be resolved during grace period")
+		require.Equal(t, resolverIP, ip)
+	}
+
+	err = resolverClient.RotateResolverKey(ctx, oldPublicKey, newPublicKey)
+	require.ErrorContains(t, err, "Resolver not found", "rotated key can't be rotated again")
+}
+
+func TestReregisterRotatedKey(t *testing.T) {
+	ctx := context.Background()
+	otherPrivateKey := "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+
+	contractAddress, ownerClient, err := registry.DeployNodeRegistry(ctx, &registry.Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	otherClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:         rpcURL,
+		PrivateKey:      otherPrivateKey,
+		ContractAddress: contractAddress.Hex(),
+	})
+	require.NoError(t, err)
+
+	publicKeys := make([][]byte, 3)
+	for i := range publicKeys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		publicKeys[i] = crypto.CompressPubkey(&key.PublicKey)
+	}
+	oldPublicKey, newPublicKey, nextPublicKey := publicKeys[0], publicKeys[1], publicKeys[2]
+
+	require.ErrorContains(t, otherClient.SetKeyRotationGracePeriod(ctx, time.Second), "Not authorized")
+	require.NoError(t, ownerClient.SetKeyRotationGracePeriod(ctx, time.Second))
+
+	require.NoError(t, ownerClient.RegisterResolver(ctx, "127.0.0.1:8001", oldPublicKey))
+	require.NoError(t, ownerClient.RotateResolverKey(ctx, oldPublicKey, newPublicKey))
+
+	require.ErrorContains(t, ownerClient.RegisterResolver(ctx, "127.0.0.1:8002", oldPublicKey), "Resolver already registered", "rotated key can't be registered during grace period")
+	require.ErrorContains(t, otherClient.PruneResolverKey(ctx, oldPublicKey), "Resolver key not expired")
+	require.ErrorContains(t, otherClient.PruneResolverKey(ctx, newPublicKey), "Resolver key not rotated")
+
+	// blocks of next transactions are after grace period
+	time.Sleep(2 * time.Second)
+
+	_, err = ownerClient.GetResolver(oldPublicKey)
+	require.ErrorContains(t, err, "Resolver key expired")
+
+	require.NoError(t, ownerClient.RegisterResolver(ctx, "127.0.0.1:8002", oldPublicKey), "rotated key can be registered after grace period")
+	ip, err := ownerClient.GetResolver(oldPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8002", ip)
+	require.NoError(t, ownerClient.DeregisterResolver(ctx, oldPublicKey), "registered again key can be removed")
+
+	require.NoError(t, ownerClient.RotateResolverKey(ctx, newPublicKey, nextPublicKey))
+	time.Sleep(2 * time.Second)
+	require.NoError(t, otherClient.PruneResolverKey(ctx, newPublicKey), "any account can prune expired key")
+	_, err = ownerClient.GetResolver(newPublicKey)
+	require.ErrorContains(t, err, "Resolver not found")
+
+	_, registeredKeys, err := ownerClient.GetRelayer()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{nextPublicKey}, registeredKeys)
+}
+
+func TestNodeOwnership(t *testing.T) {
+	ctx := context.Background()
+	resolverPrivateKey := "5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a"
+	otherPrivateKey := "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+
+	contractAddress, ownerClient, err := registry.DeployNodeRegistry(ctx, &registry.Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	nodeClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:         rpcURL,
+		PrivateKey:      resolverPrivateKey,
+		ContractAddress: contractAddress.Hex(),
+	})
+	require.NoError(t, err)
+
+	otherClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:         rpcURL,
+		PrivateKey:      otherPrivateKey,
+		ContractAddress: contractAddress.Hex(),
+	})
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	publicKey := crypto.CompressPubkey(&key.PublicKey)
+
+	// resolver
+	require.NoError(t, nodeClient.RegisterResolver(ctx, "127.0.0.1:8001", publicKey))
+
+	resolverOwner, err := nodeClient.Registry.GetResolverOwner(&bind.CallOpts{}, publicKey)
+	require.NoError(t, err)
+	require.Equal(t, nodeClient.Auth.From, resolverOwner)
+
+	require.ErrorContains(t, otherClient.UpdateResolver(ctx, publicKey, "127.0.0.1:9001"), "Not authorized")
+	require.ErrorContains(t, otherClient.DeregisterResolver(ctx, publicKey), "Not authorized")
+
+	require.NoError(t, nodeClient.UpdateResolver(ctx, publicKey, "127.0.0.1:8002"))
+	ip, err := nodeClient.GetResolver(publicKey)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8002", ip)
+
+	require.NoError(t, ownerClient.DeregisterResolver(ctx, publicKey), "contract owner can remove any resolver")
+	_, err = nodeClient.GetResolver(publicKey)
+	require.ErrorContains(t, err, "Resolver not found")
+
+	_, publicKeys, err := nodeClient.GetRelayer()
+	require.NoError(t, err)
+	require.Empty(t, publicKeys)
+
+	// relayer
+	require.ErrorContains(t, nodeClient.RegisterRelayer(ctx, "127.0.0.1:8080", registry.RelayerMetadata{}), "Relayer not approved")
+	require.ErrorContains(t, nodeClient.SetRelayerApproval(ctx, nodeClient.Auth.From, true), "Not authorized")
+	require.NoError(t, ownerClient.SetRelayerApproval(ctx, nodeClient.Auth.From, true))
+	require.NoError(t, ownerClient.SetRelayerApproval(ctx, otherClient.Auth.From, true))
+
+	require.NoError(t, nodeClient.RegisterRelayer(ctx, "127.0.0.1:8080", registry.RelayerMetadata{Region: "eu", Capacity: 100, PublicKey: publicKey}))
+	require.NoError(t, otherClient.RegisterRelayer(ctx, "127.0.0.1:9080", registry.RelayerMetadata{Region: "us"}))
+	require.NoError(t, ownerClient.RegisterRelayer(ctx, "127.0.0.1:7080", registry.RelayerMetadata{Region: "ap"}), "contract owner can register relayer without approval")
+
+	_, err = otherClient.Registry.DeregisterRelayer(otherClient.Auth, nodeClient.Auth.From)
+	require.ErrorContains(t, err, "Not authorized")
+
+	require.NoError(t, nodeClient.RegisterRelayer(ctx, "127.0.0.1:8081", registry.RelayerMetadata{Region: "eu"}), "owner can update relayer")
+	relayers, err := nodeClient.GetRelayers()
+	require.NoError(t, err)
+	require.Len(t, relayers, 3)
+	require.Equal(t, "127.0.0.1:8081", relayers[0].Ip)
+	require.Equal(t, nodeClient.Auth.From, relayers[0].Owner)
+	require.Equal(t, "us", relayers[1].Region)
+
+	ip, _, err = otherClient.GetRelayer()
+	require.NoError(t, err)
+	require.Empty(t, ip, "no relayer is primary until owner sets it")
+
+	require.ErrorContains(t, otherClient.SetPrimaryRelayer(ctx, otherClient.Auth.From), "Not authorized")
+	require.NoError(t, ownerClient.SetPrimaryRelayer(ctx, nodeClient.Auth.From))
+	ip, _, err = otherClient.GetRelayer()
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8081", ip)
+
+	require.NoError(t, nodeClient.DeregisterRelayer(ctx))
+	ip, _, err = otherClient.GetRelayer()
+	require.NoError(t, err)
+	require.Empty(t, ip, "removed primary relayer isn't replaced by other relayer")
+
+	relayers, err = nodeClient.GetRelayers()
+	require.NoError(t, err)
+	require.Len(t, relayers, 2)
+	require.Equal(t, otherClient.Auth.From, relayers[0].Owner, "order of relayers is kept after removal")
+	require.Equal(t, ownerClient.Auth.From, relayers[1].Owner)
+
+	require.ErrorContains(t, ownerClient.RemoveRelayer(ctx, nodeClient.Auth.From), "Relayer not found")
+	require.NoError(t, ownerClient.SetRelayerApproval(ctx, otherClient.Auth.From, false), "revoked approval removes relayer")
+	require.ErrorContains(t, otherClient.RegisterRelayer(ctx, "127.0.0.1:9080", registry.RelayerMetadata{}), "Relayer not approved")
+
+	tx, err := ownerClient.Registry.DeregisterRelayer(ownerClient.Auth, ownerClient.Auth.From)
+	require.NoError(t, err)
+	require.NoError(t, ownerClient.WaitForTx(ctx, tx.Hash()))
+
+	relayers, err = nodeClient.GetRelayers()
+	require.NoError(t, err)
+	require.Empty(t, relayers)
+}
+
+func TestResolverStaking(t *testing.T) {
+	ctx := context.Background()
+	resolverPrivateKey := "5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a"
+	slasherPrivateKey := "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+
+	contractAddress, ownerClient, err := registry.DeployNodeRegistry(ctx, &registry.Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	nodeClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:         rpcURL,
+		PrivateKey:      resolverPrivateKey,
+		ContractAddress: contractAddress.Hex(),
+	})
+	require.NoError(t, err)
+
+	slasherClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:         rpcURL,
+		PrivateKey:      slasherPrivateKey,
+		ContractAddress: contractAddress.Hex(),
+	})
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	publicKey := crypto.CompressPubkey(&key.PublicKey)
+
+	minStake := big.NewInt(1000)
+	require.ErrorContains(t, nodeClient.SetStakeConfig(ctx, minStake, 0), "Not authorized")
+	require.NoError(t, ownerClient.SetStakeConfig(ctx, minStake, 0))
+
+	require.ErrorContains(t, nodeClient.RegisterResolver(ctx, "127.0.0.1:8001", publicKey), "Insufficient stake")
+	require.NoError(t, nodeClient.RegisterResolverWithOptions(ctx, "127.0.0.1:8001", publicKey, registry.ResolverOptions{Stake: big.NewInt(1500)}))
+	require.NoError(t, nodeClient.StakeResolver(ctx, publicKey, big.NewInt(500)))
+
+	stake, err := nodeClient.GetResolverStake(publicKey)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2000), stake.Stake)
+
+	// unstake
+	require.ErrorContains(t, nodeClient.UnstakeResolver(ctx, publicKey, big.NewInt(1500)), "Stake below minimum")
+	require.NoError(t, nodeClient.UnstakeResolver(ctx, publicKey, big.NewInt(1000)))
+
+	stake, err = nodeClient.GetResolverStake(publicKey)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), stake.Stake)
+	require.Equal(t, big.NewInt(1000), stake.Unbonding)
+
+	// slash
+	require.ErrorContains(t, slasherClient.SlashResolver(ctx, publicKey, big.NewInt(100)), "Not authorized")
+	require.NoError(t, ownerClient.SetSlasher(ctx, slasherClient.Auth.From, true))
+	require.NoError(t, slasherClient.SlashResolver(ctx, publicKey, big.NewInt(1200)), "unbonding stake is slashed after active stake")
+
+	stake, err = nodeClient.GetResolverStake(publicKey)
+	require.NoError(t, err)
+	require.Zero(t, stake.Stake.Sign())
+	require.Equal(t, big.NewInt(800), stake.Unbonding)
+
+	// withdraw
+	require.ErrorContains(t, slasherClient.WithdrawResolverStake(ctx, publicKey), "Not authorized")
+	require.NoError(t, nodeClient.WithdrawResolverStake(ctx, publicKey), "unbonding period is zero")
+	require.ErrorContains(t, nodeClient.WithdrawResolverStake(ctx, publicKey), "Nothing to withdraw")
+
+	// deregistration unbonds the whole stake
+	require.NoError(t, ownerClient.SetStakeConfig(ctx, big.NewInt(0), time.Hour))
+	require.NoError(t, nodeClient.StakeResolver(ctx, publicKey, big.NewInt(300)))
+	require.NoError(t, nodeClient.DeregisterResolver(ctx, publicKey))
+
+	stake, err = nodeClient.GetResolverStake(publicKey)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(300), stake.Unbonding)
+	require.True(t, stake.AvailableAt.After(time.Now()))
+
+	require.ErrorContains(t, nodeClient.WithdrawResolverStake(ctx, publicKey), "Stake is unbonding")
+	require.ErrorContains(t, slasherClient.RegisterResolver(ctx, "127.0.0.1:9001", publicKey), "Stake is unbonding")
+}
+
+func TestResolverCapabilities(t *testing.T) {
+	ctx := context.Background()
+	otherPrivateKey := "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+
+	contractAddress, client, err := registry.DeployNodeRegistry(ctx, &registry.Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	otherClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:         rpcURL,
+		PrivateKey:      otherPrivateKey,
+		ContractAddress: contractAddress.Hex(),
+	})
+	require.NoError(t, err)
+
+	oldKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	oldPublicKey := crypto.CompressPubkey(&oldKey.PublicKey)
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newPublicKey := crypto.CompressPubkey(&newKey.PublicKey)
+
+	capabilities := contracts.NodeRegistryCapabilities{
+		Methods:         []string{"GetWalletBalance"},
+		ChainIds:        []*big.Int{big.NewInt(1), big.NewInt(137)},
+		ProtocolVersion: 1,
+		Encrypted:       true,
+	}
+	require.NoError(t, client.RegisterResolverWithOptions(ctx, "127.0.0.1:8001", oldPublicKey, registry.ResolverOptions{Capabilities: capabilities}))
+
+	registered, err := client.GetResolverCapabilities(oldPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, capabilities, registered)
+
+	capabilities.Methods = append(capabilities.Methods, "GetBlockNumber")
+	require.ErrorContains(t, otherClient.SetResolverCapabilities(ctx, oldPublicKey, capabilities), "Not authorized")
+	require.NoError(t, client.SetResolverCapabilities(ctx, oldPublicKey, capabilities))
+
+	capabilities.ChainIds = append(capabilities.ChainIds, big.NewInt(56))
+	require.ErrorContains(t, otherClient.UpdateResolverWithCapabilities(ctx, oldPublicKey, "127.0.0.1:9001", capabilities), "Not authorized")
+	require.NoError(t, client.UpdateResolverWithCapabilities(ctx, oldPublicKey, "127.0.0.1:8002", capabilities))
+	ip, err := client.GetResolver(oldPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8002", ip)
+	updated, err := client.GetResolverCapabilities(oldPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, capabilities, updated, "IP and capabilities are updated in one transaction")
+
+	require.NoError(t, client.RotateResolverKey(ctx, oldPublicKey, newPublicKey))
+	rotated, err := client.GetResolverCapabilities(newPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, capabilities, rotated, "capabilities are kept after key rotation")
+
+	require.NoError(t, client.DeregisterResolver(ctx, newPublicKey))
+	_, err = client.GetResolverCapabilities(newPublicKey)
+	require.ErrorContains(t, err, "Resolver not found")
+}
+
+func TestGetResolvers(t *testing.T) {
+	ctx := context.Background()
+
+	_, client, err := registry.DeployNodeRegistry(ctx, &registry.Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	var publicKeys [][]byte
+	for i := range 3 {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		publicKey := crypto.CompressPubkey(&key.PublicKey)
+		publicKeys = append(publicKeys, publicKey)
+
+		require.NoError(t, client.RegisterResolverWithOptions(ctx, fmt.Sprintf("127.0.0.1:800%d", i), publicKey, registry.ResolverOptions{
+			Stake:        big.NewInt(int64(i + 1)),
+			Capabilities: contracts.NodeRegistryCapabilities{Methods: []string{"GetWalletBalance"}, ProtocolVersion: 1},
+		}))
+	}
+
+	count, err := client.GetResolverCount()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), count)
+
+	testCases := []struct {
+		name     string
+		offset   int64
+		limit    int64
+		expected [][]byte
+	}{
+		{name: "First page", offset: 0, limit: 2, expected: publicKeys[:2]},
+		{name: "Last page", offset: 2, limit: 2, expected: publicKeys[2:]},
+		{name: "Offset out of range", offset: 3, limit: 2, expected: nil},
+		{name: "Zero limit", offset: 0, limit: 0, expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			page, err := client.Registry.GetResolvers(&bind.CallOpts{}, big.NewInt(testCase.offset), big.NewInt(testCase.limit))
+			require.NoError(t, err)
+			require.Len(t, page, len(testCase.expected))
+			for i, resolver := range page {
+				require.Equal(t, testCase.expected[i], resolver.PublicKey)
+			}
+		})
+	}
+
+	resolvers, err := client.GetResolvers(ctx)
+	require.NoError(t, err)
+	require.Len(t, resolvers, 3)
+	for i, resolver := range resolvers {
+		require.Equal(t, publicKeys[i], resolver.PublicKey)
+		require.Equal(t, fmt.Sprintf("127.0.0.1:800%d", i), resolver.Ip)
+		require.Equal(t, client.Auth.From, resolver.Owner)
+		require.Equal(t, big.NewInt(int64(i+1)), resolver.Stake)
+		require.Equal(t, []string{"GetWalletBalance"}, resolver.Capabilities.Methods)
+	}
+}
+
+func TestPaymentChannel(t *testing.T) {
+	ctx := context.Background()
+	payerPrivateKey := "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+	payeePrivateKey := "5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a"
+
+	ownerClient, err := registry.Dial(ctx, &registry.Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err)
+	deployed, err := ownerClient.DeployPaymentChannel(ctx, 0)
+	require.NoError(t, err, "contract deployment failed")
+
+	payerClient, err := registry.Dial(ctx, &registry.Config{DialURI: rpcURL, PrivateKey: payerPrivateKey})
+	require.NoError(t, err)
+	payerChannel, err := payerClient.PaymentChannel(deployed.Address())
+	require.NoError(t, err)
+
+	payeeClient, err := registry.Dial(ctx, &registry.Config{DialURI: rpcURL, PrivateKey: payeePrivateKey})
+	require.NoError(t, err)
+	payeeChannel, err := payeeClient.PaymentChannel(deployed.Address())
+	require.NoError(t, err)
+
+	payer, err := signer.NewFromHex(payerPrivateKey)
+	require.NoError(t, err)
+	payee := payeeClient.Auth.From
+
+	domain, err := payerChannel.Domain(ctx)
+	require.NoError(t, err)
+
+	voucherHash, err := deployed.Channel.VoucherHash(&bind.CallOpts{}, payer.Address(), payee, big.NewInt(300))
+	require.NoError(t, err)
+	require.Equal(t, voucherHash[:], domain.VoucherHash(payer.Address(), payee, big.NewInt(300)), "voucher hash must be the same as in contract")
+
+	require.NoError(t, payerChannel.Deposit(ctx, payee, big.NewInt(1000)))
+
+	voucher, err := domain.SignVoucher(payer, payee, big.NewInt(300))
+	require.NoError(t, err)
+	require.NoError(t, domain.VerifyVoucher(voucher))
+
+	tooLarge, err := domain.SignVoucher(payer, payee, big.NewInt(1001))
+	require.NoError(t, err)
+	_, err = payeeChannel.Settle(ctx, tooLarge)
+	require.ErrorContains(t, err, "Amount exceeds deposit")
+
+	forged := voucher
+	forged.Amount = big.NewInt(400)
+	_, err = payeeChannel.Settle(ctx, forged)
+	require.ErrorContains(t, err, "Invalid signature")
+
+	_, err = payeeChannel.Settle(ctx, voucher)
+	require.NoError(t, err)
+	_, err = payeeChannel.Settle(ctx, voucher)
+	require.ErrorContains(t, err, "Nothing to settle")
+
+	// vouchers are cumulative, only difference is paid
+	voucher, err = domain.SignVoucher(payer, payee, big.NewInt(700))
+	require.NoError(t, err)
+	_, err = payerChannel.Settle(ctx, voucher)
+	require.NoError(t, err, "voucher can be settled by any account")
+
+	state, err := payerChannel.GetChannel(ctx, payer.Address(), payee)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), state.Deposit)
+	require.Equal(t, big.NewInt(700), state.Settled)
+	require.True(t, state.WithdrawableAt.IsZero())
+
+	// withdrawal of unsettled deposit
+	require.ErrorContains(t, payerChannel.Withdraw(ctx, payee), "Withdrawal is delayed")
+	require.NoError(t, payerChannel.RequestWithdrawal(ctx, payee))
+	require.NoError(t, payerChannel.Withdraw(ctx, payee), "withdrawal delay is zero")
+
+	state, err = payerChannel.GetChannel(ctx, payer.Address(), payee)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(700), state.Deposit, "settled amount is kept")
+	require.Equal(t, big.NewInt(700), state.Settled)
+}
@@ -3,3 +3,5 @@ package contracts
 
 //go:generate sh -c "solc --bin --abi -o ./bin/ NodeRegistry.sol --overwrite --optimize"
 //go:generate sh -c "abigen --bin=./bin/NodeRegistry.bin --abi=./bin/NodeRegistry.abi --pkg=contracts --type NodeRegistry --out=node_registry.go"
+//go:generate sh -c "solc --bin --abi -o ./bin/ PaymentChannel.sol --overwrite --optimize"
+//go:generate sh -c "abigen --bin=./bin/PaymentChannel.bin --abi=./bin/PaymentChannel.abi --pkg=contracts --type PaymentChannel --out=payment_channel.go"
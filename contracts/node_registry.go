@@ -29,10 +29,36 @@ var (
 	_ = abi.ConvertType
 )
 
+// NodeRegistryCapabilities is an auto generated low-level Go binding around an user-defined struct.
+type NodeRegistryCapabilities struct {
+	Methods         []string
+	ChainIds        []*big.Int
+	ProtocolVersion uint32
+	Encrypted       bool
+}
+
+// NodeRegistryRelayer is an auto generated low-level Go binding around an user-defined struct.
+type NodeRegistryRelayer struct {
+	Ip        string
+	Region    string
+	Capacity  uint32
+	PublicKey []byte
+	Owner     common.Address
+}
+
+// NodeRegistryResolverInfo is an auto generated low-level Go binding around an user-defined struct.
+type NodeRegistryResolverInfo struct {
+	PublicKey    []byte
+	Ip           string
+	Owner        common.Address
+	Stake        *big.Int
+	Capabilities NodeRegistryCapabilities
+}
+
 // NodeRegistryMetaData contains all meta data concerning the NodeRegistry contract.
 var NodeRegistryMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"name\":\"getRelayer\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"},{\"internalType\":\"bytes[]\",\"name\":\"publicKeys\",\"type\":\"bytes[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"getResolver\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"}],\"name\":\"registerRelayer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"registerResolver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
-	Bin: "0x6080604052348015600e575f5ffd5b506109268061001c5f395ff3fe608060405234801561000f575f5ffd5b506004361061004a575f3560e01c80636f5a4e381461004e578063bdc5037314610063578063e5ee399814610082578063eea330f914610095575b5f5ffd5b61006161005c36600461057c565b6100b5565b005b61006b610118565b6040516100799291906105e9565b60405180910390f35b61006161009036600461065e565b610278565b6100a86100a336600461057c565b61043e565b60405161007991906106ca565b806101075760405162461bcd60e51b815260206004820152601a60248201527f52656c617965722049502063616e6e6f7420626520656d70747900000000000060448201526064015b60405180910390fd5b5f61011382848361076c565b505050565b6060805f8054610127906106f0565b80601f0160208091040260200160405190810160405280929190818152602001828054610153906106f0565b801561019e5780601f106101755761010080835404028352916020019161019e565b820191905f5260205f20905b81548152906001019060200180831161018157829003601f168201915b505050505091506002805480602002602001604051908101604052809291908181526020015f905b8282101561026e578382905f5260205f200180546101e3906106f0565b80601f016020809104026020016040519081016040528092919081815260200182805461020f906106f0565b801561025a5780601f106102315761010080835404028352916020019161025a565b820191905f5260205f20905b81548152906001019060200180831161023d57829003601f168201915b5050505050815260200190600101906101c6565b5050505090509091565b826102c55760405162461bcd60e51b815260206004820152601b60248201527f5265736f6c7665722049502063616e6e6f7420626520656d707479000000000060448201526064016100fe565b806103125760405162461bcd60e51b815260206004820152601a60248201527f5075626c6963206b65792063616e6e6f7420626520656d70747900000000000060448201526064016100fe565b60018282604051610324929190610826565b908152604051908190036020019020805461033e906106f0565b15905061038d5760405162461bcd60e51b815260206004820152601b60248201527f5265736f6c76657220616c72656164792072656769737465726564000000000060448201526064016100fe565b604080516020601f860181900481028201830183528101858152909182919087908790819085018382808284375f9201919091525050509152506040516001906103da9085908590610826565b908152604051908190036020019020815181906103f79082610835565b5050600280546001810182555f919091527f405787fa12a823e0f2b7631cc41b3ba8828b3321ca811111fa75cd3aa3bb5ace01905061043782848361076c565b5050505050565b60605f60018484604051610453929190610826565b908152604051908190036020019020805461046d906106f0565b80601f0160208091040260200160405190810160405280929190818152602001828054610499906106f0565b80156104e45780601f106104bb576101008083540402835291602001916104e4565b820191905f5260205f20905b8154815290600101906020018083116104c757829003601f168201915b505050505090505f8151116105305760405162461bcd60e51b815260206004820152601260248201527114995cdbdb1d995c881b9bdd08199bdd5b9960721b60448201526064016100fe565b9392505050565b5f5f83601f840112610547575f5ffd5b50813567ffffffffffffffff81111561055e575f5ffd5b602083019150836020828501011115610575575f5ffd5b9250929050565b5f5f6020838503121561058d575f5ffd5b823567ffffffffffffffff8111156105a3575f5ffd5b6105af85828601610537565b90969095509350505050565b5f81518084528060208401602086015e5f602082860101526020601f19601f83011685010191505092915050565b604081525f6105fb60408301856105bb565b828103602084015280845180835260208301915060208160051b840101602087015f5b8381101561065057601f1986840301855261063a8383516105bb565b602095860195909350919091019060010161061e565b509098975050505050505050565b5f5f5f5f60408587031215610671575f5ffd5b843567ffffffffffffffff811115610687575f5ffd5b61069387828801610537565b909550935050602085013567ffffffffffffffff8111156106b2575f5ffd5b6106be87828801610537565b95989497509550505050565b602081525f61053060208301846105bb565b634e487b7160e01b5f52604160045260245ffd5b600181811c9082168061070457607f821691505b60208210810361072257634e487b7160e01b5f52602260045260245ffd5b50919050565b601f82111561011357805f5260205f20601f840160051c8101602085101561074d5750805b601f840160051c820191505b81811015610437575f8155600101610759565b67ffffffffffffffff831115610784576107846106dc565b6107988361079283546106f0565b83610728565b5f601f8411600181146107c9575f85156107b25750838201355b5f19600387901b1c1916600186901b178355610437565b5f83815260208120601f198716915b828110156107f857868501358255602094850194600190920191016107d8565b5086821015610814575f1960f88860031b161c19848701351681555b505060018560011b0183555050505050565b818382375f9101908152919050565b815167ffffffffffffffff81111561084f5761084f6106dc565b6108638161085d84546106f0565b84610728565b6020601f821160018114610895575f831561087e5750848201515b5f19600385901b1c1916600184901b178455610437565b5f84815260208120601f198516915b828110156108c457878501518255602094850194600190920191016108a4565b50848210156108e157868401515f19600387901b60f8161c191681555b50505050600190811b0190555056fea2646970667358221220418e17147bcb61450db72ac911b86f989680179a5aa7e732723f83b0b66358db64736f6c634300081c0033",
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"period\",\"type\":\"uint256\"}],\"name\":\"KeyRotationGracePeriodUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"relayerOwner\",\"type\":\"address\"}],\"name\":\"PrimaryRelayerUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"relayerOwner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"RelayerApprovalUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"}],\"name\":\"RelayerRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"RelayerRemoved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"ResolverCapabilitiesUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"oldPublicKey\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"newPublicKey\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"validUntil\",\"type\":\"uint256\"}],\"name\":\"ResolverKeyRotated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"}],\"name\":\"ResolverRegistered\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"ResolverRemoved\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"slasher\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"ResolverSlashed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"ResolverStakeWithdrawn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"}],\"name\":\"ResolverStaked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"availableAt\",\"type\":\"uint256\"}],\"name\":\"ResolverUnstaked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"}],\"name\":\"ResolverUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"slasher\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"name\":\"SlasherUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"minResolverStake\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"unbondingPeriod\",\"type\":\"uint256\"}],\"name\":\"StakeConfigUpdated\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"approvedRelayers\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"relayerOwner\",\"type\":\"address\"}],\"name\":\"deregisterRelayer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"deregisterResolver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getRelayer\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"},{\"internalType\":\"bytes[]\",\"name\":\"publicKeys\",\"type\":\"bytes[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getRelayers\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"region\",\"type\":\"string\"},{\"internalType\":\"uint32\",\"name\":\"capacity\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"internalType\":\"structNodeRegistry.Relayer[]\",\"name\":\"result\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"getResolver\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"getResolverCapabilities\",\"outputs\":[{\"components\":[{\"internalType\":\"string[]\",\"name\":\"methods\",\"type\":\"string[]\"},{\"internalType\":\"uint256[]\",\"name\":\"chainIds\",\"type\":\"uint256[]\"},{\"internalType\":\"uint32\",\"name\":\"protocolVersion\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"encrypted\",\"type\":\"bool\"}],\"internalType\":\"structNodeRegistry.Capabilities\",\"name\":\"resolverCapabilities\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getResolverCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"count\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"getResolverOwner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"resolverOwner\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"getResolverStake\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unbonding\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"availableAt\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"offset\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"limit\",\"type\":\"uint256\"}],\"name\":\"getResolvers\",\"outputs\":[{\"components\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"components\":[{\"internalType\":\"string[]\",\"name\":\"methods\",\"type\":\"string[]\"},{\"internalType\":\"uint256[]\",\"name\":\"chainIds\",\"type\":\"uint256[]\"},{\"internalType\":\"uint32\",\"name\":\"protocolVersion\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"encrypted\",\"type\":\"bool\"}],\"internalType\":\"structNodeRegistry.Capabilities\",\"name\":\"capabilities\",\"type\":\"tuple\"}],\"internalType\":\"structNodeRegistry.ResolverInfo[]\",\"name\":\"result\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"keyRotationGracePeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minResolverStake\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"primaryRelayer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"pruneResolverKey\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"region\",\"type\":\"string\"},{\"internalType\":\"uint32\",\"name\":\"capacity\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"registerRelayer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"components\":[{\"internalType\":\"string[]\",\"name\":\"methods\",\"type\":\"string[]\"},{\"internalType\":\"uint256[]\",\"name\":\"chainIds\",\"type\":\"uint256[]\"},{\"internalType\":\"uint32\",\"name\":\"protocolVersion\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"encrypted\",\"type\":\"bool\"}],\"internalType\":\"structNodeRegistry.Capabilities\",\"name\":\"resolverCapabilities\",\"type\":\"tuple\"}],\"name\":\"registerResolver\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"oldPublicKey\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"newPublicKey\",\"type\":\"bytes\"}],\"name\":\"rotateResolverKey\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"period\",\"type\":\"uint256\"}],\"name\":\"setKeyRotationGracePeriod\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"relayerOwner\",\"type\":\"address\"}],\"name\":\"setPrimaryRelayer\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"relayerOwner\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"setRelayerApproval\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"components\":[{\"internalType\":\"string[]\",\"name\":\"methods\",\"type\":\"string[]\"},{\"internalType\":\"uint256[]\",\"name\":\"chainIds\",\"type\":\"uint256[]\"},{\"internalType\":\"uint32\",\"name\":\"protocolVersion\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"encrypted\",\"type\":\"bool\"}],\"internalType\":\"structNodeRegistry.Capabilities\",\"name\":\"resolverCapabilities\",\"type\":\"tuple\"}],\"name\":\"setResolverCapabilities\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"slasher\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"allowed\",\"type\":\"bool\"}],\"name\":\"setSlasher\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"minStake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"period\",\"type\":\"uint256\"}],\"name\":\"setStakeConfig\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"slash\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"slashers\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"stakeResolver\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unbondingPeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"unstakeResolver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"}],\"name\":\"updateResolver\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"ip\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"string[]\",\"name\":\"methods\",\"type\":\"string[]\"},{\"internalType\":\"uint256[]\",\"name\":\"chainIds\",\"type\":\"uint256[]\"},{\"internalType\":\"uint32\",\"name\":\"protocolVersion\",\"type\":\"uint32\"},{\"internalType\":\"bool\",\"name\":\"encrypted\",\"type\":\"bool\"}],\"internalType\":\"structNodeRegistry.Capabilities\",\"name\":\"resolverCapabilities\",\"type\":\"tuple\"}],\"name\":\"updateResolverWithCapabilities\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes\",\"name\":\"publicKey\",\"type\":\"bytes\"}],\"name\":\"withdrawResolverStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405262093a80600255610e10600355348015601b575f5ffd5b505f80546001600160a01b031916331790556143a28061003a5f395ff3fe6080604052600436106101e6575f3560e01c80637c88943511610108578063b73eb2d51161009d578063d4478b0e1161006d578063d4478b0e146105af578063e37347e6146105ce578063ed70ee19146105ed578063eea330f914610602578063f62ac70f1461062e575f5ffd5b8063b73eb2d514610521578063b87fcbff14610540578063bdc503731461056e578063c1659e5814610590575f5ffd5b80638da5cb5b116100d85780638da5cb5b146104b257806398b871f8146104d05780639fcfef26146104e3578063a6d5118514610502575f5ffd5b80637c8894351461042257806383b66dd214610436578063874fa473146104555780638cba8b6a14610474575f5ffd5b8063448a4dc31161017e578063597df2751161014e578063597df275146103985780635c6c6319146103b75780635ffd6851146103ee5780636cf6d6751461040d575f5ffd5b8063448a4dc3146102f05780634497dc991461032a578063523480801461035657806357691f2e14610375575f5ffd5b806327f3dba2116101b957806327f3dba2146102675780632fdc6e641461029357806337d4bb56146102b25780633dbb1b8f146102d1575f5ffd5b80631505d595146101ea578063179ff4b21461020b578063195b0925146102355780631cfc014414610254575b5f5ffd5b3480156101f5575f5ffd5b50610209610204366004613415565b61064d565b005b348015610216575f5ffd5b5061021f610889565b60405161022c919061348a565b60405180910390f35b348015610240575f5ffd5b5061020961024f366004613568565b610b57565b6102096102623660046135cf565b610c13565b348015610272575f5ffd5b5061028661028136600461360d565b610cf3565b60405161022c9190613713565b34801561029e575f5ffd5b506102096102ad3660046137c7565b6110fd565b3480156102bd575f5ffd5b506102096102cc3660046135cf565b611161565b3480156102dc575f5ffd5b506102096102eb3660046135cf565b6112b9565b3480156102fb575f5ffd5b5061030f61030a3660046135cf565b6114bd565b6040805193845260208401929092529082015260600161022c565b348015610335575f5ffd5b506103496103443660046135cf565b611539565b60405161022c91906137de565b348015610361575f5ffd5b5061020961037036600461381f565b6116f9565b348015610380575f5ffd5b5061038a60015481565b60405190815260200161022c565b3480156103a3575f5ffd5b506102096103b236600461360d565b611780565b3480156103c2575f5ffd5b506006546103d6906001600160a01b031681565b6040516001600160a01b03909116815260200161022c565b3480156103f9575f5ffd5b50610209610408366004613865565b6117f0565b348015610418575f5ffd5b5061038a60025481565b34801561042d575f5ffd5b50600a5461038a565b348015610441575f5ffd5b50610209610450366004613415565b611aa4565b348015610460575f5ffd5b5061020961046f366004613913565b611bbf565b34801561047f575f5ffd5b506104a261048e36600461397d565b60056020525f908152604090205460ff1681565b604051901515815260200161022c565b3480156104bd575f5ffd5b505f546103d6906001600160a01b031681565b6102096104de366004613996565b61202d565b3480156104ee575f5ffd5b506102096104fd366004613913565b612334565b34801561050d575f5ffd5b5061020961051c366004613996565b6123f0565b34801561052c575f5ffd5b506103d661053b3660046135cf565b612505565b34801561054b575f5ffd5b506104a261055a36600461397d565b60046020525f908152604090205460ff1681565b348015610579575f5ffd5b50610582612524565b60405161022c929190613a2c565b34801561059b575f5ffd5b506102096105aa3660046135cf565b61269e565b3480156105ba575f5ffd5b506102096105c936600461397d565b61284b565b3480156105d9575f5ffd5b506102096105e836600461397d565b61292e565b3480156105f8575f5ffd5b5061038a60035481565b34801561060d575f5ffd5b5061062161061c3660046135cf565b6129d3565b60405161022c9190613aa1565b348015610639575f5ffd5b5061020961064836600461381f565b612b0f565b5f546001600160a01b03163314806106735750335f9081526004602052604090205460ff165b6106985760405162461bcd60e51b815260040161068f90613ab3565b60405180910390fd5b5f600984846040516106ab929190613adb565b908152602001604051809103902090505f816003015483106106d15781600301546106d3565b825b905080826003015f8282546106e89190613afe565b925050819055505f600b8686604051610702929190613adb565b908152602001604051809103902090505f816001015483866107249190613afe565b1061073357816001015461073d565b61073d8386613afe565b905080826001015f8282546107529190613afe565b909155505f90506107638285613b11565b90505f81116107a75760405162461bcd60e51b815260206004820152601060248201526f09cdee8d0d2dcce40e8de40e6d8c2e6d60831b604482015260640161068f565b5f80546040516001600160a01b039091169083908381818185875af1925050503d805f81146107f1576040519150601f19603f3d011682016040523d82523d5f602084013e6107f6565b606091505b50509050806108395760405162461bcd60e51b815260206004820152600f60248201526e151c985b9cd9995c8819985a5b1959608a1b604482015260640161068f565b336001600160a01b03167fd8177226b11b04915c7cf46f189b793397879dfa6b9c802c9580280253a9e93c8a8a8560405161087693929190613b4c565b60405180910390a2505050505050505050565b6008546060906001600160401b038111156108a6576108a6613b6f565b60405190808252806020026020018201604052801561090057816020015b6040805160a0810182526060808252602082018190525f9282018390528082015260808101919091528152602001906001900390816108c45790505b5090505f5b600854811015610b535760075f6008838154811061092557610925613b83565b5f9182526020808320909101546001600160a01b031683528201929092526040908101909120815160a0810190925280548290829061096390613b97565b80601f016020809104026020016040519081016040528092919081815260200182805461098f90613b97565b80156109da5780601f106109b1576101008083540402835291602001916109da565b820191905f5260205f20905b8154815290600101906020018083116109bd57829003601f168201915b505050505081526020016001820180546109f390613b97565b80601f0160208091040260200160405190810160405280929190818152602001828054610a1f90613b97565b8015610a6a5780601f10610a4157610100808354040283529160200191610a6a565b820191905f5260205f20905b815481529060010190602001808311610a4d57829003601f168201915b5050509183525050600282015463ffffffff166020820152600382018054604090920191610a9790613b97565b80601f0160208091040260200160405190810160405280929190818152602001828054610ac390613b97565b8015610b0e5780601f10610ae557610100808354040283529160200191610b0e565b820191905f5260205f20905b815481529060010190602001808311610af157829003601f168201915b5050509183525050600491909101546001600160a01b03166020909101528251839083908110610b4057610b40613b83565b6020908102919091010152600101610905565b5090565b5f610b628484612bcd565b60028101549091506001600160a01b0316331480610b8957505f546001600160a01b031633145b610ba55760405162461bcd60e51b815260040161068f90613ab3565b81600c8585604051610bb8929190613adb565b908152604051908190036020019020610bd18282613df9565b9050507f8cd8c2d9a114b6aab89a36737cb6d156dc70cf1bc123d54fb0acee5bf3ab79608484604051610c05929190613f54565b60405180910390a150505050565b5f610c1e8383612bcd565b60028101549091506001600160a01b03163314610c4d5760405162461bcd60e51b815260040161068f90613ab3565b5f3411610c945760405162461bcd60e51b81526020600482015260156024820152745374616b652063616e6e6f7420626520656d70747960581b604482015260640161068f565b34816003015f828254610ca79190613b11565b909155505060038101546040517fec4366777d5ce32d10f92e972ff65ef95ef8f9d63af5dcbaa98c8fbc368b546391610ce69186918691349190613f67565b60405180910390a1505050565b600a546060908310610d3757604080515f8082526020820190925290610d2f565b610d1c61323c565b815260200190600190039081610d145790505b5090506110f7565b600a545f908390610d49908690613afe565b10610d5d57610d588385613b11565b610d61565b600a545b9050610d6d8482613afe565b6001600160401b03811115610d8457610d84613b6f565b604051908082528060200260200182016040528015610dbd57816020015b610daa61323c565b815260200190600190039081610da25790505b509150835b818110156110f4575f600a8281548110610dde57610dde613b83565b905f5260205f200190505f600982604051610df99190613ffb565b908152602001604051809103902090506040518060a00160405280838054610e2090613b97565b80601f0160208091040260200160405190810160405280929190818152602001828054610e4c90613b97565b8015610e975780601f10610e6e57610100808354040283529160200191610e97565b820191905f5260205f20905b815481529060010190602001808311610e7a57829003601f168201915b50505050508152602001825f018054610eaf90613b97565b80601f0160208091040260200160405190810160405280929190818152602001828054610edb90613b97565b8015610f265780601f10610efd57610100808354040283529160200191610f26565b820191905f5260205f20905b815481529060010190602001808311610f0957829003601f168201915b505050918352505060028301546001600160a01b03166020820152600383015460408083019190915251606090910190600c90610f64908690613ffb565b908152604080519182900360209081018320805460a09281028501830190935260808401838152909284928491905f9085015b8282101561103f578382905f5260205f20018054610fb490613b97565b80601f0160208091040260200160405190810160405280929190818152602001828054610fe090613b97565b801561102b5780601f106110025761010080835404028352916020019161102b565b820191905f5260205f20905b81548152906001019060200180831161100e57829003601f168201915b505050505081526020019060010190610f97565b5050505081526020016001820180548060200260200160405190810160405280929190818152602001828054801561109457602002820191905f5260205f20905b815481526020019060010190808311611080575b50505091835250506002919091015463ffffffff81166020830152640100000000900460ff1615156040909101529052856110cf8986613afe565b815181106110df576110df613b83565b60209081029190910101525050600101610dc2565b50505b92915050565b5f546001600160a01b031633146111265760405162461bcd60e51b815260040161068f90613ab3565b60038190556040518181527f4d5891cea464eec920af2cf0a1166abc1e0eb8f53d63ae82a540aa6bf32d53979060200160405180910390a150565b5f61116c8383612bcd565b60028101549091506001600160a01b031633148061119357505f546001600160a01b031633145b6111af5760405162461bcd60e51b815260040161068f90613ab3565b600381015460028201546040516001600160a01b03909116906009906111d89087908790613adb565b9081526040519081900360200190205f6111f282826132a0565b505f600182018190556002820180546001600160a01b0319169055600390910155604051600c906112269087908790613adb565b9081526040519081900360200190205f61124082826132d7565b61124d600183015f6132f2565b50600201805464ffffffffff191690556112678585612c2c565b7f2b50bc55e1a16067cf46920ac4b73ac3ad2d71688b6df915f5d6706a08bc66b68585604051611298929190613f54565b60405180910390a181156112b2576112b285858385612d13565b5050505050565b5f600b83836040516112cc929190613adb565b908152604080516020928190038301812060608201835280546001600160a01b0316825260018101549382018490526002015491810191909152915061134a5760405162461bcd60e51b81526020600482015260136024820152724e6f7468696e6720746f20776974686472617760681b604482015260640161068f565b80516001600160a01b031633146113735760405162461bcd60e51b815260040161068f90613ab3565b80604001514210156113975760405162461bcd60e51b815260040161068f90614006565b600b83836040516113a9929190613adb565b9081526040516020918190038201812080546001600160a01b03191681555f60018201819055600290910181905583519284015190926001600160a01b0316915f6040518083038185875af1925050503d805f8114611423576040519150601f19603f3d011682016040523d82523d5f602084013e611428565b606091505b505090508061146b5760405162461bcd60e51b815260206004820152600f60248201526e151c985b9cd9995c8819985a5b1959608a1b604482015260640161068f565b815f01516001600160a01b03167f977b65de01de109637af6cd9cb96f3e82b3c2642a08255d7977aedf28fa24502858585602001516040516114af93929190613b4c565b60405180910390a250505050565b5f5f5f600985856040516114d2929190613adb565b9081526020016040518091039020600301549250600b85856040516114f8929190613adb565b9081526020016040518091039020600101549150600b858560405161151e929190613adb565b90815260200160405180910390206002015490509250925092565b604080516080810182526060808252602082018190525f9282018390528101919091526115668383612db6565b6115825760405162461bcd60e51b815260040161068f90614032565b600c8383604051611594929190613adb565b908152604080519182900360209081018320805460a09281028501830190935260808401838152909284928491905f9085015b8282101561166f578382905f5260205f200180546115e490613b97565b80601f016020809104026020016040519081016040528092919081815260200182805461161090613b97565b801561165b5780601f106116325761010080835404028352916020019161165b565b820191905f5260205f20905b81548152906001019060200180831161163e57829003601f168201915b5050505050815260200190600101906115c7565b505050508152602001600182018054806020026020016040519081016040528092919081815260200182805480156116c457602002820191905f5260205f20905b8154815260200190600101908083116116b0575b50505091835250506002919091015463ffffffff81166020830152640100000000900460ff1615156040909101529392505050565b5f546001600160a01b031633146117225760405162461bcd60e51b815260040161068f90613ab3565b6001600160a01b0382165f81815260046020908152604091829020805460ff191685151590811790915591519182527feb3af117484a092137d31b606301615ef829364c4c501ae769735869d31d1d51910160405180910390a25050565b5f546001600160a01b031633146117a95760405162461bcd60e51b815260040161068f90613ab3565b6001829055600281905560408051838152602081018390527fba51fd4d4127b8e4df59c2cd64d1c4d945320058c8e6e496f95ba0dd3b0b86e1910160405180910390a15050565b8561183d5760405162461bcd60e51b815260206004820152601a60248201527f52656c617965722049502063616e6e6f7420626520656d707479000000000000604482015260640161068f565b5f546001600160a01b03163314806118635750335f9081526005602052604090205460ff165b6118a65760405162461bcd60e51b815260206004820152601460248201527314995b185e595c881b9bdd08185c1c1c9bdd995960621b604482015260640161068f565b335f908152600760205260409020600401546001600160a01b031661190757600880546001810182555f919091527ff3f7a9fe364faab93b216da50a3214154f22a0a2b415b23a84c8169e8b636ee30180546001600160a01b031916331790555b6040518060a0016040528088888080601f0160208091040260200160405190810160405280939291908181526020018383808284375f92019190915250505090825250604080516020601f8901819004810282018101909252878152918101919088908890819084018382808284375f9201919091525050509082525063ffffffff851660208083019190915260408051601f860183900483028101830182528581529201919085908590819084018382808284375f9201829052509385525050336020938401819052825250600790915260409020815181906119eb908261405e565b5060208201516001820190611a00908261405e565b50604082015160028201805463ffffffff191663ffffffff90921691909117905560608201516003820190611a35908261405e565b5060809190910151600490910180546001600160a01b0319166001600160a01b0390921691909117905560405133907f119261697411a80cecee79669b3569ce033e07a0353a51c300b8e72f6e0e187d90611a93908a908a90613f54565b60405180910390a250505050505050565b5f611aaf8484612bcd565b60028101549091506001600160a01b03163314611ade5760405162461bcd60e51b815260040161068f90613ab3565b5f82118015611af1575080600301548211155b611b2e5760405162461bcd60e51b815260206004820152600e60248201526d125b9d985b1a5908185b5bdd5b9d60921b604482015260640161068f565b600154828260030154611b419190613afe565b1015611b855760405162461bcd60e51b81526020600482015260136024820152725374616b652062656c6f77206d696e696d756d60681b604482015260640161068f565b81816003015f828254611b989190613afe565b90915550506002810154611bb990859085906001600160a01b031685612d13565b50505050565b5f611bca8585612bcd565b905081611c195760405162461bcd60e51b815260206004820152601a60248201527f5075626c6963206b65792063616e6e6f7420626520656d707479000000000000604482015260640161068f565b611c238383612db6565b15611c705760405162461bcd60e51b815260206004820152601b60248201527f5265736f6c76657220616c726561647920726567697374657265640000000000604482015260640161068f565b5f546001600160a01b0316331480611c94575060028101546001600160a01b031633145b80611cb95750611ca48585612e0f565b6001600160a01b0316336001600160a01b0316145b611cd55760405162461bcd60e51b815260040161068f90613ab3565b600b8383604051611ce7929190613adb565b9081526020016040518091039020600101545f1480611d40575060028101546040516001600160a01b0390911690600b90611d259086908690613adb565b908152604051908190036020019020546001600160a01b0316145b611d5c5760405162461bcd60e51b815260040161068f90614006565b5f60035442611d6b9190613b11565b90506040518060800160405280835f018054611d8690613b97565b80601f0160208091040260200160405190810160405280929190818152602001828054611db290613b97565b8015611dfd5780601f10611dd457610100808354040283529160200191611dfd565b820191905f5260205f20905b815481529060010190602001808311611de057829003601f168201915b50505091835250505f602082015260028401546001600160a01b0316604080830191909152600385015460609092019190915251600990611e419087908790613adb565b90815260405190819003602001902081518190611e5e908261405e565b5060208201516001828101919091556040808401516002840180546001600160a01b0319166001600160a01b0390921691909117905560609093015160039283015584018390555f9084015551600c90611ebb9088908890613adb565b9081526020016040518091039020600c8585604051611edb929190613adb565b9081526040519081900360200190208154611ef9908290849061330d565b5060018281018054611f0e928401919061335d565b506002918201805491909201805463ffffffff90921663ffffffff19831681178255925464ffffffffff199092169092176401000000009182900460ff1615159091021790555f5b600a54811015611fe5578686604051611f70929190613adb565b6040518091039020600a8281548110611f8b57611f8b613b83565b905f5260205f2001604051611fa09190613ffb565b604051809103902003611fdd578484600a8381548110611fc257611fc2613b83565b905f5260205f20019182611fd7929190613caf565b50611fe5565b600101611f56565b507ff285d5b07010e42e51c652485826beb7cae0cc5fe4983ffda626d7a1a88a458c868686868560405161201d959493929190614113565b60405180910390a1505050505050565b8361204a5760405162461bcd60e51b815260040161068f9061414c565b816120975760405162461bcd60e51b815260206004820152601a60248201527f5075626c6963206b65792063616e6e6f7420626520656d707479000000000000604482015260640161068f565b6120a18383612db6565b156120ee5760405162461bcd60e51b815260206004820152601b60248201527f5265736f6c76657220616c726561647920726567697374657265640000000000604482015260640161068f565b6001543410156121355760405162461bcd60e51b8152602060048201526012602482015271496e73756666696369656e74207374616b6560701b604482015260640161068f565b600b8383604051612147929190613adb565b9081526020016040518091039020600101545f14806121985750336001600160a01b0316600b848460405161217d929190613adb565b908152604051908190036020019020546001600160a01b0316145b6121b45760405162461bcd60e51b815260040161068f90614006565b604051806080016040528086868080601f0160208091040260200160405190810160405280939291908181526020018383808284375f92018290525093855250505060208201523360408083019190915234606090920191909152516009906122209086908690613adb565b9081526040519081900360200190208151819061223d908261405e565b50602082015160018201556040808301516002830180546001600160a01b0319166001600160a01b03909216919091179055606090920151600390910155518190600c9061228e9086908690613adb565b9081526040519081900360200190206122a78282613df9565b5050600a80546001810182555f919091527fc65a7bb8d6351c1cf70c95a316cc6a92839c986682d98bc35f958f4883f9d2a8016122e5838583613caf565b50336001600160a01b03167f14631046fd60db37cdcaa521f914707d5a6ca8809e87206c3a569ee246a1e05a848488886040516123259493929190614183565b60405180910390a25050505050565b806123515760405162461bcd60e51b815260040161068f9061414c565b5f61235c8585612bcd565b60028101549091506001600160a01b031633148061238357505f546001600160a01b031633145b61239f5760405162461bcd60e51b815260040161068f90613ab3565b806123ab838583613caf565b507f02dfabd5fefe902b33b340e710440433dfd6d0f8e8e107aa01a778b50d1f5c37858585856040516123e19493929190614183565b60405180910390a15050505050565b8161240d5760405162461bcd60e51b815260040161068f9061414c565b5f6124188686612bcd565b60028101549091506001600160a01b031633148061243f57505f546001600160a01b031633145b61245b5760405162461bcd60e51b815260040161068f90613ab3565b80612467848683613caf565b5081600c878760405161247b929190613adb565b9081526040519081900360200190206124948282613df9565b9050507f02dfabd5fefe902b33b340e710440433dfd6d0f8e8e107aa01a778b50d1f5c37868686866040516124cc9493929190614183565b60405180910390a17f8cd8c2d9a114b6aab89a36737cb6d156dc70cf1bc123d54fb0acee5bf3ab7960868660405161201d929190613f54565b5f6125108383612bcd565b600201546001600160a01b03169392505050565b6006546001600160a01b03165f9081526007602052604090208054606091829161254d90613b97565b80601f016020809104026020016040519081016040528092919081815260200182805461257990613b97565b80156125c45780601f1061259b576101008083540402835291602001916125c4565b820191905f5260205f20905b8154815290600101906020018083116125a757829003601f168201915b50505050509150600a805480602002602001604051908101604052809291908181526020015f905b82821015612694578382905f5260205f2001805461260990613b97565b80601f016020809104026020016040519081016040528092919081815260200182805461263590613b97565b80156126805780601f1061265757610100808354040283529160200191612680565b820191905f5260205f20905b81548152906001019060200180831161266357829003601f168201915b5050505050815260200190600101906125ec565b5050505090509091565b5f600983836040516126b1929190613adb565b908152602001604051809103902090505f815f0180546126d090613b97565b90501180156126e25750600181015415155b61272e5760405162461bcd60e51b815260206004820152601860248201527f5265736f6c766572206b6579206e6f7420726f74617465640000000000000000604482015260640161068f565b80600101544210156127825760405162461bcd60e51b815260206004820152601860248201527f5265736f6c766572206b6579206e6f7420657870697265640000000000000000604482015260640161068f565b60098383604051612794929190613adb565b9081526040519081900360200190205f6127ae82826132a0565b505f600182018190556002820180546001600160a01b0319169055600390910155604051600c906127e29085908590613adb565b9081526040519081900360200190205f6127fc82826132d7565b612809600183015f6132f2565b50600201805464ffffffffff191690556040517f2b50bc55e1a16067cf46920ac4b73ac3ad2d71688b6df915f5d6706a08bc66b690610ce69085908590613f54565b5f546001600160a01b031633146128745760405162461bcd60e51b815260040161068f90613ab3565b6001600160a01b03811615806128a557506001600160a01b038181165f908152600760205260409020600401541615155b6128e55760405162461bcd60e51b815260206004820152601160248201527014995b185e595c881b9bdd08199bdd5b99607a1b604482015260640161068f565b600680546001600160a01b0319166001600160a01b0383169081179091556040517f35481457cd222ebd02e4f2750d2505c2eac004e0cf60aeebcaa793c6dfbaceb3905f90a250565b6001600160a01b038181165f908152600760205260409020600401541661298b5760405162461bcd60e51b815260206004820152601160248201527014995b185e595c881b9bdd08199bdd5b99607a1b604482015260640161068f565b336001600160a01b03821614806129ab57505f546001600160a01b031633145b6129c75760405162461bcd60e51b815260040161068f90613ab3565b6129d081613040565b50565b60605f600984846040516129e8929190613adb565b908152602001604051809103902090505f815f018054612a0790613b97565b905011612a265760405162461bcd60e51b815260040161068f90614032565b60018101541580612a3a5750806001015442105b612a7d5760405162461bcd60e51b815260206004820152601460248201527314995cdbdb1d995c881ad95e48195e1c1a5c995960621b604482015260640161068f565b80548190612a8a90613b97565b80601f0160208091040260200160405190810160405280929190818152602001828054612ab690613b97565b8015612b015780601f10612ad857610100808354040283529160200191612b01565b820191905f5260205f20905b815481529060010190602001808311612ae457829003601f168201915b505050505091505092915050565b5f546001600160a01b03163314612b385760405162461bcd60e51b815260040161068f90613ab3565b6001600160a01b0382165f81815260056020908152604091829020805460ff191685151590811790915591519182527fc18c96b23e5afbaf72346174b2e46029640a336fed31d8eb543c7d9e38ca5d76910160405180910390a280158015612bbb57506001600160a01b038281165f908152600760205260409020600401541615155b15612bc957612bc982613040565b5050565b5f60098383604051612be0929190613adb565b908152602001604051809103902090505f815f018054612bff90613b97565b9050118015612c1057506001810154155b6110f75760405162461bcd60e51b815260040161068f90614032565b5f5b600a54811015612d0e578282604051612c48929190613adb565b6040518091039020600a8281548110612c6357612c63613b83565b905f5260205f2001604051612c789190613ffb565b604051809103902003612d0657600a8054612c9590600190613afe565b81548110612ca557612ca5613b83565b905f5260205f2001600a8281548110612cc057612cc0613b83565b905f5260205f20019081612cd491906141b4565b50600a805480612ce657612ce6614274565b600190038181905f5260205f20015f612cff91906132a0565b9055505050565b600101612c2e565b505050565b5f600b8585604051612d26929190613adb565b90815260405190819003602001902080546001600160a01b0385166001600160a01b031990911617815560018101805491925083915f90612d68908490613b11565b9091555050600254612d7a9042613b11565b600282018190556040517f7baaf4f3fb0bee5e014580d827d63c3e5c477c0a5eaabe18000c718d4987eecd916123e19188918891879190613f67565b5f5f60098484604051612dca929190613adb565b908152602001604051809103902090505f815f018054612de990613b97565b9050118015612e07575060018101541580612e075750806001015442105b949350505050565b5f602182141580612e7d575082825f818110612e2d57612e2d613b83565b9050013560f81c60f81b6001600160f81b031916600260f81b14158015612e7d575082825f818110612e6157612e61613b83565b9050013560f81c60f81b6001600160f81b031916600360f81b14155b15612e8957505f6110f7565b5f612e98602160018587614288565b612ea1916142af565b90505f6401000003d01960076401000003d019846401000003d019868709090890505f80600560208080866004612edf6401000003d0196001613b11565b612ee991906142e0565b6040805160208101969096528501939093526060840191909152608083015260a08201526401000003d01960c082015260e00160408051601f1981840301815290829052612f36916142f3565b5f60405180830381855afa9150503d805f8114612f6e576040519150601f19603f3d011682016040523d82523d5f602084013e612f73565b606091505b509150915081612f89575f9450505050506110f7565b5f81806020019051810190612f9e9190614309565b9050836401000003d01982830914612fbd575f955050505050506110f7565b600288885f818110612fd157612fd1613b83565b612fe29392013560f81c9050614320565b60ff16612ff0600283614341565b1461300857613005816401000003d019613afe565b90505b604080516020810187905290810182905260600160408051601f19818403018152919052805160209091012098975050505050505050565b6001600160a01b0381165f9081526007602052604081209061306282826132a0565b61306f600183015f6132a0565b60028201805463ffffffff1916905561308b600383015f6132a0565b5060040180546001600160a01b03191690555f5b6008548110156131b657816001600160a01b0316600882815481106130c6576130c6613b83565b5f918252602090912001546001600160a01b0316036131ae57805b6008546130ef826001613b11565b1015613177576008613102826001613b11565b8154811061311257613112613b83565b5f91825260209091200154600880546001600160a01b03909216918390811061313d5761313d613b83565b5f91825260209091200180546001600160a01b0319166001600160a01b03929092169190911790558061316f81614354565b9150506130e1565b50600880548061318957613189614274565b5f8281526020902081015f1990810180546001600160a01b03191690550190556131b6565b60010161309f565b506040516001600160a01b038216907f10e1f7ce9fd7d1b90a66d13a2ab3cb8dd7f29f3f8d520b143b063ccfbab6906b905f90a26006546001600160a01b038083169116036129d057600680546001600160a01b03191690556040515f907f35481457cd222ebd02e4f2750d2505c2eac004e0cf60aeebcaa793c6dfbaceb3908290a250565b6040518060a0016040528060608152602001606081526020015f6001600160a01b031681526020015f815260200161329b604051806080016040528060608152602001606081526020015f63ffffffff1681526020015f151581525090565b905290565b5080546132ac90613b97565b5f825580601f106132bb575050565b601f0160209004905f5260205f20908101906129d091906133a1565b5080545f8255905f5260205f20908101906129d091906133b5565b5080545f8255905f5260205f20908101906129d091906133a1565b828054828255905f5260205f20908101928215613351575f5260205f209182015b82811115613351578161334184826141b4565b509160010191906001019061332e565b50610b539291506133b5565b828054828255905f5260205f20908101928215613399575f5260205f209182015b8281111561339957825482559160010191906001019061337e565b50610b539291505b5b80821115610b53575f81556001016133a2565b80821115610b53575f6133c882826132a0565b506001016133b5565b5f5f83601f8401126133e1575f5ffd5b5081356001600160401b038111156133f7575f5ffd5b60208301915083602082850101111561340e575f5ffd5b9250929050565b5f5f5f60408486031215613427575f5ffd5b83356001600160401b0381111561343c575f5ffd5b613448868287016133d1565b909790965060209590950135949350505050565b5f81518084528060208401602086015e5f602082860101526020601f19601f83011685010191505092915050565b5f602082016020835280845180835260408501915060408160051b8601019250602086015f5b8281101561354657603f198786030184528151805160a087526134d660a088018261345c565b9050602082015187820360208901526134ef828261345c565b91505063ffffffff604083015116604088015260608201518782036060890152613519828261345c565b6080938401516001600160a01b0316989093019790975250945060209384019391909101906001016134b0565b50929695505050505050565b5f60808284031215613562575f5ffd5b50919050565b5f5f5f6040848603121561357a575f5ffd5b83356001600160401b0381111561358f575f5ffd5b61359b868287016133d1565b90945092505060208401356001600160401b038111156135b9575f5ffd5b6135c586828701613552565b9150509250925092565b5f5f602083850312156135e0575f5ffd5b82356001600160401b038111156135f5575f5ffd5b613601858286016133d1565b90969095509350505050565b5f5f6040838503121561361e575f5ffd5b50508035926020909101359150565b5f8151808452602084019350602083015f5b8281101561365d57815186526020958601959091019060010161363f565b5093949350505050565b5f6080830182516080855281815180845260a08701915060a08160051b88010193506020830192505f5b818110156136c257609f198886030183526136ad85855161345c565b94506020938401939290920191600101613691565b50505050602083015184820360208601526136dd828261362d565b91505060408301516136f7604086018263ffffffff169052565b50606083015161370b606086018215159052565b509392505050565b5f602082016020835280845180835260408501915060408160051b8601019250602086015f5b8281101561354657603f198786030184528151805160a0875261375f60a088018261345c565b905060208201518782036020890152613778828261345c565b91505060018060a01b036040830151166040880152606082015160608801526080820151915086810360808801526137b08183613667565b965050506020938401939190910190600101613739565b5f602082840312156137d7575f5ffd5b5035919050565b602081525f6137f06020830184613667565b9392505050565b80356001600160a01b038116811461380d575f5ffd5b919050565b80151581146129d0575f5ffd5b5f5f60408385031215613830575f5ffd5b613839836137f7565b9150602083013561384981613812565b809150509250929050565b63ffffffff811681146129d0575f5ffd5b5f5f5f5f5f5f5f6080888a03121561387b575f5ffd5b87356001600160401b03811115613890575f5ffd5b61389c8a828b016133d1565b90985096505060208801356001600160401b038111156138ba575f5ffd5b6138c68a828b016133d1565b90965094505060408801356138da81613854565b925060608801356001600160401b038111156138f4575f5ffd5b6139008a828b016133d1565b989b979a50959850939692959293505050565b5f5f5f5f60408587031215613926575f5ffd5b84356001600160401b0381111561393b575f5ffd5b613947878288016133d1565b90955093505060208501356001600160401b03811115613965575f5ffd5b613971878288016133d1565b95989497509550505050565b5f6020828403121561398d575f5ffd5b6137f0826137f7565b5f5f5f5f5f606086880312156139aa575f5ffd5b85356001600160401b038111156139bf575f5ffd5b6139cb888289016133d1565b90965094505060208601356001600160401b038111156139e9575f5ffd5b6139f5888289016133d1565b90945092505060408601356001600160401b03811115613a13575f5ffd5b613a1f88828901613552565b9150509295509295909350565b604081525f613a3e604083018561345c565b828103602084015280845180835260208301915060208160051b840101602087015f5b83811015613a9357601f19868403018552613a7d83835161345c565b6020958601959093509190910190600101613a61565b509098975050505050505050565b602081525f6137f0602083018461345c565b6020808252600e908201526d139bdd08185d5d1a1bdc9a5e995960921b604082015260600190565b818382375f9101908152919050565b634e487b7160e01b5f52601160045260245ffd5b818103818111156110f7576110f7613aea565b808201808211156110f7576110f7613aea565b81835281816020850137505f828201602090810191909152601f909101601f19169091010190565b604081525f613b5f604083018587613b24565b9050826020830152949350505050565b634e487b7160e01b5f52604160045260245ffd5b634e487b7160e01b5f52603260045260245ffd5b600181811c90821680613bab57607f821691505b60208210810361356257634e487b7160e01b5f52602260045260245ffd5b5f5f8335601e19843603018112613bde575f5ffd5b8301803591506001600160401b03821115613bf7575f5ffd5b6020019150600581901b360382131561340e575f5ffd5b5f5f8335601e19843603018112613c23575f5ffd5b8301803591506001600160401b03821115613c3c575f5ffd5b60200191503681900382131561340e575f5ffd5b5b81811015612bc9575f8155600101613c51565b5f19600383901b1c191660019190911b1790565b601f821115612d0e57805f5260205f20601f840160051c81016020851015613c9d5750805b6112b2601f850160051c830182613c50565b6001600160401b03831115613cc657613cc6613b6f565b613cda83613cd48354613b97565b83613c78565b5f601f841160018114613d06575f8515613cf45750838201355b613cfe8682613c64565b8455506112b2565b5f83815260208120601f198716915b82811015613d355786850135825560209485019460019092019101613d15565b5086821015613d51575f1960f88860031b161c19848701351681555b505060018560011b0183555050505050565b6001600160401b03831115613d7a57613d7a613b6f565b600160401b831115613d8e57613d8e613b6f565b805483825580841015613db257815f5260205f20613db0828201868301613c50565b505b5081815f5260205f205f5b85811015613dd957823582820155602090920191600101613dbd565b505050505050565b5f81356110f781613854565b5f81356110f781613812565b613e038283613bc9565b600160401b811115613e1757613e17613b6f565b825481845580821015613e9b575f848152602090208281019082015b80821015613e9857613e458254613b97565b8015613e8c57601f811160018114613e5f575f8455613e8a565b5f84815260209020613e7c601f840160051c820160018301613c50565b505f84815260208120818655555b505b50600182019150613e33565b50505b505f8381526020812083915b83811015613ed957613eb98386613c0e565b613ec4818386613caf565b50506020929092019160019182019101613ea7565b5050505050613eeb6020830183613bc9565b613ef9818360018601613d63565b505060028101613f25613f0e60408501613de1565b825463ffffffff191663ffffffff91909116178255565b612d0e613f3460608501613ded565b82805464ff00000000191691151560201b64ff0000000016919091179055565b602081525f612e07602083018486613b24565b606081525f613f7a606083018688613b24565b6020830194909452506040015292915050565b5f8154613f9981613b97565b600182168015613fb05760018114613fc557613ff2565b60ff1983168652811515820286019350613ff2565b845f5260205f205f5b83811015613fea57815488820152600190910190602001613fce565b505081860193505b50505092915050565b5f6137f08284613f8d565b6020808252601290820152715374616b6520697320756e626f6e64696e6760701b604082015260600190565b60208082526012908201527114995cdbdb1d995c881b9bdd08199bdd5b9960721b604082015260600190565b81516001600160401b0381111561407757614077613b6f565b61408b816140858454613b97565b84613c78565b6020601f8211600181146140b8575f83156140a65750848201515b6140b08482613c64565b8555506112b2565b5f84815260208120601f198516915b828110156140e757878501518255602094850194600190920191016140c7565b508482101561410457868401515f19600387901b60f8161c191681555b50505050600190811b01905550565b606081525f614126606083018789613b24565b8281036020840152614139818688613b24565b9150508260408301529695505050505050565b6020808252601b908201527f5265736f6c7665722049502063616e6e6f7420626520656d7074790000000000604082015260600190565b604081525f614196604083018688613b24565b82810360208401526141a9818587613b24565b979650505050505050565b8181036141bf575050565b6141c98254613b97565b6001600160401b038111156141e0576141e0613b6f565b6141ee816140858454613b97565b5f601f821160018114614211575f83156140a65750848201546140b08482613c64565b5f8581526020808220868352908220601f198616925b838110156142475782860154825560019586019590910190602001614227565b508583101561426457818501545f19600388901b60f8161c191681555b5050505050600190811b01905550565b634e487b7160e01b5f52603160045260245ffd5b5f5f85851115614296575f5ffd5b838611156142a2575f5ffd5b5050820193919092039150565b803560208310156110f7575f19602084900360031b1b1692915050565b634e487b7160e01b5f52601260045260245ffd5b5f826142ee576142ee6142cc565b500490565b5f82518060208501845e5f920191825250919050565b5f60208284031215614319575f5ffd5b5051919050565b5f60ff831680614332576143326142cc565b8060ff84160691505092915050565b5f8261434f5761434f6142cc565b500690565b5f6001820161436557614365613aea565b506001019056fea2646970667358221220556e83e370a2a21f1508505ecf5efe085d3e1b7cb05750d0af4b7f2ec6e3881764736f6c634300081e0033",
 }
 
 // NodeRegistryABI is the input ABI used to generate the binding from.
@@ -202,6 +228,37 @@ func (_NodeRegistry *NodeRegistryTransactorRaw) Transact(opts *bind.TransactOpts
 	return _NodeRegistry.Contract.contract.Transact(opts, method, params...)
 }
 
+// ApprovedRelayers is a free data retrieval call binding the contract method 0x8cba8b6a.
+//
+// Solidity: function approvedRelayers(address ) view returns(bool)
+func (_NodeRegistry *NodeRegistryCaller) ApprovedRelayers(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "approvedRelayers", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// ApprovedRelayers is a free data retrieval call binding the contract method 0x8cba8b6a.
+//
+// Solidity: function approvedRelayers(address ) view returns(bool)
+func (_NodeRegistry *NodeRegistrySession) ApprovedRelayers(arg0 common.Address) (bool, error) {
+	return _NodeRegistry.Contract.ApprovedRelayers(&_NodeRegistry.CallOpts, arg0)
+}
+
+// ApprovedRelayers is a free data retrieval call binding the contract method 0x8cba8b6a.
+//
+// Solidity: function approvedRelayers(address ) view returns(bool)
+func (_NodeRegistry *NodeRegistryCallerSession) ApprovedRelayers(arg0 common.Address) (bool, error) {
+	return _NodeRegistry.Contract.ApprovedRelayers(&_NodeRegistry.CallOpts, arg0)
+}
+
 // GetRelayer is a free data retrieval call binding the contract method 0xbdc50373.
 //
 // Solidity: function getRelayer() view returns(string ip, bytes[] publicKeys)
@@ -247,6 +304,37 @@ func (_NodeRegistry *NodeRegistryCallerSession) GetRelayer() (struct {
 	return _NodeRegistry.Contract.GetRelayer(&_NodeRegistry.CallOpts)
 }
 
+// GetRelayers is a free data retrieval call binding the contract method 0x179ff4b2.
+//
+// Solidity: function getRelayers() view returns((string,string,uint32,bytes,address)[] result)
+func (_NodeRegistry *NodeRegistryCaller) GetRelayers(opts *bind.CallOpts) ([]NodeRegistryRelayer, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "getRelayers")
+
+	if err != nil {
+		return *new([]NodeRegistryRelayer), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]NodeRegistryRelayer)).(*[]NodeRegistryRelayer)
+
+	return out0, err
+
+}
+
+// GetRelayers is a free data retrieval call binding the contract method 0x179ff4b2.
+//
+// Solidity: function getRelayers() view returns((string,string,uint32,bytes,address)[] result)
+func (_NodeRegistry *NodeRegistrySession) GetRelayers() ([]NodeRegistryRelayer, error) {
+	return _NodeRegistry.Contract.GetRelayers(&_NodeRegistry.CallOpts)
+}
+
+// GetRelayers is a free data retrieval call binding the contract method 0x179ff4b2.
+//
+// Solidity: function getRelayers() view returns((string,string,uint32,bytes,address)[] result)
+func (_NodeRegistry *NodeRegistryCallerSession) GetRelayers() ([]NodeRegistryRelayer, error) {
+	return _NodeRegistry.Contract.GetRelayers(&_NodeRegistry.CallOpts)
+}
+
 // GetResolver is a free data retrieval call binding the contract method 0xeea330f9.
 //
 // Solidity: function getResolver(bytes publicKey) view returns(string ip)
@@ -278,44 +366,2981 @@ func (_NodeRegistry *NodeRegistryCallerSession) GetResolver(publicKey []byte) (s
 	return _NodeRegistry.Contract.GetResolver(&_NodeRegistry.CallOpts, publicKey)
 }
 
-// RegisterRelayer is a paid mutator transaction binding the contract method 0x6f5a4e38.
+// GetResolverCapabilities is a free data retrieval call binding the contract method 0x4497dc99.
+//
+// Solidity: function getResolverCapabilities(bytes publicKey) view returns((string[],uint256[],uint32,bool) resolverCapabilities)
+func (_NodeRegistry *NodeRegistryCaller) GetResolverCapabilities(opts *bind.CallOpts, publicKey []byte) (NodeRegistryCapabilities, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "getResolverCapabilities", publicKey)
+
+	if err != nil {
+		return *new(NodeRegistryCapabilities), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(NodeRegistryCapabilities)).(*NodeRegistryCapabilities)
+
+	return out0, err
+
+}
+
+// GetResolverCapabilities is a free data retrieval call binding the contract method 0x4497dc99.
+//
+// Solidity: function getResolverCapabilities(bytes publicKey) view returns((string[],uint256[],uint32,bool) resolverCapabilities)
+func (_NodeRegistry *NodeRegistrySession) GetResolverCapabilities(publicKey []byte) (NodeRegistryCapabilities, error) {
+	return _NodeRegistry.Contract.GetResolverCapabilities(&_NodeRegistry.CallOpts, publicKey)
+}
+
+// GetResolverCapabilities is a free data retrieval call binding the contract method 0x4497dc99.
+//
+// Solidity: function getResolverCapabilities(bytes publicKey) view returns((string[],uint256[],uint32,bool) resolverCapabilities)
+func (_NodeRegistry *NodeRegistryCallerSession) GetResolverCapabilities(publicKey []byte) (NodeRegistryCapabilities, error) {
+	return _NodeRegistry.Contract.GetResolverCapabilities(&_NodeRegistry.CallOpts, publicKey)
+}
+
+// GetResolverCount is a free data retrieval call binding the contract method 0x7c889435.
+//
+// Solidity: function getResolverCount() view returns(uint256 count)
+func (_NodeRegistry *NodeRegistryCaller) GetResolverCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "getResolverCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetResolverCount is a free data retrieval call binding the contract method 0x7c889435.
+//
+// Solidity: function getResolverCount() view returns(uint256 count)
+func (_NodeRegistry *NodeRegistrySession) GetResolverCount() (*big.Int, error) {
+	return _NodeRegistry.Contract.GetResolverCount(&_NodeRegistry.CallOpts)
+}
+
+// GetResolverCount is a free data retrieval call binding the contract method 0x7c889435.
+//
+// Solidity: function getResolverCount() view returns(uint256 count)
+func (_NodeRegistry *NodeRegistryCallerSession) GetResolverCount() (*big.Int, error) {
+	return _NodeRegistry.Contract.GetResolverCount(&_NodeRegistry.CallOpts)
+}
+
+// GetResolverOwner is a free data retrieval call binding the contract method 0xb73eb2d5.
+//
+// Solidity: function getResolverOwner(bytes publicKey) view returns(address resolverOwner)
+func (_NodeRegistry *NodeRegistryCaller) GetResolverOwner(opts *bind.CallOpts, publicKey []byte) (common.Address, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "getResolverOwner", publicKey)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetResolverOwner is a free data retrieval call binding the contract method 0xb73eb2d5.
+//
+// Solidity: function getResolverOwner(bytes publicKey) view returns(address resolverOwner)
+func (_NodeRegistry *NodeRegistrySession) GetResolverOwner(publicKey []byte) (common.Address, error) {
+	return _NodeRegistry.Contract.GetResolverOwner(&_NodeRegistry.CallOpts, publicKey)
+}
+
+// GetResolverOwner is a free data retrieval call binding the contract method 0xb73eb2d5.
+//
+// Solidity: function getResolverOwner(bytes publicKey) view returns(address resolverOwner)
+func (_NodeRegistry *NodeRegistryCallerSession) GetResolverOwner(publicKey []byte) (common.Address, error) {
+	return _NodeRegistry.Contract.GetResolverOwner(&_NodeRegistry.CallOpts, publicKey)
+}
+
+// GetResolverStake is a free data retrieval call binding the contract method 0x448a4dc3.
+//
+// Solidity: function getResolverStake(bytes publicKey) view returns(uint256 stake, uint256 unbonding, uint256 availableAt)
+func (_NodeRegistry *NodeRegistryCaller) GetResolverStake(opts *bind.CallOpts, publicKey []byte) (struct {
+	Stake       *big.Int
+	Unbonding   *big.Int
+	AvailableAt *big.Int
+}, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "getResolverStake", publicKey)
+
+	outstruct := new(struct {
+		Stake       *big.Int
+		Unbonding   *big.Int
+		AvailableAt *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Stake = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Unbonding = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.AvailableAt = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetResolverStake is a free data retrieval call binding the contract method 0x448a4dc3.
+//
+// Solidity: function getResolverStake(bytes publicKey) view returns(uint256 stake, uint256 unbonding, uint256 availableAt)
+func (_NodeRegistry *NodeRegistrySession) GetResolverStake(publicKey []byte) (struct {
+	Stake       *big.Int
+	Unbonding   *big.Int
+	AvailableAt *big.Int
+}, error) {
+	return _NodeRegistry.Contract.GetResolverStake(&_NodeRegistry.CallOpts, publicKey)
+}
+
+// GetResolverStake is a free data retrieval call binding the contract method 0x448a4dc3.
+//
+// Solidity: function getResolverStake(bytes publicKey) view returns(uint256 stake, uint256 unbonding, uint256 availableAt)
+func (_NodeRegistry *NodeRegistryCallerSession) GetResolverStake(publicKey []byte) (struct {
+	Stake       *big.Int
+	Unbonding   *big.Int
+	AvailableAt *big.Int
+}, error) {
+	return _NodeRegistry.Contract.GetResolverStake(&_NodeRegistry.CallOpts, publicKey)
+}
+
+// GetResolvers is a free data retrieval call binding the contract method 0x27f3dba2.
+//
+// Solidity: function getResolvers(uint256 offset, uint256 limit) view returns((bytes,string,address,uint256,(string[],uint256[],uint32,bool))[] result)
+func (_NodeRegistry *NodeRegistryCaller) GetResolvers(opts *bind.CallOpts, offset *big.Int, limit *big.Int) ([]NodeRegistryResolverInfo, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "getResolvers", offset, limit)
+
+	if err != nil {
+		return *new([]NodeRegistryResolverInfo), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]NodeRegistryResolverInfo)).(*[]NodeRegistryResolverInfo)
+
+	return out0, err
+
+}
+
+// GetResolvers is a free data retrieval call binding the contract method 0x27f3dba2.
+//
+// Solidity: function getResolvers(uint256 offset, uint256 limit) view returns((bytes,string,address,uint256,(string[],uint256[],uint32,bool))[] result)
+func (_NodeRegistry *NodeRegistrySession) GetResolvers(offset *big.Int, limit *big.Int) ([]NodeRegistryResolverInfo, error) {
+	return _NodeRegistry.Contract.GetResolvers(&_NodeRegistry.CallOpts, offset, limit)
+}
+
+// GetResolvers is a free data retrieval call binding the contract method 0x27f3dba2.
 //
-// Solidity: function registerRelayer(string ip) returns()
-func (_NodeRegistry *NodeRegistryTransactor) RegisterRelayer(opts *bind.TransactOpts, ip string) (*types.Transaction, error) {
-	return _NodeRegistry.contract.Transact(opts, "registerRelayer", ip)
+// Solidity: function getResolvers(uint256 offset, uint256 limit) view returns((bytes,string,address,uint256,(string[],uint256[],uint32,bool))[] result)
+func (_NodeRegistry *NodeRegistryCallerSession) GetResolvers(offset *big.Int, limit *big.Int) ([]NodeRegistryResolverInfo, error) {
+	return _NodeRegistry.Contract.GetResolvers(&_NodeRegistry.CallOpts, offset, limit)
 }
 
-// RegisterRelayer is a paid mutator transaction binding the contract method 0x6f5a4e38.
+// KeyRotationGracePeriod is a free data retrieval call binding the contract method 0xed70ee19.
 //
-// Solidity: function registerRelayer(string ip) returns()
-func (_NodeRegistry *NodeRegistrySession) RegisterRelayer(ip string) (*types.Transaction, error) {
-	return _NodeRegistry.Contract.RegisterRelayer(&_NodeRegistry.TransactOpts, ip)
+// Solidity: function keyRotationGracePeriod() view returns(uint256)
+func (_NodeRegistry *NodeRegistryCaller) KeyRotationGracePeriod(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "keyRotationGracePeriod")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// KeyRotationGracePeriod is a free data retrieval call binding the contract method 0xed70ee19.
+//
+// Solidity: function keyRotationGracePeriod() view returns(uint256)
+func (_NodeRegistry *NodeRegistrySession) KeyRotationGracePeriod() (*big.Int, error) {
+	return _NodeRegistry.Contract.KeyRotationGracePeriod(&_NodeRegistry.CallOpts)
+}
+
+// KeyRotationGracePeriod is a free data retrieval call binding the contract method 0xed70ee19.
+//
+// Solidity: function keyRotationGracePeriod() view returns(uint256)
+func (_NodeRegistry *NodeRegistryCallerSession) KeyRotationGracePeriod() (*big.Int, error) {
+	return _NodeRegistry.Contract.KeyRotationGracePeriod(&_NodeRegistry.CallOpts)
+}
+
+// MinResolverStake is a free data retrieval call binding the contract method 0x57691f2e.
+//
+// Solidity: function minResolverStake() view returns(uint256)
+func (_NodeRegistry *NodeRegistryCaller) MinResolverStake(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "minResolverStake")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinResolverStake is a free data retrieval call binding the contract method 0x57691f2e.
+//
+// Solidity: function minResolverStake() view returns(uint256)
+func (_NodeRegistry *NodeRegistrySession) MinResolverStake() (*big.Int, error) {
+	return _NodeRegistry.Contract.MinResolverStake(&_NodeRegistry.CallOpts)
+}
+
+// MinResolverStake is a free data retrieval call binding the contract method 0x57691f2e.
+//
+// Solidity: function minResolverStake() view returns(uint256)
+func (_NodeRegistry *NodeRegistryCallerSession) MinResolverStake() (*big.Int, error) {
+	return _NodeRegistry.Contract.MinResolverStake(&_NodeRegistry.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_NodeRegistry *NodeRegistryCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_NodeRegistry *NodeRegistrySession) Owner() (common.Address, error) {
+	return _NodeRegistry.Contract.Owner(&_NodeRegistry.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_NodeRegistry *NodeRegistryCallerSession) Owner() (common.Address, error) {
+	return _NodeRegistry.Contract.Owner(&_NodeRegistry.CallOpts)
+}
+
+// PrimaryRelayer is a free data retrieval call binding the contract method 0x5c6c6319.
+//
+// Solidity: function primaryRelayer() view returns(address)
+func (_NodeRegistry *NodeRegistryCaller) PrimaryRelayer(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "primaryRelayer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PrimaryRelayer is a free data retrieval call binding the contract method 0x5c6c6319.
+//
+// Solidity: function primaryRelayer() view returns(address)
+func (_NodeRegistry *NodeRegistrySession) PrimaryRelayer() (common.Address, error) {
+	return _NodeRegistry.Contract.PrimaryRelayer(&_NodeRegistry.CallOpts)
+}
+
+// PrimaryRelayer is a free data retrieval call binding the contract method 0x5c6c6319.
+//
+// Solidity: function primaryRelayer() view returns(address)
+func (_NodeRegistry *NodeRegistryCallerSession) PrimaryRelayer() (common.Address, error) {
+	return _NodeRegistry.Contract.PrimaryRelayer(&_NodeRegistry.CallOpts)
+}
+
+// Slashers is a free data retrieval call binding the contract method 0xb87fcbff.
+//
+// Solidity: function slashers(address ) view returns(bool)
+func (_NodeRegistry *NodeRegistryCaller) Slashers(opts *bind.CallOpts, arg0 common.Address) (bool, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "slashers", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Slashers is a free data retrieval call binding the contract method 0xb87fcbff.
+//
+// Solidity: function slashers(address ) view returns(bool)
+func (_NodeRegistry *NodeRegistrySession) Slashers(arg0 common.Address) (bool, error) {
+	return _NodeRegistry.Contract.Slashers(&_NodeRegistry.CallOpts, arg0)
+}
+
+// Slashers is a free data retrieval call binding the contract method 0xb87fcbff.
+//
+// Solidity: function slashers(address ) view returns(bool)
+func (_NodeRegistry *NodeRegistryCallerSession) Slashers(arg0 common.Address) (bool, error) {
+	return _NodeRegistry.Contract.Slashers(&_NodeRegistry.CallOpts, arg0)
+}
+
+// UnbondingPeriod is a free data retrieval call binding the contract method 0x6cf6d675.
+//
+// Solidity: function unbondingPeriod() view returns(uint256)
+func (_NodeRegistry *NodeRegistryCaller) UnbondingPeriod(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _NodeRegistry.contract.Call(opts, &out, "unbondingPeriod")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UnbondingPeriod is a free data retrieval call binding the contract method 0x6cf6d675.
+//
+// Solidity: function unbondingPeriod() view returns(uint256)
+func (_NodeRegistry *NodeRegistrySession) UnbondingPeriod() (*big.Int, error) {
+	return _NodeRegistry.Contract.UnbondingPeriod(&_NodeRegistry.CallOpts)
 }
 
-// RegisterRelayer is a paid mutator transaction binding the contract method 0x6f5a4e38.
+// UnbondingPeriod is a free data retrieval call binding the contract method 0x6cf6d675.
 //
-// Solidity: function registerRelayer(string ip) returns()
-func (_NodeRegistry *NodeRegistryTransactorSession) RegisterRelayer(ip string) (*types.Transaction, error) {
-	return _NodeRegistry.Contract.RegisterRelayer(&_NodeRegistry.TransactOpts, ip)
+// Solidity: function unbondingPeriod() view returns(uint256)
+func (_NodeRegistry *NodeRegistryCallerSession) UnbondingPeriod() (*big.Int, error) {
+	return _NodeRegistry.Contract.UnbondingPeriod(&_NodeRegistry.CallOpts)
 }
 
-// RegisterResolver is a paid mutator transaction binding the contract method 0xe5ee3998.
+// DeregisterRelayer is a paid mutator transaction binding the contract method 0xe37347e6.
 //
-// Solidity: function registerResolver(string ip, bytes publicKey) returns()
-func (_NodeRegistry *NodeRegistryTransactor) RegisterResolver(opts *bind.TransactOpts, ip string, publicKey []byte) (*types.Transaction, error) {
-	return _NodeRegistry.contract.Transact(opts, "registerResolver", ip, publicKey)
+// Solidity: function deregisterRelayer(address relayerOwner) returns()
+func (_NodeRegistry *NodeRegistryTransactor) DeregisterRelayer(opts *bind.TransactOpts, relayerOwner common.Address) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "deregisterRelayer", relayerOwner)
 }
 
-// RegisterResolver is a paid mutator transaction binding the contract method 0xe5ee3998.
+// DeregisterRelayer is a paid mutator transaction binding the contract method 0xe37347e6.
 //
-// Solidity: function registerResolver(string ip, bytes publicKey) returns()
-func (_NodeRegistry *NodeRegistrySession) RegisterResolver(ip string, publicKey []byte) (*types.Transaction, error) {
-	return _NodeRegistry.Contract.RegisterResolver(&_NodeRegistry.TransactOpts, ip, publicKey)
+// Solidity: function deregisterRelayer(address relayerOwner) returns()
+func (_NodeRegistry *NodeRegistrySession) DeregisterRelayer(relayerOwner common.Address) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.DeregisterRelayer(&_NodeRegistry.TransactOpts, relayerOwner)
 }
 
-// RegisterResolver is a paid mutator transaction binding the contract method 0xe5ee3998.
+// DeregisterRelayer is a paid mutator transaction binding the contract method 0xe37347e6.
 //
-// Solidity: function registerResolver(string ip, bytes publicKey) returns()
-func (_NodeRegistry *NodeRegistryTransactorSession) RegisterResolver(ip string, publicKey []byte) (*types.Transaction, error) {
-	return _NodeRegistry.Contract.RegisterResolver(&_NodeRegistry.TransactOpts, ip, publicKey)
+// Solidity: function deregisterRelayer(address relayerOwner) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) DeregisterRelayer(relayerOwner common.Address) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.DeregisterRelayer(&_NodeRegistry.TransactOpts, relayerOwner)
+}
+
+// DeregisterResolver is a paid mutator transaction binding the contract method 0x37d4bb56.
+//
+// Solidity: function deregisterResolver(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactor) DeregisterResolver(opts *bind.TransactOpts, publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "deregisterResolver", publicKey)
+}
+
+// DeregisterResolver is a paid mutator transaction binding the contract method 0x37d4bb56.
+//
+// Solidity: function deregisterResolver(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistrySession) DeregisterResolver(publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.DeregisterResolver(&_NodeRegistry.TransactOpts, publicKey)
+}
+
+// DeregisterResolver is a paid mutator transaction binding the contract method 0x37d4bb56.
+//
+// Solidity: function deregisterResolver(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) DeregisterResolver(publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.DeregisterResolver(&_NodeRegistry.TransactOpts, publicKey)
+}
+
+// PruneResolverKey is a paid mutator transaction binding the contract method 0xc1659e58.
+//
+// Solidity: function pruneResolverKey(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactor) PruneResolverKey(opts *bind.TransactOpts, publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "pruneResolverKey", publicKey)
+}
+
+// PruneResolverKey is a paid mutator transaction binding the contract method 0xc1659e58.
+//
+// Solidity: function pruneResolverKey(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistrySession) PruneResolverKey(publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.PruneResolverKey(&_NodeRegistry.TransactOpts, publicKey)
+}
+
+// PruneResolverKey is a paid mutator transaction binding the contract method 0xc1659e58.
+//
+// Solidity: function pruneResolverKey(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) PruneResolverKey(publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.PruneResolverKey(&_NodeRegistry.TransactOpts, publicKey)
+}
+
+// RegisterRelayer is a paid mutator transaction binding the contract method 0x5ffd6851.
+//
+// Solidity: function registerRelayer(string ip, string region, uint32 capacity, bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactor) RegisterRelayer(opts *bind.TransactOpts, ip string, region string, capacity uint32, publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "registerRelayer", ip, region, capacity, publicKey)
+}
+
+// RegisterRelayer is a paid mutator transaction binding the contract method 0x5ffd6851.
+//
+// Solidity: function registerRelayer(string ip, string region, uint32 capacity, bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistrySession) RegisterRelayer(ip string, region string, capacity uint32, publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.RegisterRelayer(&_NodeRegistry.TransactOpts, ip, region, capacity, publicKey)
+}
+
+// RegisterRelayer is a paid mutator transaction binding the contract method 0x5ffd6851.
+//
+// Solidity: function registerRelayer(string ip, string region, uint32 capacity, bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) RegisterRelayer(ip string, region string, capacity uint32, publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.RegisterRelayer(&_NodeRegistry.TransactOpts, ip, region, capacity, publicKey)
+}
+
+// RegisterResolver is a paid mutator transaction binding the contract method 0x98b871f8.
+//
+// Solidity: function registerResolver(string ip, bytes publicKey, (string[],uint256[],uint32,bool) resolverCapabilities) payable returns()
+func (_NodeRegistry *NodeRegistryTransactor) RegisterResolver(opts *bind.TransactOpts, ip string, publicKey []byte, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "registerResolver", ip, publicKey, resolverCapabilities)
+}
+
+// RegisterResolver is a paid mutator transaction binding the contract method 0x98b871f8.
+//
+// Solidity: function registerResolver(string ip, bytes publicKey, (string[],uint256[],uint32,bool) resolverCapabilities) payable returns()
+func (_NodeRegistry *NodeRegistrySession) RegisterResolver(ip string, publicKey []byte, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.RegisterResolver(&_NodeRegistry.TransactOpts, ip, publicKey, resolverCapabilities)
+}
+
+// RegisterResolver is a paid mutator transaction binding the contract method 0x98b871f8.
+//
+// Solidity: function registerResolver(string ip, bytes publicKey, (string[],uint256[],uint32,bool) resolverCapabilities) payable returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) RegisterResolver(ip string, publicKey []byte, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.RegisterResolver(&_NodeRegistry.TransactOpts, ip, publicKey, resolverCapabilities)
+}
+
+// RotateResolverKey is a paid mutator transaction binding the contract method 0x874fa473.
+//
+// Solidity: function rotateResolverKey(bytes oldPublicKey, bytes newPublicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactor) RotateResolverKey(opts *bind.TransactOpts, oldPublicKey []byte, newPublicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "rotateResolverKey", oldPublicKey, newPublicKey)
+}
+
+// RotateResolverKey is a paid mutator transaction binding the contract method 0x874fa473.
+//
+// Solidity: function rotateResolverKey(bytes oldPublicKey, bytes newPublicKey) returns()
+func (_NodeRegistry *NodeRegistrySession) RotateResolverKey(oldPublicKey []byte, newPublicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.RotateResolverKey(&_NodeRegistry.TransactOpts, oldPublicKey, newPublicKey)
+}
+
+// RotateResolverKey is a paid mutator transaction binding the contract method 0x874fa473.
+//
+// Solidity: function rotateResolverKey(bytes oldPublicKey, bytes newPublicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) RotateResolverKey(oldPublicKey []byte, newPublicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.RotateResolverKey(&_NodeRegistry.TransactOpts, oldPublicKey, newPublicKey)
+}
+
+// SetKeyRotationGracePeriod is a paid mutator transaction binding the contract method 0x2fdc6e64.
+//
+// Solidity: function setKeyRotationGracePeriod(uint256 period) returns()
+func (_NodeRegistry *NodeRegistryTransactor) SetKeyRotationGracePeriod(opts *bind.TransactOpts, period *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "setKeyRotationGracePeriod", period)
+}
+
+// SetKeyRotationGracePeriod is a paid mutator transaction binding the contract method 0x2fdc6e64.
+//
+// Solidity: function setKeyRotationGracePeriod(uint256 period) returns()
+func (_NodeRegistry *NodeRegistrySession) SetKeyRotationGracePeriod(period *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetKeyRotationGracePeriod(&_NodeRegistry.TransactOpts, period)
+}
+
+// SetKeyRotationGracePeriod is a paid mutator transaction binding the contract method 0x2fdc6e64.
+//
+// Solidity: function setKeyRotationGracePeriod(uint256 period) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) SetKeyRotationGracePeriod(period *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetKeyRotationGracePeriod(&_NodeRegistry.TransactOpts, period)
+}
+
+// SetPrimaryRelayer is a paid mutator transaction binding the contract method 0xd4478b0e.
+//
+// Solidity: function setPrimaryRelayer(address relayerOwner) returns()
+func (_NodeRegistry *NodeRegistryTransactor) SetPrimaryRelayer(opts *bind.TransactOpts, relayerOwner common.Address) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "setPrimaryRelayer", relayerOwner)
+}
+
+// SetPrimaryRelayer is a paid mutator transaction binding the contract method 0xd4478b0e.
+//
+// Solidity: function setPrimaryRelayer(address relayerOwner) returns()
+func (_NodeRegistry *NodeRegistrySession) SetPrimaryRelayer(relayerOwner common.Address) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetPrimaryRelayer(&_NodeRegistry.TransactOpts, relayerOwner)
+}
+
+// SetPrimaryRelayer is a paid mutator transaction binding the contract method 0xd4478b0e.
+//
+// Solidity: function setPrimaryRelayer(address relayerOwner) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) SetPrimaryRelayer(relayerOwner common.Address) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetPrimaryRelayer(&_NodeRegistry.TransactOpts, relayerOwner)
+}
+
+// SetRelayerApproval is a paid mutator transaction binding the contract method 0xf62ac70f.
+//
+// Solidity: function setRelayerApproval(address relayerOwner, bool approved) returns()
+func (_NodeRegistry *NodeRegistryTransactor) SetRelayerApproval(opts *bind.TransactOpts, relayerOwner common.Address, approved bool) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "setRelayerApproval", relayerOwner, approved)
+}
+
+// SetRelayerApproval is a paid mutator transaction binding the contract method 0xf62ac70f.
+//
+// Solidity: function setRelayerApproval(address relayerOwner, bool approved) returns()
+func (_NodeRegistry *NodeRegistrySession) SetRelayerApproval(relayerOwner common.Address, approved bool) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetRelayerApproval(&_NodeRegistry.TransactOpts, relayerOwner, approved)
+}
+
+// SetRelayerApproval is a paid mutator transaction binding the contract method 0xf62ac70f.
+//
+// Solidity: function setRelayerApproval(address relayerOwner, bool approved) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) SetRelayerApproval(relayerOwner common.Address, approved bool) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetRelayerApproval(&_NodeRegistry.TransactOpts, relayerOwner, approved)
+}
+
+// SetResolverCapabilities is a paid mutator transaction binding the contract method 0x195b0925.
+//
+// Solidity: function setResolverCapabilities(bytes publicKey, (string[],uint256[],uint32,bool) resolverCapabilities) returns()
+func (_NodeRegistry *NodeRegistryTransactor) SetResolverCapabilities(opts *bind.TransactOpts, publicKey []byte, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "setResolverCapabilities", publicKey, resolverCapabilities)
+}
+
+// SetResolverCapabilities is a paid mutator transaction binding the contract method 0x195b0925.
+//
+// Solidity: function setResolverCapabilities(bytes publicKey, (string[],uint256[],uint32,bool) resolverCapabilities) returns()
+func (_NodeRegistry *NodeRegistrySession) SetResolverCapabilities(publicKey []byte, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetResolverCapabilities(&_NodeRegistry.TransactOpts, publicKey, resolverCapabilities)
+}
+
+// SetResolverCapabilities is a paid mutator transaction binding the contract method 0x195b0925.
+//
+// Solidity: function setResolverCapabilities(bytes publicKey, (string[],uint256[],uint32,bool) resolverCapabilities) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) SetResolverCapabilities(publicKey []byte, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetResolverCapabilities(&_NodeRegistry.TransactOpts, publicKey, resolverCapabilities)
+}
+
+// SetSlasher is a paid mutator transaction binding the contract method 0x52348080.
+//
+// Solidity: function setSlasher(address slasher, bool allowed) returns()
+func (_NodeRegistry *NodeRegistryTransactor) SetSlasher(opts *bind.TransactOpts, slasher common.Address, allowed bool) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "setSlasher", slasher, allowed)
+}
+
+// SetSlasher is a paid mutator transaction binding the contract method 0x52348080.
+//
+// Solidity: function setSlasher(address slasher, bool allowed) returns()
+func (_NodeRegistry *NodeRegistrySession) SetSlasher(slasher common.Address, allowed bool) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetSlasher(&_NodeRegistry.TransactOpts, slasher, allowed)
+}
+
+// SetSlasher is a paid mutator transaction binding the contract method 0x52348080.
+//
+// Solidity: function setSlasher(address slasher, bool allowed) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) SetSlasher(slasher common.Address, allowed bool) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetSlasher(&_NodeRegistry.TransactOpts, slasher, allowed)
+}
+
+// SetStakeConfig is a paid mutator transaction binding the contract method 0x597df275.
+//
+// Solidity: function setStakeConfig(uint256 minStake, uint256 period) returns()
+func (_NodeRegistry *NodeRegistryTransactor) SetStakeConfig(opts *bind.TransactOpts, minStake *big.Int, period *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "setStakeConfig", minStake, period)
+}
+
+// SetStakeConfig is a paid mutator transaction binding the contract method 0x597df275.
+//
+// Solidity: function setStakeConfig(uint256 minStake, uint256 period) returns()
+func (_NodeRegistry *NodeRegistrySession) SetStakeConfig(minStake *big.Int, period *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetStakeConfig(&_NodeRegistry.TransactOpts, minStake, period)
+}
+
+// SetStakeConfig is a paid mutator transaction binding the contract method 0x597df275.
+//
+// Solidity: function setStakeConfig(uint256 minStake, uint256 period) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) SetStakeConfig(minStake *big.Int, period *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.SetStakeConfig(&_NodeRegistry.TransactOpts, minStake, period)
+}
+
+// Slash is a paid mutator transaction binding the contract method 0x1505d595.
+//
+// Solidity: function slash(bytes publicKey, uint256 amount) returns()
+func (_NodeRegistry *NodeRegistryTransactor) Slash(opts *bind.TransactOpts, publicKey []byte, amount *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "slash", publicKey, amount)
+}
+
+// Slash is a paid mutator transaction binding the contract method 0x1505d595.
+//
+// Solidity: function slash(bytes publicKey, uint256 amount) returns()
+func (_NodeRegistry *NodeRegistrySession) Slash(publicKey []byte, amount *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.Slash(&_NodeRegistry.TransactOpts, publicKey, amount)
+}
+
+// Slash is a paid mutator transaction binding the contract method 0x1505d595.
+//
+// Solidity: function slash(bytes publicKey, uint256 amount) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) Slash(publicKey []byte, amount *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.Slash(&_NodeRegistry.TransactOpts, publicKey, amount)
+}
+
+// StakeResolver is a paid mutator transaction binding the contract method 0x1cfc0144.
+//
+// Solidity: function stakeResolver(bytes publicKey) payable returns()
+func (_NodeRegistry *NodeRegistryTransactor) StakeResolver(opts *bind.TransactOpts, publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "stakeResolver", publicKey)
+}
+
+// StakeResolver is a paid mutator transaction binding the contract method 0x1cfc0144.
+//
+// Solidity: function stakeResolver(bytes publicKey) payable returns()
+func (_NodeRegistry *NodeRegistrySession) StakeResolver(publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.StakeResolver(&_NodeRegistry.TransactOpts, publicKey)
+}
+
+// StakeResolver is a paid mutator transaction binding the contract method 0x1cfc0144.
+//
+// Solidity: function stakeResolver(bytes publicKey) payable returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) StakeResolver(publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.StakeResolver(&_NodeRegistry.TransactOpts, publicKey)
+}
+
+// UnstakeResolver is a paid mutator transaction binding the contract method 0x83b66dd2.
+//
+// Solidity: function unstakeResolver(bytes publicKey, uint256 amount) returns()
+func (_NodeRegistry *NodeRegistryTransactor) UnstakeResolver(opts *bind.TransactOpts, publicKey []byte, amount *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "unstakeResolver", publicKey, amount)
+}
+
+// UnstakeResolver is a paid mutator transaction binding the contract method 0x83b66dd2.
+//
+// Solidity: function unstakeResolver(bytes publicKey, uint256 amount) returns()
+func (_NodeRegistry *NodeRegistrySession) UnstakeResolver(publicKey []byte, amount *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.UnstakeResolver(&_NodeRegistry.TransactOpts, publicKey, amount)
+}
+
+// UnstakeResolver is a paid mutator transaction binding the contract method 0x83b66dd2.
+//
+// Solidity: function unstakeResolver(bytes publicKey, uint256 amount) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) UnstakeResolver(publicKey []byte, amount *big.Int) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.UnstakeResolver(&_NodeRegistry.TransactOpts, publicKey, amount)
+}
+
+// UpdateResolver is a paid mutator transaction binding the contract method 0x9fcfef26.
+//
+// Solidity: function updateResolver(bytes publicKey, string ip) returns()
+func (_NodeRegistry *NodeRegistryTransactor) UpdateResolver(opts *bind.TransactOpts, publicKey []byte, ip string) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "updateResolver", publicKey, ip)
+}
+
+// UpdateResolver is a paid mutator transaction binding the contract method 0x9fcfef26.
+//
+// Solidity: function updateResolver(bytes publicKey, string ip) returns()
+func (_NodeRegistry *NodeRegistrySession) UpdateResolver(publicKey []byte, ip string) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.UpdateResolver(&_NodeRegistry.TransactOpts, publicKey, ip)
+}
+
+// UpdateResolver is a paid mutator transaction binding the contract method 0x9fcfef26.
+//
+// Solidity: function updateResolver(bytes publicKey, string ip) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) UpdateResolver(publicKey []byte, ip string) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.UpdateResolver(&_NodeRegistry.TransactOpts, publicKey, ip)
+}
+
+// UpdateResolverWithCapabilities is a paid mutator transaction binding the contract method 0xa6d51185.
+//
+// Solidity: function updateResolverWithCapabilities(bytes publicKey, string ip, (string[],uint256[],uint32,bool) resolverCapabilities) returns()
+func (_NodeRegistry *NodeRegistryTransactor) UpdateResolverWithCapabilities(opts *bind.TransactOpts, publicKey []byte, ip string, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "updateResolverWithCapabilities", publicKey, ip, resolverCapabilities)
+}
+
+// UpdateResolverWithCapabilities is a paid mutator transaction binding the contract method 0xa6d51185.
+//
+// Solidity: function updateResolverWithCapabilities(bytes publicKey, string ip, (string[],uint256[],uint32,bool) resolverCapabilities) returns()
+func (_NodeRegistry *NodeRegistrySession) UpdateResolverWithCapabilities(publicKey []byte, ip string, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.UpdateResolverWithCapabilities(&_NodeRegistry.TransactOpts, publicKey, ip, resolverCapabilities)
+}
+
+// UpdateResolverWithCapabilities is a paid mutator transaction binding the contract method 0xa6d51185.
+//
+// Solidity: function updateResolverWithCapabilities(bytes publicKey, string ip, (string[],uint256[],uint32,bool) resolverCapabilities) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) UpdateResolverWithCapabilities(publicKey []byte, ip string, resolverCapabilities NodeRegistryCapabilities) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.UpdateResolverWithCapabilities(&_NodeRegistry.TransactOpts, publicKey, ip, resolverCapabilities)
+}
+
+// WithdrawResolverStake is a paid mutator transaction binding the contract method 0x3dbb1b8f.
+//
+// Solidity: function withdrawResolverStake(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactor) WithdrawResolverStake(opts *bind.TransactOpts, publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.contract.Transact(opts, "withdrawResolverStake", publicKey)
+}
+
+// WithdrawResolverStake is a paid mutator transaction binding the contract method 0x3dbb1b8f.
+//
+// Solidity: function withdrawResolverStake(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistrySession) WithdrawResolverStake(publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.WithdrawResolverStake(&_NodeRegistry.TransactOpts, publicKey)
+}
+
+// WithdrawResolverStake is a paid mutator transaction binding the contract method 0x3dbb1b8f.
+//
+// Solidity: function withdrawResolverStake(bytes publicKey) returns()
+func (_NodeRegistry *NodeRegistryTransactorSession) WithdrawResolverStake(publicKey []byte) (*types.Transaction, error) {
+	return _NodeRegistry.Contract.WithdrawResolverStake(&_NodeRegistry.TransactOpts, publicKey)
+}
+
+// NodeRegistryKeyRotationGracePeriodUpdatedIterator is returned from FilterKeyRotationGracePeriodUpdated and is used to iterate over the raw logs and unpacked data for KeyRotationGracePeriodUpdated events raised by the NodeRegistry contract.
+type NodeRegistryKeyRotationGracePeriodUpdatedIterator struct {
+	Event *NodeRegistryKeyRotationGracePeriodUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryKeyRotationGracePeriodUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryKeyRotationGracePeriodUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryKeyRotationGracePeriodUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryKeyRotationGracePeriodUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryKeyRotationGracePeriodUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryKeyRotationGracePeriodUpdated represents a KeyRotationGracePeriodUpdated event raised by the NodeRegistry contract.
+type NodeRegistryKeyRotationGracePeriodUpdated struct {
+	Period *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterKeyRotationGracePeriodUpdated is a free log retrieval operation binding the contract event 0x4d5891cea464eec920af2cf0a1166abc1e0eb8f53d63ae82a540aa6bf32d5397.
+//
+// Solidity: event KeyRotationGracePeriodUpdated(uint256 period)
+func (_NodeRegistry *NodeRegistryFilterer) FilterKeyRotationGracePeriodUpdated(opts *bind.FilterOpts) (*NodeRegistryKeyRotationGracePeriodUpdatedIterator, error) {
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "KeyRotationGracePeriodUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryKeyRotationGracePeriodUpdatedIterator{contract: _NodeRegistry.contract, event: "KeyRotationGracePeriodUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchKeyRotationGracePeriodUpdated is a free log subscription operation binding the contract event 0x4d5891cea464eec920af2cf0a1166abc1e0eb8f53d63ae82a540aa6bf32d5397.
+//
+// Solidity: event KeyRotationGracePeriodUpdated(uint256 period)
+func (_NodeRegistry *NodeRegistryFilterer) WatchKeyRotationGracePeriodUpdated(opts *bind.WatchOpts, sink chan<- *NodeRegistryKeyRotationGracePeriodUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "KeyRotationGracePeriodUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryKeyRotationGracePeriodUpdated)
+				if err := _NodeRegistry.contract.UnpackLog(event, "KeyRotationGracePeriodUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseKeyRotationGracePeriodUpdated is a log parse operation binding the contract event 0x4d5891cea464eec920af2cf0a1166abc1e0eb8f53d63ae82a540aa6bf32d5397.
+//
+// Solidity: event KeyRotationGracePeriodUpdated(uint256 period)
+func (_NodeRegistry *NodeRegistryFilterer) ParseKeyRotationGracePeriodUpdated(log types.Log) (*NodeRegistryKeyRotationGracePeriodUpdated, error) {
+	event := new(NodeRegistryKeyRotationGracePeriodUpdated)
+	if err := _NodeRegistry.contract.UnpackLog(event, "KeyRotationGracePeriodUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryPrimaryRelayerUpdatedIterator is returned from FilterPrimaryRelayerUpdated and is used to iterate over the raw logs and unpacked data for PrimaryRelayerUpdated events raised by the NodeRegistry contract.
+type NodeRegistryPrimaryRelayerUpdatedIterator struct {
+	Event *NodeRegistryPrimaryRelayerUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryPrimaryRelayerUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryPrimaryRelayerUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryPrimaryRelayerUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryPrimaryRelayerUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryPrimaryRelayerUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryPrimaryRelayerUpdated represents a PrimaryRelayerUpdated event raised by the NodeRegistry contract.
+type NodeRegistryPrimaryRelayerUpdated struct {
+	RelayerOwner common.Address
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterPrimaryRelayerUpdated is a free log retrieval operation binding the contract event 0x35481457cd222ebd02e4f2750d2505c2eac004e0cf60aeebcaa793c6dfbaceb3.
+//
+// Solidity: event PrimaryRelayerUpdated(address indexed relayerOwner)
+func (_NodeRegistry *NodeRegistryFilterer) FilterPrimaryRelayerUpdated(opts *bind.FilterOpts, relayerOwner []common.Address) (*NodeRegistryPrimaryRelayerUpdatedIterator, error) {
+
+	var relayerOwnerRule []interface{}
+	for _, relayerOwnerItem := range relayerOwner {
+		relayerOwnerRule = append(relayerOwnerRule, relayerOwnerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "PrimaryRelayerUpdated", relayerOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryPrimaryRelayerUpdatedIterator{contract: _NodeRegistry.contract, event: "PrimaryRelayerUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchPrimaryRelayerUpdated is a free log subscription operation binding the contract event 0x35481457cd222ebd02e4f2750d2505c2eac004e0cf60aeebcaa793c6dfbaceb3.
+//
+// Solidity: event PrimaryRelayerUpdated(address indexed relayerOwner)
+func (_NodeRegistry *NodeRegistryFilterer) WatchPrimaryRelayerUpdated(opts *bind.WatchOpts, sink chan<- *NodeRegistryPrimaryRelayerUpdated, relayerOwner []common.Address) (event.Subscription, error) {
+
+	var relayerOwnerRule []interface{}
+	for _, relayerOwnerItem := range relayerOwner {
+		relayerOwnerRule = append(relayerOwnerRule, relayerOwnerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "PrimaryRelayerUpdated", relayerOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryPrimaryRelayerUpdated)
+				if err := _NodeRegistry.contract.UnpackLog(event, "PrimaryRelayerUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePrimaryRelayerUpdated is a log parse operation binding the contract event 0x35481457cd222ebd02e4f2750d2505c2eac004e0cf60aeebcaa793c6dfbaceb3.
+//
+// Solidity: event PrimaryRelayerUpdated(address indexed relayerOwner)
+func (_NodeRegistry *NodeRegistryFilterer) ParsePrimaryRelayerUpdated(log types.Log) (*NodeRegistryPrimaryRelayerUpdated, error) {
+	event := new(NodeRegistryPrimaryRelayerUpdated)
+	if err := _NodeRegistry.contract.UnpackLog(event, "PrimaryRelayerUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryRelayerApprovalUpdatedIterator is returned from FilterRelayerApprovalUpdated and is used to iterate over the raw logs and unpacked data for RelayerApprovalUpdated events raised by the NodeRegistry contract.
+type NodeRegistryRelayerApprovalUpdatedIterator struct {
+	Event *NodeRegistryRelayerApprovalUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryRelayerApprovalUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryRelayerApprovalUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryRelayerApprovalUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryRelayerApprovalUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryRelayerApprovalUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryRelayerApprovalUpdated represents a RelayerApprovalUpdated event raised by the NodeRegistry contract.
+type NodeRegistryRelayerApprovalUpdated struct {
+	RelayerOwner common.Address
+	Approved     bool
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterRelayerApprovalUpdated is a free log retrieval operation binding the contract event 0xc18c96b23e5afbaf72346174b2e46029640a336fed31d8eb543c7d9e38ca5d76.
+//
+// Solidity: event RelayerApprovalUpdated(address indexed relayerOwner, bool approved)
+func (_NodeRegistry *NodeRegistryFilterer) FilterRelayerApprovalUpdated(opts *bind.FilterOpts, relayerOwner []common.Address) (*NodeRegistryRelayerApprovalUpdatedIterator, error) {
+
+	var relayerOwnerRule []interface{}
+	for _, relayerOwnerItem := range relayerOwner {
+		relayerOwnerRule = append(relayerOwnerRule, relayerOwnerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "RelayerApprovalUpdated", relayerOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryRelayerApprovalUpdatedIterator{contract: _NodeRegistry.contract, event: "RelayerApprovalUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchRelayerApprovalUpdated is a free log subscription operation binding the contract event 0xc18c96b23e5afbaf72346174b2e46029640a336fed31d8eb543c7d9e38ca5d76.
+//
+// Solidity: event RelayerApprovalUpdated(address indexed relayerOwner, bool approved)
+func (_NodeRegistry *NodeRegistryFilterer) WatchRelayerApprovalUpdated(opts *bind.WatchOpts, sink chan<- *NodeRegistryRelayerApprovalUpdated, relayerOwner []common.Address) (event.Subscription, error) {
+
+	var relayerOwnerRule []interface{}
+	for _, relayerOwnerItem := range relayerOwner {
+		relayerOwnerRule = append(relayerOwnerRule, relayerOwnerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "RelayerApprovalUpdated", relayerOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryRelayerApprovalUpdated)
+				if err := _NodeRegistry.contract.UnpackLog(event, "RelayerApprovalUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRelayerApprovalUpdated is a log parse operation binding the contract event 0xc18c96b23e5afbaf72346174b2e46029640a336fed31d8eb543c7d9e38ca5d76.
+//
+// Solidity: event RelayerApprovalUpdated(address indexed relayerOwner, bool approved)
+func (_NodeRegistry *NodeRegistryFilterer) ParseRelayerApprovalUpdated(log types.Log) (*NodeRegistryRelayerApprovalUpdated, error) {
+	event := new(NodeRegistryRelayerApprovalUpdated)
+	if err := _NodeRegistry.contract.UnpackLog(event, "RelayerApprovalUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryRelayerRegisteredIterator is returned from FilterRelayerRegistered and is used to iterate over the raw logs and unpacked data for RelayerRegistered events raised by the NodeRegistry contract.
+type NodeRegistryRelayerRegisteredIterator struct {
+	Event *NodeRegistryRelayerRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryRelayerRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryRelayerRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryRelayerRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryRelayerRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryRelayerRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryRelayerRegistered represents a RelayerRegistered event raised by the NodeRegistry contract.
+type NodeRegistryRelayerRegistered struct {
+	Owner common.Address
+	Ip    string
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterRelayerRegistered is a free log retrieval operation binding the contract event 0x119261697411a80cecee79669b3569ce033e07a0353a51c300b8e72f6e0e187d.
+//
+// Solidity: event RelayerRegistered(address indexed owner, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) FilterRelayerRegistered(opts *bind.FilterOpts, owner []common.Address) (*NodeRegistryRelayerRegisteredIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "RelayerRegistered", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryRelayerRegisteredIterator{contract: _NodeRegistry.contract, event: "RelayerRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchRelayerRegistered is a free log subscription operation binding the contract event 0x119261697411a80cecee79669b3569ce033e07a0353a51c300b8e72f6e0e187d.
+//
+// Solidity: event RelayerRegistered(address indexed owner, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) WatchRelayerRegistered(opts *bind.WatchOpts, sink chan<- *NodeRegistryRelayerRegistered, owner []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "RelayerRegistered", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryRelayerRegistered)
+				if err := _NodeRegistry.contract.UnpackLog(event, "RelayerRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRelayerRegistered is a log parse operation binding the contract event 0x119261697411a80cecee79669b3569ce033e07a0353a51c300b8e72f6e0e187d.
+//
+// Solidity: event RelayerRegistered(address indexed owner, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) ParseRelayerRegistered(log types.Log) (*NodeRegistryRelayerRegistered, error) {
+	event := new(NodeRegistryRelayerRegistered)
+	if err := _NodeRegistry.contract.UnpackLog(event, "RelayerRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryRelayerRemovedIterator is returned from FilterRelayerRemoved and is used to iterate over the raw logs and unpacked data for RelayerRemoved events raised by the NodeRegistry contract.
+type NodeRegistryRelayerRemovedIterator struct {
+	Event *NodeRegistryRelayerRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryRelayerRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryRelayerRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryRelayerRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryRelayerRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryRelayerRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryRelayerRemoved represents a RelayerRemoved event raised by the NodeRegistry contract.
+type NodeRegistryRelayerRemoved struct {
+	Owner common.Address
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterRelayerRemoved is a free log retrieval operation binding the contract event 0x10e1f7ce9fd7d1b90a66d13a2ab3cb8dd7f29f3f8d520b143b063ccfbab6906b.
+//
+// Solidity: event RelayerRemoved(address indexed owner)
+func (_NodeRegistry *NodeRegistryFilterer) FilterRelayerRemoved(opts *bind.FilterOpts, owner []common.Address) (*NodeRegistryRelayerRemovedIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "RelayerRemoved", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryRelayerRemovedIterator{contract: _NodeRegistry.contract, event: "RelayerRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchRelayerRemoved is a free log subscription operation binding the contract event 0x10e1f7ce9fd7d1b90a66d13a2ab3cb8dd7f29f3f8d520b143b063ccfbab6906b.
+//
+// Solidity: event RelayerRemoved(address indexed owner)
+func (_NodeRegistry *NodeRegistryFilterer) WatchRelayerRemoved(opts *bind.WatchOpts, sink chan<- *NodeRegistryRelayerRemoved, owner []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "RelayerRemoved", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryRelayerRemoved)
+				if err := _NodeRegistry.contract.UnpackLog(event, "RelayerRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRelayerRemoved is a log parse operation binding the contract event 0x10e1f7ce9fd7d1b90a66d13a2ab3cb8dd7f29f3f8d520b143b063ccfbab6906b.
+//
+// Solidity: event RelayerRemoved(address indexed owner)
+func (_NodeRegistry *NodeRegistryFilterer) ParseRelayerRemoved(log types.Log) (*NodeRegistryRelayerRemoved, error) {
+	event := new(NodeRegistryRelayerRemoved)
+	if err := _NodeRegistry.contract.UnpackLog(event, "RelayerRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverCapabilitiesUpdatedIterator is returned from FilterResolverCapabilitiesUpdated and is used to iterate over the raw logs and unpacked data for ResolverCapabilitiesUpdated events raised by the NodeRegistry contract.
+type NodeRegistryResolverCapabilitiesUpdatedIterator struct {
+	Event *NodeRegistryResolverCapabilitiesUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverCapabilitiesUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverCapabilitiesUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverCapabilitiesUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverCapabilitiesUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverCapabilitiesUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverCapabilitiesUpdated represents a ResolverCapabilitiesUpdated event raised by the NodeRegistry contract.
+type NodeRegistryResolverCapabilitiesUpdated struct {
+	PublicKey []byte
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverCapabilitiesUpdated is a free log retrieval operation binding the contract event 0x8cd8c2d9a114b6aab89a36737cb6d156dc70cf1bc123d54fb0acee5bf3ab7960.
+//
+// Solidity: event ResolverCapabilitiesUpdated(bytes publicKey)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverCapabilitiesUpdated(opts *bind.FilterOpts) (*NodeRegistryResolverCapabilitiesUpdatedIterator, error) {
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverCapabilitiesUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverCapabilitiesUpdatedIterator{contract: _NodeRegistry.contract, event: "ResolverCapabilitiesUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverCapabilitiesUpdated is a free log subscription operation binding the contract event 0x8cd8c2d9a114b6aab89a36737cb6d156dc70cf1bc123d54fb0acee5bf3ab7960.
+//
+// Solidity: event ResolverCapabilitiesUpdated(bytes publicKey)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverCapabilitiesUpdated(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverCapabilitiesUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverCapabilitiesUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverCapabilitiesUpdated)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverCapabilitiesUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverCapabilitiesUpdated is a log parse operation binding the contract event 0x8cd8c2d9a114b6aab89a36737cb6d156dc70cf1bc123d54fb0acee5bf3ab7960.
+//
+// Solidity: event ResolverCapabilitiesUpdated(bytes publicKey)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverCapabilitiesUpdated(log types.Log) (*NodeRegistryResolverCapabilitiesUpdated, error) {
+	event := new(NodeRegistryResolverCapabilitiesUpdated)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverCapabilitiesUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverKeyRotatedIterator is returned from FilterResolverKeyRotated and is used to iterate over the raw logs and unpacked data for ResolverKeyRotated events raised by the NodeRegistry contract.
+type NodeRegistryResolverKeyRotatedIterator struct {
+	Event *NodeRegistryResolverKeyRotated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverKeyRotatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverKeyRotated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverKeyRotated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverKeyRotatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverKeyRotatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverKeyRotated represents a ResolverKeyRotated event raised by the NodeRegistry contract.
+type NodeRegistryResolverKeyRotated struct {
+	OldPublicKey []byte
+	NewPublicKey []byte
+	ValidUntil   *big.Int
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverKeyRotated is a free log retrieval operation binding the contract event 0xf285d5b07010e42e51c652485826beb7cae0cc5fe4983ffda626d7a1a88a458c.
+//
+// Solidity: event ResolverKeyRotated(bytes oldPublicKey, bytes newPublicKey, uint256 validUntil)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverKeyRotated(opts *bind.FilterOpts) (*NodeRegistryResolverKeyRotatedIterator, error) {
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverKeyRotated")
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverKeyRotatedIterator{contract: _NodeRegistry.contract, event: "ResolverKeyRotated", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverKeyRotated is a free log subscription operation binding the contract event 0xf285d5b07010e42e51c652485826beb7cae0cc5fe4983ffda626d7a1a88a458c.
+//
+// Solidity: event ResolverKeyRotated(bytes oldPublicKey, bytes newPublicKey, uint256 validUntil)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverKeyRotated(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverKeyRotated) (event.Subscription, error) {
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverKeyRotated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverKeyRotated)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverKeyRotated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverKeyRotated is a log parse operation binding the contract event 0xf285d5b07010e42e51c652485826beb7cae0cc5fe4983ffda626d7a1a88a458c.
+//
+// Solidity: event ResolverKeyRotated(bytes oldPublicKey, bytes newPublicKey, uint256 validUntil)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverKeyRotated(log types.Log) (*NodeRegistryResolverKeyRotated, error) {
+	event := new(NodeRegistryResolverKeyRotated)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverKeyRotated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverRegisteredIterator is returned from FilterResolverRegistered and is used to iterate over the raw logs and unpacked data for ResolverRegistered events raised by the NodeRegistry contract.
+type NodeRegistryResolverRegisteredIterator struct {
+	Event *NodeRegistryResolverRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverRegistered represents a ResolverRegistered event raised by the NodeRegistry contract.
+type NodeRegistryResolverRegistered struct {
+	PublicKey []byte
+	Owner     common.Address
+	Ip        string
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverRegistered is a free log retrieval operation binding the contract event 0x14631046fd60db37cdcaa521f914707d5a6ca8809e87206c3a569ee246a1e05a.
+//
+// Solidity: event ResolverRegistered(bytes publicKey, address indexed owner, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverRegistered(opts *bind.FilterOpts, owner []common.Address) (*NodeRegistryResolverRegisteredIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverRegistered", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverRegisteredIterator{contract: _NodeRegistry.contract, event: "ResolverRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverRegistered is a free log subscription operation binding the contract event 0x14631046fd60db37cdcaa521f914707d5a6ca8809e87206c3a569ee246a1e05a.
+//
+// Solidity: event ResolverRegistered(bytes publicKey, address indexed owner, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverRegistered(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverRegistered, owner []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverRegistered", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverRegistered)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverRegistered is a log parse operation binding the contract event 0x14631046fd60db37cdcaa521f914707d5a6ca8809e87206c3a569ee246a1e05a.
+//
+// Solidity: event ResolverRegistered(bytes publicKey, address indexed owner, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverRegistered(log types.Log) (*NodeRegistryResolverRegistered, error) {
+	event := new(NodeRegistryResolverRegistered)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverRemovedIterator is returned from FilterResolverRemoved and is used to iterate over the raw logs and unpacked data for ResolverRemoved events raised by the NodeRegistry contract.
+type NodeRegistryResolverRemovedIterator struct {
+	Event *NodeRegistryResolverRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverRemoved represents a ResolverRemoved event raised by the NodeRegistry contract.
+type NodeRegistryResolverRemoved struct {
+	PublicKey []byte
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverRemoved is a free log retrieval operation binding the contract event 0x2b50bc55e1a16067cf46920ac4b73ac3ad2d71688b6df915f5d6706a08bc66b6.
+//
+// Solidity: event ResolverRemoved(bytes publicKey)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverRemoved(opts *bind.FilterOpts) (*NodeRegistryResolverRemovedIterator, error) {
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverRemoved")
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverRemovedIterator{contract: _NodeRegistry.contract, event: "ResolverRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverRemoved is a free log subscription operation binding the contract event 0x2b50bc55e1a16067cf46920ac4b73ac3ad2d71688b6df915f5d6706a08bc66b6.
+//
+// Solidity: event ResolverRemoved(bytes publicKey)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverRemoved(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverRemoved) (event.Subscription, error) {
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverRemoved")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverRemoved)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverRemoved is a log parse operation binding the contract event 0x2b50bc55e1a16067cf46920ac4b73ac3ad2d71688b6df915f5d6706a08bc66b6.
+//
+// Solidity: event ResolverRemoved(bytes publicKey)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverRemoved(log types.Log) (*NodeRegistryResolverRemoved, error) {
+	event := new(NodeRegistryResolverRemoved)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverSlashedIterator is returned from FilterResolverSlashed and is used to iterate over the raw logs and unpacked data for ResolverSlashed events raised by the NodeRegistry contract.
+type NodeRegistryResolverSlashedIterator struct {
+	Event *NodeRegistryResolverSlashed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverSlashedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverSlashed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverSlashed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverSlashedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverSlashedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverSlashed represents a ResolverSlashed event raised by the NodeRegistry contract.
+type NodeRegistryResolverSlashed struct {
+	PublicKey []byte
+	Slasher   common.Address
+	Amount    *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverSlashed is a free log retrieval operation binding the contract event 0xd8177226b11b04915c7cf46f189b793397879dfa6b9c802c9580280253a9e93c.
+//
+// Solidity: event ResolverSlashed(bytes publicKey, address indexed slasher, uint256 amount)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverSlashed(opts *bind.FilterOpts, slasher []common.Address) (*NodeRegistryResolverSlashedIterator, error) {
+
+	var slasherRule []interface{}
+	for _, slasherItem := range slasher {
+		slasherRule = append(slasherRule, slasherItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverSlashed", slasherRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverSlashedIterator{contract: _NodeRegistry.contract, event: "ResolverSlashed", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverSlashed is a free log subscription operation binding the contract event 0xd8177226b11b04915c7cf46f189b793397879dfa6b9c802c9580280253a9e93c.
+//
+// Solidity: event ResolverSlashed(bytes publicKey, address indexed slasher, uint256 amount)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverSlashed(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverSlashed, slasher []common.Address) (event.Subscription, error) {
+
+	var slasherRule []interface{}
+	for _, slasherItem := range slasher {
+		slasherRule = append(slasherRule, slasherItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverSlashed", slasherRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverSlashed)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverSlashed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverSlashed is a log parse operation binding the contract event 0xd8177226b11b04915c7cf46f189b793397879dfa6b9c802c9580280253a9e93c.
+//
+// Solidity: event ResolverSlashed(bytes publicKey, address indexed slasher, uint256 amount)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverSlashed(log types.Log) (*NodeRegistryResolverSlashed, error) {
+	event := new(NodeRegistryResolverSlashed)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverSlashed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverStakeWithdrawnIterator is returned from FilterResolverStakeWithdrawn and is used to iterate over the raw logs and unpacked data for ResolverStakeWithdrawn events raised by the NodeRegistry contract.
+type NodeRegistryResolverStakeWithdrawnIterator struct {
+	Event *NodeRegistryResolverStakeWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverStakeWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverStakeWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverStakeWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverStakeWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverStakeWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverStakeWithdrawn represents a ResolverStakeWithdrawn event raised by the NodeRegistry contract.
+type NodeRegistryResolverStakeWithdrawn struct {
+	PublicKey []byte
+	Owner     common.Address
+	Amount    *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverStakeWithdrawn is a free log retrieval operation binding the contract event 0x977b65de01de109637af6cd9cb96f3e82b3c2642a08255d7977aedf28fa24502.
+//
+// Solidity: event ResolverStakeWithdrawn(bytes publicKey, address indexed owner, uint256 amount)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverStakeWithdrawn(opts *bind.FilterOpts, owner []common.Address) (*NodeRegistryResolverStakeWithdrawnIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverStakeWithdrawn", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverStakeWithdrawnIterator{contract: _NodeRegistry.contract, event: "ResolverStakeWithdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverStakeWithdrawn is a free log subscription operation binding the contract event 0x977b65de01de109637af6cd9cb96f3e82b3c2642a08255d7977aedf28fa24502.
+//
+// Solidity: event ResolverStakeWithdrawn(bytes publicKey, address indexed owner, uint256 amount)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverStakeWithdrawn(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverStakeWithdrawn, owner []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverStakeWithdrawn", ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverStakeWithdrawn)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverStakeWithdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverStakeWithdrawn is a log parse operation binding the contract event 0x977b65de01de109637af6cd9cb96f3e82b3c2642a08255d7977aedf28fa24502.
+//
+// Solidity: event ResolverStakeWithdrawn(bytes publicKey, address indexed owner, uint256 amount)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverStakeWithdrawn(log types.Log) (*NodeRegistryResolverStakeWithdrawn, error) {
+	event := new(NodeRegistryResolverStakeWithdrawn)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverStakeWithdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverStakedIterator is returned from FilterResolverStaked and is used to iterate over the raw logs and unpacked data for ResolverStaked events raised by the NodeRegistry contract.
+type NodeRegistryResolverStakedIterator struct {
+	Event *NodeRegistryResolverStaked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverStakedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverStaked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverStaked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverStakedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverStakedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverStaked represents a ResolverStaked event raised by the NodeRegistry contract.
+type NodeRegistryResolverStaked struct {
+	PublicKey []byte
+	Amount    *big.Int
+	Stake     *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverStaked is a free log retrieval operation binding the contract event 0xec4366777d5ce32d10f92e972ff65ef95ef8f9d63af5dcbaa98c8fbc368b5463.
+//
+// Solidity: event ResolverStaked(bytes publicKey, uint256 amount, uint256 stake)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverStaked(opts *bind.FilterOpts) (*NodeRegistryResolverStakedIterator, error) {
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverStaked")
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverStakedIterator{contract: _NodeRegistry.contract, event: "ResolverStaked", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverStaked is a free log subscription operation binding the contract event 0xec4366777d5ce32d10f92e972ff65ef95ef8f9d63af5dcbaa98c8fbc368b5463.
+//
+// Solidity: event ResolverStaked(bytes publicKey, uint256 amount, uint256 stake)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverStaked(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverStaked) (event.Subscription, error) {
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverStaked")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverStaked)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverStaked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverStaked is a log parse operation binding the contract event 0xec4366777d5ce32d10f92e972ff65ef95ef8f9d63af5dcbaa98c8fbc368b5463.
+//
+// Solidity: event ResolverStaked(bytes publicKey, uint256 amount, uint256 stake)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverStaked(log types.Log) (*NodeRegistryResolverStaked, error) {
+	event := new(NodeRegistryResolverStaked)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverStaked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverUnstakedIterator is returned from FilterResolverUnstaked and is used to iterate over the raw logs and unpacked data for ResolverUnstaked events raised by the NodeRegistry contract.
+type NodeRegistryResolverUnstakedIterator struct {
+	Event *NodeRegistryResolverUnstaked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverUnstakedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverUnstaked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverUnstaked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverUnstakedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverUnstakedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverUnstaked represents a ResolverUnstaked event raised by the NodeRegistry contract.
+type NodeRegistryResolverUnstaked struct {
+	PublicKey   []byte
+	Amount      *big.Int
+	AvailableAt *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverUnstaked is a free log retrieval operation binding the contract event 0x7baaf4f3fb0bee5e014580d827d63c3e5c477c0a5eaabe18000c718d4987eecd.
+//
+// Solidity: event ResolverUnstaked(bytes publicKey, uint256 amount, uint256 availableAt)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverUnstaked(opts *bind.FilterOpts) (*NodeRegistryResolverUnstakedIterator, error) {
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverUnstaked")
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverUnstakedIterator{contract: _NodeRegistry.contract, event: "ResolverUnstaked", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverUnstaked is a free log subscription operation binding the contract event 0x7baaf4f3fb0bee5e014580d827d63c3e5c477c0a5eaabe18000c718d4987eecd.
+//
+// Solidity: event ResolverUnstaked(bytes publicKey, uint256 amount, uint256 availableAt)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverUnstaked(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverUnstaked) (event.Subscription, error) {
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverUnstaked")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverUnstaked)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverUnstaked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverUnstaked is a log parse operation binding the contract event 0x7baaf4f3fb0bee5e014580d827d63c3e5c477c0a5eaabe18000c718d4987eecd.
+//
+// Solidity: event ResolverUnstaked(bytes publicKey, uint256 amount, uint256 availableAt)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverUnstaked(log types.Log) (*NodeRegistryResolverUnstaked, error) {
+	event := new(NodeRegistryResolverUnstaked)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverUnstaked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryResolverUpdatedIterator is returned from FilterResolverUpdated and is used to iterate over the raw logs and unpacked data for ResolverUpdated events raised by the NodeRegistry contract.
+type NodeRegistryResolverUpdatedIterator struct {
+	Event *NodeRegistryResolverUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryResolverUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryResolverUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryResolverUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryResolverUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryResolverUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryResolverUpdated represents a ResolverUpdated event raised by the NodeRegistry contract.
+type NodeRegistryResolverUpdated struct {
+	PublicKey []byte
+	Ip        string
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterResolverUpdated is a free log retrieval operation binding the contract event 0x02dfabd5fefe902b33b340e710440433dfd6d0f8e8e107aa01a778b50d1f5c37.
+//
+// Solidity: event ResolverUpdated(bytes publicKey, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) FilterResolverUpdated(opts *bind.FilterOpts) (*NodeRegistryResolverUpdatedIterator, error) {
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "ResolverUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryResolverUpdatedIterator{contract: _NodeRegistry.contract, event: "ResolverUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchResolverUpdated is a free log subscription operation binding the contract event 0x02dfabd5fefe902b33b340e710440433dfd6d0f8e8e107aa01a778b50d1f5c37.
+//
+// Solidity: event ResolverUpdated(bytes publicKey, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) WatchResolverUpdated(opts *bind.WatchOpts, sink chan<- *NodeRegistryResolverUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "ResolverUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryResolverUpdated)
+				if err := _NodeRegistry.contract.UnpackLog(event, "ResolverUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseResolverUpdated is a log parse operation binding the contract event 0x02dfabd5fefe902b33b340e710440433dfd6d0f8e8e107aa01a778b50d1f5c37.
+//
+// Solidity: event ResolverUpdated(bytes publicKey, string ip)
+func (_NodeRegistry *NodeRegistryFilterer) ParseResolverUpdated(log types.Log) (*NodeRegistryResolverUpdated, error) {
+	event := new(NodeRegistryResolverUpdated)
+	if err := _NodeRegistry.contract.UnpackLog(event, "ResolverUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistrySlasherUpdatedIterator is returned from FilterSlasherUpdated and is used to iterate over the raw logs and unpacked data for SlasherUpdated events raised by the NodeRegistry contract.
+type NodeRegistrySlasherUpdatedIterator struct {
+	Event *NodeRegistrySlasherUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistrySlasherUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistrySlasherUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistrySlasherUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistrySlasherUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistrySlasherUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistrySlasherUpdated represents a SlasherUpdated event raised by the NodeRegistry contract.
+type NodeRegistrySlasherUpdated struct {
+	Slasher common.Address
+	Allowed bool
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterSlasherUpdated is a free log retrieval operation binding the contract event 0xeb3af117484a092137d31b606301615ef829364c4c501ae769735869d31d1d51.
+//
+// Solidity: event SlasherUpdated(address indexed slasher, bool allowed)
+func (_NodeRegistry *NodeRegistryFilterer) FilterSlasherUpdated(opts *bind.FilterOpts, slasher []common.Address) (*NodeRegistrySlasherUpdatedIterator, error) {
+
+	var slasherRule []interface{}
+	for _, slasherItem := range slasher {
+		slasherRule = append(slasherRule, slasherItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "SlasherUpdated", slasherRule)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistrySlasherUpdatedIterator{contract: _NodeRegistry.contract, event: "SlasherUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchSlasherUpdated is a free log subscription operation binding the contract event 0xeb3af117484a092137d31b606301615ef829364c4c501ae769735869d31d1d51.
+//
+// Solidity: event SlasherUpdated(address indexed slasher, bool allowed)
+func (_NodeRegistry *NodeRegistryFilterer) WatchSlasherUpdated(opts *bind.WatchOpts, sink chan<- *NodeRegistrySlasherUpdated, slasher []common.Address) (event.Subscription, error) {
+
+	var slasherRule []interface{}
+	for _, slasherItem := range slasher {
+		slasherRule = append(slasherRule, slasherItem)
+	}
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "SlasherUpdated", slasherRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistrySlasherUpdated)
+				if err := _NodeRegistry.contract.UnpackLog(event, "SlasherUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSlasherUpdated is a log parse operation binding the contract event 0xeb3af117484a092137d31b606301615ef829364c4c501ae769735869d31d1d51.
+//
+// Solidity: event SlasherUpdated(address indexed slasher, bool allowed)
+func (_NodeRegistry *NodeRegistryFilterer) ParseSlasherUpdated(log types.Log) (*NodeRegistrySlasherUpdated, error) {
+	event := new(NodeRegistrySlasherUpdated)
+	if err := _NodeRegistry.contract.UnpackLog(event, "SlasherUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// NodeRegistryStakeConfigUpdatedIterator is returned from FilterStakeConfigUpdated and is used to iterate over the raw logs and unpacked data for StakeConfigUpdated events raised by the NodeRegistry contract.
+type NodeRegistryStakeConfigUpdatedIterator struct {
+	Event *NodeRegistryStakeConfigUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *NodeRegistryStakeConfigUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(NodeRegistryStakeConfigUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(NodeRegistryStakeConfigUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *NodeRegistryStakeConfigUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *NodeRegistryStakeConfigUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// NodeRegistryStakeConfigUpdated represents a StakeConfigUpdated event raised by the NodeRegistry contract.
+type NodeRegistryStakeConfigUpdated struct {
+	MinResolverStake *big.Int
+	UnbondingPeriod  *big.Int
+	Raw              types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakeConfigUpdated is a free log retrieval operation binding the contract event 0xba51fd4d4127b8e4df59c2cd64d1c4d945320058c8e6e496f95ba0dd3b0b86e1.
+//
+// Solidity: event StakeConfigUpdated(uint256 minResolverStake, uint256 unbondingPeriod)
+func (_NodeRegistry *NodeRegistryFilterer) FilterStakeConfigUpdated(opts *bind.FilterOpts) (*NodeRegistryStakeConfigUpdatedIterator, error) {
+
+	logs, sub, err := _NodeRegistry.contract.FilterLogs(opts, "StakeConfigUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistryStakeConfigUpdatedIterator{contract: _NodeRegistry.contract, event: "StakeConfigUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchStakeConfigUpdated is a free log subscription operation binding the contract event 0xba51fd4d4127b8e4df59c2cd64d1c4d945320058c8e6e496f95ba0dd3b0b86e1.
+//
+// Solidity: event StakeConfigUpdated(uint256 minResolverStake, uint256 unbondingPeriod)
+func (_NodeRegistry *NodeRegistryFilterer) WatchStakeConfigUpdated(opts *bind.WatchOpts, sink chan<- *NodeRegistryStakeConfigUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _NodeRegistry.contract.WatchLogs(opts, "StakeConfigUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(NodeRegistryStakeConfigUpdated)
+				if err := _NodeRegistry.contract.UnpackLog(event, "StakeConfigUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakeConfigUpdated is a log parse operation binding the contract event 0xba51fd4d4127b8e4df59c2cd64d1c4d945320058c8e6e496f95ba0dd3b0b86e1.
+//
+// Solidity: event StakeConfigUpdated(uint256 minResolverStake, uint256 unbondingPeriod)
+func (_NodeRegistry *NodeRegistryFilterer) ParseStakeConfigUpdated(log types.Log) (*NodeRegistryStakeConfigUpdated, error) {
+	event := new(NodeRegistryStakeConfigUpdated)
+	if err := _NodeRegistry.contract.UnpackLog(event, "StakeConfigUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
 }
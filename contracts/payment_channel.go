@@ -0,0 +1,1079 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// PaymentChannelMetaData contains all meta data concerning the PaymentChannel contract.
+var PaymentChannelMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"delay\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"}],\"name\":\"Deposited\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"settled\",\"type\":\"uint256\"}],\"name\":\"Settled\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"withdrawableAt\",\"type\":\"uint256\"}],\"name\":\"WithdrawalRequested\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"Withdrawn\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"DOMAIN_SEPARATOR\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"VOUCHER_TYPEHASH\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"channels\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"deposit\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"settled\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"withdrawableAt\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"}],\"name\":\"deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"}],\"name\":\"requestWithdrawal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}],\"name\":\"settle\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"payer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"voucherHash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"hash\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"payee\",\"type\":\"address\"}],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"withdrawalDelay\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x60c060405234801561000f575f5ffd5b50604051610dbd380380610dbd83398101604081905261002e916100dd565b60a0818152604080517f8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f60208201527fbd921c0724c8ae2db5e3e658a141fa7a43d49494b3cd5e7ab1602b3b06f66914918101919091527fc89efdaa54c0f20c7adf612882df0950f5a951637e0307cdcb4c672f298b8bc66060820152466080820152309181019190915260c00160408051601f198184030181529190528051602090910120608052506100f4565b5f602082840312156100ed575f5ffd5b5051919050565b60805160a051610c9b6101225f395f81816101ed015261044401525f81816099015261072d0152610c9b5ff3fe608060405260043610610084575f3560e01c806394739e871161005757806394739e871461016b57806399bb07b81461019e5780639dbe34ff146101bd578063a7ab6961146101dc578063f340fa011461020f575f5ffd5b80633644e5151461008857806345a1b48d146100ce57806351cff8d91461012b57806386c5c4ed1461014c575b5f5ffd5b348015610093575f5ffd5b506100bb7f000000000000000000000000000000000000000000000000000000000000000081565b6040519081526020015b60405180910390f35b3480156100d9575f5ffd5b506101106100e8366004610a69565b5f60208181529281526040808220909352908152208054600182015460029092015490919083565b604080519384526020840192909252908201526060016100c5565b348015610136575f5ffd5b5061014a610145366004610a9a565b610222565b005b348015610157575f5ffd5b5061014a610166366004610a9a565b6103d5565b348015610176575f5ffd5b506100bb7fef4a8c81873f35a3a980964d4991a51031630c271e8fb3bba757d2dd4b2f413d81565b3480156101a9575f5ffd5b5061014a6101b8366004610aba565b6104b5565b3480156101c8575f5ffd5b506100bb6101d7366004610b4f565b6106b8565b3480156101e7575f5ffd5b506100bb7f000000000000000000000000000000000000000000000000000000000000000081565b61014a61021d366004610a9a565b610774565b335f908152602081815260408083206001600160a01b03851684529091529020600281015415801590610259575080600201544210155b6102a25760405162461bcd60e51b815260206004820152601560248201527415da5d1a191c985dd85b081a5cc819195b185e5959605a1b60448201526064015b60405180910390fd5b600181015481545f916102b491610b9d565b90505f81116102fb5760405162461bcd60e51b81526020600482015260136024820152724e6f7468696e6720746f20776974686472617760681b6044820152606401610299565b600182015482555f60028301819055604051339083908381818185875af1925050503d805f8114610347576040519150601f19603f3d011682016040523d82523d5f602084013e61034c565b606091505b505090508061038f5760405162461bcd60e51b815260206004820152600f60248201526e151c985b9cd9995c8819985a5b1959608a1b6044820152606401610299565b6040518281526001600160a01b0385169033907fd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb9060200160405180910390a350505050565b335f908152602081815260408083206001600160a01b03851684529091529020600181015481541161043f5760405162461bcd60e51b81526020600482015260136024820152724e6f7468696e6720746f20776974686472617760681b6044820152606401610299565b6104697f000000000000000000000000000000000000000000000000000000000000000042610bb6565b600282018190556040519081526001600160a01b0383169033907f04c56a409d50971e45c5a2d96e5d557d2b0f1d66d40f14b141e4c958b0f39b32906020015b60405180910390a35050565b6001600160a01b038086165f908152602081815260408083209388168352929052206001810154841161051e5760405162461bcd60e51b81526020600482015260116024820152704e6f7468696e6720746f20736574746c6560781b6044820152606401610299565b80548411156105685760405162461bcd60e51b8152602060048201526016602482015275105b5bdd5b9d08195e18d959591cc819195c1bdcda5d60521b6044820152606401610299565b856001600160a01b03166105876105808888886106b8565b8585610896565b6001600160a01b0316146105ad5760405162461bcd60e51b815260040161029990610bc9565b5f8160010154856105be9190610b9d565b600183018690556040519091505f906001600160a01b0388169083908381818185875af1925050503d805f8114610610576040519150601f19603f3d011682016040523d82523d5f602084013e610615565b606091505b50509050806106585760405162461bcd60e51b815260206004820152600f60248201526e151c985b9cd9995c8819985a5b1959608a1b6044820152606401610299565b866001600160a01b0316886001600160a01b03167f16c41a749cf94bd479b1fc5d82a6eb4557d71262f15dc382d2cf6f1eb3d68e8e84896040516106a6929190918252602082015260400190565b60405180910390a35050505050505050565b604080517fef4a8c81873f35a3a980964d4991a51031630c271e8fb3bba757d2dd4b2f413d6020808301919091526001600160a01b03958616828401529390941660608501526080808501929092528051808503909201825260a08401815281519183019190912061190160f01b60c08501527f000000000000000000000000000000000000000000000000000000000000000060c285015260e280850191909152815180850390910181526101029093019052815191012090565b6001600160a01b0381166107c25760405162461bcd60e51b815260206004820152601560248201527450617965652063616e6e6f7420626520656d70747960581b6044820152606401610299565b5f34116108115760405162461bcd60e51b815260206004820152601760248201527f4465706f7369742063616e6e6f7420626520656d7074790000000000000000006044820152606401610299565b335f908152602081815260408083206001600160a01b03851684529091528120805490913491839190610845908490610bb6565b90915550505f600282015580546040805134815260208101929092526001600160a01b0384169133917ff5681f9d0db1b911ac18ee83d515a1cf1051853a9eae418316a2fdf7dea427c591016104a9565b5f604182146108e75760405162461bcd60e51b815260206004820152601860248201527f496e76616c6964207369676e6174757265206c656e67746800000000000000006044820152606401610299565b5f6108f56020828587610bf4565b6108fe91610c1b565b90505f61090f604060208688610bf4565b61091891610c1b565b90505f8585604081811061092e5761092e610c38565b919091013560f81c915050601b8110156109505761094d601b82610c4c565b90505b8060ff16601b148061096557508060ff16601c145b6109815760405162461bcd60e51b815260040161029990610bc9565b7f7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a08211156109c15760405162461bcd60e51b815260040161029990610bc9565b604080515f81526020810180835289905260ff831691810191909152606081018490526080810183905260019060a0016020604051602081039080840390855afa158015610a11573d5f5f3e3d5ffd5b5050604051601f1901519450506001600160a01b038416610a445760405162461bcd60e51b815260040161029990610bc9565b5050509392505050565b80356001600160a01b0381168114610a64575f5ffd5b919050565b5f5f60408385031215610a7a575f5ffd5b610a8383610a4e565b9150610a9160208401610a4e565b90509250929050565b5f60208284031215610aaa575f5ffd5b610ab382610a4e565b9392505050565b5f5f5f5f5f60808688031215610ace575f5ffd5b610ad786610a4e565b9450610ae560208701610a4e565b935060408601359250606086013567ffffffffffffffff811115610b07575f5ffd5b8601601f81018813610b17575f5ffd5b803567ffffffffffffffff811115610b2d575f5ffd5b886020828401011115610b3e575f5ffd5b959894975092955050506020019190565b5f5f5f60608486031215610b61575f5ffd5b610b6a84610a4e565b9250610b7860208501610a4e565b929592945050506040919091013590565b634e487b7160e01b5f52601160045260245ffd5b81810381811115610bb057610bb0610b89565b92915050565b80820180821115610bb057610bb0610b89565b602080825260119082015270496e76616c6964207369676e617475726560781b604082015260600190565b5f5f85851115610c02575f5ffd5b83861115610c0e575f5ffd5b5050820193919092039150565b80356020831015610bb0575f19602084900360031b1b1692915050565b634e487b7160e01b5f52603260045260245ffd5b60ff8181168382160190811115610bb057610bb0610b8956fea2646970667358221220f425816db3550771ca3fc45198453328b15253aff1241c0689f8eddf67a6a98064736f6c634300081e0033",
+}
+
+// PaymentChannelABI is the input ABI used to generate the binding from.
+// Deprecated: Use PaymentChannelMetaData.ABI instead.
+var PaymentChannelABI = PaymentChannelMetaData.ABI
+
+// PaymentChannelBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use PaymentChannelMetaData.Bin instead.
+var PaymentChannelBin = PaymentChannelMetaData.Bin
+
+// DeployPaymentChannel deploys a new Ethereum contract, binding an instance of PaymentChannel to it.
+func DeployPaymentChannel(auth *bind.TransactOpts, backend bind.ContractBackend, delay *big.Int) (common.Address, *types.Transaction, *PaymentChannel, error) {
+	parsed, err := PaymentChannelMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(PaymentChannelBin), backend, delay)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &PaymentChannel{PaymentChannelCaller: PaymentChannelCaller{contract: contract}, PaymentChannelTransactor: PaymentChannelTransactor{contract: contract}, PaymentChannelFilterer: PaymentChannelFilterer{contract: contract}}, nil
+}
+
+// PaymentChannel is an auto generated Go binding around an Ethereum contract.
+type PaymentChannel struct {
+	PaymentChannelCaller     // Read-only binding to the contract
+	PaymentChannelTransactor // Write-only binding to the contract
+	PaymentChannelFilterer   // Log filterer for contract events
+}
+
+// PaymentChannelCaller is an auto generated read-only Go binding around an Ethereum contract.
+type PaymentChannelCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymentChannelTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type PaymentChannelTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymentChannelFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type PaymentChannelFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PaymentChannelSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type PaymentChannelSession struct {
+	Contract     *PaymentChannel   // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// PaymentChannelCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type PaymentChannelCallerSession struct {
+	Contract *PaymentChannelCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts         // Call options to use throughout this session
+}
+
+// PaymentChannelTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type PaymentChannelTransactorSession struct {
+	Contract     *PaymentChannelTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// PaymentChannelRaw is an auto generated low-level Go binding around an Ethereum contract.
+type PaymentChannelRaw struct {
+	Contract *PaymentChannel // Generic contract binding to access the raw methods on
+}
+
+// PaymentChannelCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type PaymentChannelCallerRaw struct {
+	Contract *PaymentChannelCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// PaymentChannelTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type PaymentChannelTransactorRaw struct {
+	Contract *PaymentChannelTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewPaymentChannel creates a new instance of PaymentChannel, bound to a specific deployed contract.
+func NewPaymentChannel(address common.Address, backend bind.ContractBackend) (*PaymentChannel, error) {
+	contract, err := bindPaymentChannel(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentChannel{PaymentChannelCaller: PaymentChannelCaller{contract: contract}, PaymentChannelTransactor: PaymentChannelTransactor{contract: contract}, PaymentChannelFilterer: PaymentChannelFilterer{contract: contract}}, nil
+}
+
+// NewPaymentChannelCaller creates a new read-only instance of PaymentChannel, bound to a specific deployed contract.
+func NewPaymentChannelCaller(address common.Address, caller bind.ContractCaller) (*PaymentChannelCaller, error) {
+	contract, err := bindPaymentChannel(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentChannelCaller{contract: contract}, nil
+}
+
+// NewPaymentChannelTransactor creates a new write-only instance of PaymentChannel, bound to a specific deployed contract.
+func NewPaymentChannelTransactor(address common.Address, transactor bind.ContractTransactor) (*PaymentChannelTransactor, error) {
+	contract, err := bindPaymentChannel(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentChannelTransactor{contract: contract}, nil
+}
+
+// NewPaymentChannelFilterer creates a new log filterer instance of PaymentChannel, bound to a specific deployed contract.
+func NewPaymentChannelFilterer(address common.Address, filterer bind.ContractFilterer) (*PaymentChannelFilterer, error) {
+	contract, err := bindPaymentChannel(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentChannelFilterer{contract: contract}, nil
+}
+
+// bindPaymentChannel binds a generic wrapper to an already deployed contract.
+func bindPaymentChannel(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := PaymentChannelMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PaymentChannel *PaymentChannelRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PaymentChannel.Contract.PaymentChannelCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PaymentChannel *PaymentChannelRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.PaymentChannelTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PaymentChannel *PaymentChannelRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.PaymentChannelTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PaymentChannel *PaymentChannelCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PaymentChannel.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PaymentChannel *PaymentChannelTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PaymentChannel *PaymentChannelTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.contract.Transact(opts, method, params...)
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_PaymentChannel *PaymentChannelCaller) DOMAINSEPARATOR(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PaymentChannel.contract.Call(opts, &out, "DOMAIN_SEPARATOR")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_PaymentChannel *PaymentChannelSession) DOMAINSEPARATOR() ([32]byte, error) {
+	return _PaymentChannel.Contract.DOMAINSEPARATOR(&_PaymentChannel.CallOpts)
+}
+
+// DOMAINSEPARATOR is a free data retrieval call binding the contract method 0x3644e515.
+//
+// Solidity: function DOMAIN_SEPARATOR() view returns(bytes32)
+func (_PaymentChannel *PaymentChannelCallerSession) DOMAINSEPARATOR() ([32]byte, error) {
+	return _PaymentChannel.Contract.DOMAINSEPARATOR(&_PaymentChannel.CallOpts)
+}
+
+// VOUCHERTYPEHASH is a free data retrieval call binding the contract method 0x94739e87.
+//
+// Solidity: function VOUCHER_TYPEHASH() view returns(bytes32)
+func (_PaymentChannel *PaymentChannelCaller) VOUCHERTYPEHASH(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PaymentChannel.contract.Call(opts, &out, "VOUCHER_TYPEHASH")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// VOUCHERTYPEHASH is a free data retrieval call binding the contract method 0x94739e87.
+//
+// Solidity: function VOUCHER_TYPEHASH() view returns(bytes32)
+func (_PaymentChannel *PaymentChannelSession) VOUCHERTYPEHASH() ([32]byte, error) {
+	return _PaymentChannel.Contract.VOUCHERTYPEHASH(&_PaymentChannel.CallOpts)
+}
+
+// VOUCHERTYPEHASH is a free data retrieval call binding the contract method 0x94739e87.
+//
+// Solidity: function VOUCHER_TYPEHASH() view returns(bytes32)
+func (_PaymentChannel *PaymentChannelCallerSession) VOUCHERTYPEHASH() ([32]byte, error) {
+	return _PaymentChannel.Contract.VOUCHERTYPEHASH(&_PaymentChannel.CallOpts)
+}
+
+// Channels is a free data retrieval call binding the contract method 0x45a1b48d.
+//
+// Solidity: function channels(address , address ) view returns(uint256 deposit, uint256 settled, uint256 withdrawableAt)
+func (_PaymentChannel *PaymentChannelCaller) Channels(opts *bind.CallOpts, arg0 common.Address, arg1 common.Address) (struct {
+	Deposit        *big.Int
+	Settled        *big.Int
+	WithdrawableAt *big.Int
+}, error) {
+	var out []interface{}
+	err := _PaymentChannel.contract.Call(opts, &out, "channels", arg0, arg1)
+
+	outstruct := new(struct {
+		Deposit        *big.Int
+		Settled        *big.Int
+		WithdrawableAt *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Deposit = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Settled = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.WithdrawableAt = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Channels is a free data retrieval call binding the contract method 0x45a1b48d.
+//
+// Solidity: function channels(address , address ) view returns(uint256 deposit, uint256 settled, uint256 withdrawableAt)
+func (_PaymentChannel *PaymentChannelSession) Channels(arg0 common.Address, arg1 common.Address) (struct {
+	Deposit        *big.Int
+	Settled        *big.Int
+	WithdrawableAt *big.Int
+}, error) {
+	return _PaymentChannel.Contract.Channels(&_PaymentChannel.CallOpts, arg0, arg1)
+}
+
+// Channels is a free data retrieval call binding the contract method 0x45a1b48d.
+//
+// Solidity: function channels(address , address ) view returns(uint256 deposit, uint256 settled, uint256 withdrawableAt)
+func (_PaymentChannel *PaymentChannelCallerSession) Channels(arg0 common.Address, arg1 common.Address) (struct {
+	Deposit        *big.Int
+	Settled        *big.Int
+	WithdrawableAt *big.Int
+}, error) {
+	return _PaymentChannel.Contract.Channels(&_PaymentChannel.CallOpts, arg0, arg1)
+}
+
+// VoucherHash is a free data retrieval call binding the contract method 0x9dbe34ff.
+//
+// Solidity: function voucherHash(address payer, address payee, uint256 amount) view returns(bytes32 hash)
+func (_PaymentChannel *PaymentChannelCaller) VoucherHash(opts *bind.CallOpts, payer common.Address, payee common.Address, amount *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _PaymentChannel.contract.Call(opts, &out, "voucherHash", payer, payee, amount)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// VoucherHash is a free data retrieval call binding the contract method 0x9dbe34ff.
+//
+// Solidity: function voucherHash(address payer, address payee, uint256 amount) view returns(bytes32 hash)
+func (_PaymentChannel *PaymentChannelSession) VoucherHash(payer common.Address, payee common.Address, amount *big.Int) ([32]byte, error) {
+	return _PaymentChannel.Contract.VoucherHash(&_PaymentChannel.CallOpts, payer, payee, amount)
+}
+
+// VoucherHash is a free data retrieval call binding the contract method 0x9dbe34ff.
+//
+// Solidity: function voucherHash(address payer, address payee, uint256 amount) view returns(bytes32 hash)
+func (_PaymentChannel *PaymentChannelCallerSession) VoucherHash(payer common.Address, payee common.Address, amount *big.Int) ([32]byte, error) {
+	return _PaymentChannel.Contract.VoucherHash(&_PaymentChannel.CallOpts, payer, payee, amount)
+}
+
+// WithdrawalDelay is a free data retrieval call binding the contract method 0xa7ab6961.
+//
+// Solidity: function withdrawalDelay() view returns(uint256)
+func (_PaymentChannel *PaymentChannelCaller) WithdrawalDelay(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PaymentChannel.contract.Call(opts, &out, "withdrawalDelay")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// WithdrawalDelay is a free data retrieval call binding the contract method 0xa7ab6961.
+//
+// Solidity: function withdrawalDelay() view returns(uint256)
+func (_PaymentChannel *PaymentChannelSession) WithdrawalDelay() (*big.Int, error) {
+	return _PaymentChannel.Contract.WithdrawalDelay(&_PaymentChannel.CallOpts)
+}
+
+// WithdrawalDelay is a free data retrieval call binding the contract method 0xa7ab6961.
+//
+// Solidity: function withdrawalDelay() view returns(uint256)
+func (_PaymentChannel *PaymentChannelCallerSession) WithdrawalDelay() (*big.Int, error) {
+	return _PaymentChannel.Contract.WithdrawalDelay(&_PaymentChannel.CallOpts)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xf340fa01.
+//
+// Solidity: function deposit(address payee) payable returns()
+func (_PaymentChannel *PaymentChannelTransactor) Deposit(opts *bind.TransactOpts, payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.contract.Transact(opts, "deposit", payee)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xf340fa01.
+//
+// Solidity: function deposit(address payee) payable returns()
+func (_PaymentChannel *PaymentChannelSession) Deposit(payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.Deposit(&_PaymentChannel.TransactOpts, payee)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xf340fa01.
+//
+// Solidity: function deposit(address payee) payable returns()
+func (_PaymentChannel *PaymentChannelTransactorSession) Deposit(payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.Deposit(&_PaymentChannel.TransactOpts, payee)
+}
+
+// RequestWithdrawal is a paid mutator transaction binding the contract method 0x86c5c4ed.
+//
+// Solidity: function requestWithdrawal(address payee) returns()
+func (_PaymentChannel *PaymentChannelTransactor) RequestWithdrawal(opts *bind.TransactOpts, payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.contract.Transact(opts, "requestWithdrawal", payee)
+}
+
+// RequestWithdrawal is a paid mutator transaction binding the contract method 0x86c5c4ed.
+//
+// Solidity: function requestWithdrawal(address payee) returns()
+func (_PaymentChannel *PaymentChannelSession) RequestWithdrawal(payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.RequestWithdrawal(&_PaymentChannel.TransactOpts, payee)
+}
+
+// RequestWithdrawal is a paid mutator transaction binding the contract method 0x86c5c4ed.
+//
+// Solidity: function requestWithdrawal(address payee) returns()
+func (_PaymentChannel *PaymentChannelTransactorSession) RequestWithdrawal(payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.RequestWithdrawal(&_PaymentChannel.TransactOpts, payee)
+}
+
+// Settle is a paid mutator transaction binding the contract method 0x99bb07b8.
+//
+// Solidity: function settle(address payer, address payee, uint256 amount, bytes signature) returns()
+func (_PaymentChannel *PaymentChannelTransactor) Settle(opts *bind.TransactOpts, payer common.Address, payee common.Address, amount *big.Int, signature []byte) (*types.Transaction, error) {
+	return _PaymentChannel.contract.Transact(opts, "settle", payer, payee, amount, signature)
+}
+
+// Settle is a paid mutator transaction binding the contract method 0x99bb07b8.
+//
+// Solidity: function settle(address payer, address payee, uint256 amount, bytes signature) returns()
+func (_PaymentChannel *PaymentChannelSession) Settle(payer common.Address, payee common.Address, amount *big.Int, signature []byte) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.Settle(&_PaymentChannel.TransactOpts, payer, payee, amount, signature)
+}
+
+// Settle is a paid mutator transaction binding the contract method 0x99bb07b8.
+//
+// Solidity: function settle(address payer, address payee, uint256 amount, bytes signature) returns()
+func (_PaymentChannel *PaymentChannelTransactorSession) Settle(payer common.Address, payee common.Address, amount *big.Int, signature []byte) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.Settle(&_PaymentChannel.TransactOpts, payer, payee, amount, signature)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x51cff8d9.
+//
+// Solidity: function withdraw(address payee) returns()
+func (_PaymentChannel *PaymentChannelTransactor) Withdraw(opts *bind.TransactOpts, payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.contract.Transact(opts, "withdraw", payee)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x51cff8d9.
+//
+// Solidity: function withdraw(address payee) returns()
+func (_PaymentChannel *PaymentChannelSession) Withdraw(payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.Withdraw(&_PaymentChannel.TransactOpts, payee)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x51cff8d9.
+//
+// Solidity: function withdraw(address payee) returns()
+func (_PaymentChannel *PaymentChannelTransactorSession) Withdraw(payee common.Address) (*types.Transaction, error) {
+	return _PaymentChannel.Contract.Withdraw(&_PaymentChannel.TransactOpts, payee)
+}
+
+// PaymentChannelDepositedIterator is returned from FilterDeposited and is used to iterate over the raw logs and unpacked data for Deposited events raised by the PaymentChannel contract.
+type PaymentChannelDepositedIterator struct {
+	Event *PaymentChannelDeposited // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentChannelDepositedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentChannelDeposited)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentChannelDeposited)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentChannelDepositedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentChannelDepositedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentChannelDeposited represents a Deposited event raised by the PaymentChannel contract.
+type PaymentChannelDeposited struct {
+	Payer   common.Address
+	Payee   common.Address
+	Amount  *big.Int
+	Deposit *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterDeposited is a free log retrieval operation binding the contract event 0xf5681f9d0db1b911ac18ee83d515a1cf1051853a9eae418316a2fdf7dea427c5.
+//
+// Solidity: event Deposited(address indexed payer, address indexed payee, uint256 amount, uint256 deposit)
+func (_PaymentChannel *PaymentChannelFilterer) FilterDeposited(opts *bind.FilterOpts, payer []common.Address, payee []common.Address) (*PaymentChannelDepositedIterator, error) {
+
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _PaymentChannel.contract.FilterLogs(opts, "Deposited", payerRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentChannelDepositedIterator{contract: _PaymentChannel.contract, event: "Deposited", logs: logs, sub: sub}, nil
+}
+
+// WatchDeposited is a free log subscription operation binding the contract event 0xf5681f9d0db1b911ac18ee83d515a1cf1051853a9eae418316a2fdf7dea427c5.
+//
+// Solidity: event Deposited(address indexed payer, address indexed payee, uint256 amount, uint256 deposit)
+func (_PaymentChannel *PaymentChannelFilterer) WatchDeposited(opts *bind.WatchOpts, sink chan<- *PaymentChannelDeposited, payer []common.Address, payee []common.Address) (event.Subscription, error) {
+
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _PaymentChannel.contract.WatchLogs(opts, "Deposited", payerRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentChannelDeposited)
+				if err := _PaymentChannel.contract.UnpackLog(event, "Deposited", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDeposited is a log parse operation binding the contract event 0xf5681f9d0db1b911ac18ee83d515a1cf1051853a9eae418316a2fdf7dea427c5.
+//
+// Solidity: event Deposited(address indexed payer, address indexed payee, uint256 amount, uint256 deposit)
+func (_PaymentChannel *PaymentChannelFilterer) ParseDeposited(log types.Log) (*PaymentChannelDeposited, error) {
+	event := new(PaymentChannelDeposited)
+	if err := _PaymentChannel.contract.UnpackLog(event, "Deposited", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentChannelSettledIterator is returned from FilterSettled and is used to iterate over the raw logs and unpacked data for Settled events raised by the PaymentChannel contract.
+type PaymentChannelSettledIterator struct {
+	Event *PaymentChannelSettled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentChannelSettledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentChannelSettled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentChannelSettled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentChannelSettledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentChannelSettledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentChannelSettled represents a Settled event raised by the PaymentChannel contract.
+type PaymentChannelSettled struct {
+	Payer   common.Address
+	Payee   common.Address
+	Amount  *big.Int
+	Settled *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterSettled is a free log retrieval operation binding the contract event 0x16c41a749cf94bd479b1fc5d82a6eb4557d71262f15dc382d2cf6f1eb3d68e8e.
+//
+// Solidity: event Settled(address indexed payer, address indexed payee, uint256 amount, uint256 settled)
+func (_PaymentChannel *PaymentChannelFilterer) FilterSettled(opts *bind.FilterOpts, payer []common.Address, payee []common.Address) (*PaymentChannelSettledIterator, error) {
+
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _PaymentChannel.contract.FilterLogs(opts, "Settled", payerRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentChannelSettledIterator{contract: _PaymentChannel.contract, event: "Settled", logs: logs, sub: sub}, nil
+}
+
+// WatchSettled is a free log subscription operation binding the contract event 0x16c41a749cf94bd479b1fc5d82a6eb4557d71262f15dc382d2cf6f1eb3d68e8e.
+//
+// Solidity: event Settled(address indexed payer, address indexed payee, uint256 amount, uint256 settled)
+func (_PaymentChannel *PaymentChannelFilterer) WatchSettled(opts *bind.WatchOpts, sink chan<- *PaymentChannelSettled, payer []common.Address, payee []common.Address) (event.Subscription, error) {
+
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _PaymentChannel.contract.WatchLogs(opts, "Settled", payerRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentChannelSettled)
+				if err := _PaymentChannel.contract.UnpackLog(event, "Settled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSettled is a log parse operation binding the contract event 0x16c41a749cf94bd479b1fc5d82a6eb4557d71262f15dc382d2cf6f1eb3d68e8e.
+//
+// Solidity: event Settled(address indexed payer, address indexed payee, uint256 amount, uint256 settled)
+func (_PaymentChannel *PaymentChannelFilterer) ParseSettled(log types.Log) (*PaymentChannelSettled, error) {
+	event := new(PaymentChannelSettled)
+	if err := _PaymentChannel.contract.UnpackLog(event, "Settled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentChannelWithdrawalRequestedIterator is returned from FilterWithdrawalRequested and is used to iterate over the raw logs and unpacked data for WithdrawalRequested events raised by the PaymentChannel contract.
+type PaymentChannelWithdrawalRequestedIterator struct {
+	Event *PaymentChannelWithdrawalRequested // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentChannelWithdrawalRequestedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentChannelWithdrawalRequested)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentChannelWithdrawalRequested)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentChannelWithdrawalRequestedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentChannelWithdrawalRequestedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentChannelWithdrawalRequested represents a WithdrawalRequested event raised by the PaymentChannel contract.
+type PaymentChannelWithdrawalRequested struct {
+	Payer          common.Address
+	Payee          common.Address
+	WithdrawableAt *big.Int
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawalRequested is a free log retrieval operation binding the contract event 0x04c56a409d50971e45c5a2d96e5d557d2b0f1d66d40f14b141e4c958b0f39b32.
+//
+// Solidity: event WithdrawalRequested(address indexed payer, address indexed payee, uint256 withdrawableAt)
+func (_PaymentChannel *PaymentChannelFilterer) FilterWithdrawalRequested(opts *bind.FilterOpts, payer []common.Address, payee []common.Address) (*PaymentChannelWithdrawalRequestedIterator, error) {
+
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _PaymentChannel.contract.FilterLogs(opts, "WithdrawalRequested", payerRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentChannelWithdrawalRequestedIterator{contract: _PaymentChannel.contract, event: "WithdrawalRequested", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawalRequested is a free log subscription operation binding the contract event 0x04c56a409d50971e45c5a2d96e5d557d2b0f1d66d40f14b141e4c958b0f39b32.
+//
+// Solidity: event WithdrawalRequested(address indexed payer, address indexed payee, uint256 withdrawableAt)
+func (_PaymentChannel *PaymentChannelFilterer) WatchWithdrawalRequested(opts *bind.WatchOpts, sink chan<- *PaymentChannelWithdrawalRequested, payer []common.Address, payee []common.Address) (event.Subscription, error) {
+
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _PaymentChannel.contract.WatchLogs(opts, "WithdrawalRequested", payerRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentChannelWithdrawalRequested)
+				if err := _PaymentChannel.contract.UnpackLog(event, "WithdrawalRequested", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawalRequested is a log parse operation binding the contract event 0x04c56a409d50971e45c5a2d96e5d557d2b0f1d66d40f14b141e4c958b0f39b32.
+//
+// Solidity: event WithdrawalRequested(address indexed payer, address indexed payee, uint256 withdrawableAt)
+func (_PaymentChannel *PaymentChannelFilterer) ParseWithdrawalRequested(log types.Log) (*PaymentChannelWithdrawalRequested, error) {
+	event := new(PaymentChannelWithdrawalRequested)
+	if err := _PaymentChannel.contract.UnpackLog(event, "WithdrawalRequested", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PaymentChannelWithdrawnIterator is returned from FilterWithdrawn and is used to iterate over the raw logs and unpacked data for Withdrawn events raised by the PaymentChannel contract.
+type PaymentChannelWithdrawnIterator struct {
+	Event *PaymentChannelWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PaymentChannelWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PaymentChannelWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PaymentChannelWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PaymentChannelWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PaymentChannelWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PaymentChannelWithdrawn represents a Withdrawn event raised by the PaymentChannel contract.
+type PaymentChannelWithdrawn struct {
+	Payer  common.Address
+	Payee  common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterWithdrawn is a free log retrieval operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed payer, address indexed payee, uint256 amount)
+func (_PaymentChannel *PaymentChannelFilterer) FilterWithdrawn(opts *bind.FilterOpts, payer []common.Address, payee []common.Address) (*PaymentChannelWithdrawnIterator, error) {
+
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _PaymentChannel.contract.FilterLogs(opts, "Withdrawn", payerRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return &PaymentChannelWithdrawnIterator{contract: _PaymentChannel.contract, event: "Withdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchWithdrawn is a free log subscription operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed payer, address indexed payee, uint256 amount)
+func (_PaymentChannel *PaymentChannelFilterer) WatchWithdrawn(opts *bind.WatchOpts, sink chan<- *PaymentChannelWithdrawn, payer []common.Address, payee []common.Address) (event.Subscription, error) {
+
+	var payerRule []interface{}
+	for _, payerItem := range payer {
+		payerRule = append(payerRule, payerItem)
+	}
+	var payeeRule []interface{}
+	for _, payeeItem := range payee {
+		payeeRule = append(payeeRule, payeeItem)
+	}
+
+	logs, sub, err := _PaymentChannel.contract.WatchLogs(opts, "Withdrawn", payerRule, payeeRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PaymentChannelWithdrawn)
+				if err := _PaymentChannel.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseWithdrawn is a log parse operation binding the contract event 0xd1c19fbcd4551a5edfb66d43d2e337c04837afda3482b42bdf569a8fccdae5fb.
+//
+// Solidity: event Withdrawn(address indexed payer, address indexed payee, uint256 amount)
+func (_PaymentChannel *PaymentChannelFilterer) ParseWithdrawn(log types.Log) (*PaymentChannelWithdrawn, error) {
+	event := new(PaymentChannelWithdrawn)
+	if err := _PaymentChannel.contract.UnpackLog(event, "Withdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -2,3 +2,4 @@
 package mock
 
 //go:generate sh -c "mockgen -source=../../relayer/webrtc/server.go -destination=./mock_grpc.go -package=mock GRPCClient"
+//go:generate sh -c "mockgen -source=../signer/remote.go -destination=./mock_signer.go -package=mock RemoteBackend"
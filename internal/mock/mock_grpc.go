@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	contracts "github.com/1inch/p2p-network/contracts"
 	resolver "github.com/1inch/p2p-network/proto/resolver"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -108,3 +109,80 @@ func (mr *MockRegistryClientMockRecorder) GetResolver(publicKey any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResolver", reflect.TypeOf((*MockRegistryClient)(nil).GetResolver), publicKey)
 }
+
+// MockCapabilityRegistry is a mock of CapabilityRegistry interface.
+type MockCapabilityRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockCapabilityRegistryMockRecorder
+	isgomock struct{}
+}
+
+// MockCapabilityRegistryMockRecorder is the mock recorder for MockCapabilityRegistry.
+type MockCapabilityRegistryMockRecorder struct {
+	mock *MockCapabilityRegistry
+}
+
+// NewMockCapabilityRegistry creates a new mock instance.
+func NewMockCapabilityRegistry(ctrl *gomock.Controller) *MockCapabilityRegistry {
+	mock := &MockCapabilityRegistry{ctrl: ctrl}
+	mock.recorder = &MockCapabilityRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCapabilityRegistry) EXPECT() *MockCapabilityRegistryMockRecorder {
+	return m.recorder
+}
+
+// GetResolverCapabilities mocks base method.
+func (m *MockCapabilityRegistry) GetResolverCapabilities(publicKey []byte) (contracts.NodeRegistryCapabilities, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResolverCapabilities", publicKey)
+	ret0, _ := ret[0].(contracts.NodeRegistryCapabilities)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResolverCapabilities indicates an expected call of GetResolverCapabilities.
+func (mr *MockCapabilityRegistryMockRecorder) GetResolverCapabilities(publicKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResolverCapabilities", reflect.TypeOf((*MockCapabilityRegistry)(nil).GetResolverCapabilities), publicKey)
+}
+
+// MockLivenessChecker is a mock of LivenessChecker interface.
+type MockLivenessChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLivenessCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockLivenessCheckerMockRecorder is the mock recorder for MockLivenessChecker.
+type MockLivenessCheckerMockRecorder struct {
+	mock *MockLivenessChecker
+}
+
+// NewMockLivenessChecker creates a new mock instance.
+func NewMockLivenessChecker(ctrl *gomock.Controller) *MockLivenessChecker {
+	mock := &MockLivenessChecker{ctrl: ctrl}
+	mock.recorder = &MockLivenessCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLivenessChecker) EXPECT() *MockLivenessCheckerMockRecorder {
+	return m.recorder
+}
+
+// IsAlive mocks base method.
+func (m *MockLivenessChecker) IsAlive(publicKey []byte) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAlive", publicKey)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAlive indicates an expected call of IsAlive.
+func (mr *MockLivenessCheckerMockRecorder) IsAlive(publicKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAlive", reflect.TypeOf((*MockLivenessChecker)(nil).IsAlive), publicKey)
+}
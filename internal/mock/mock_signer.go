@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../signer/remote.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../signer/remote.go -destination=./mock_signer.go -package=mock RemoteBackend
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRemoteBackend is a mock of RemoteBackend interface.
+type MockRemoteBackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemoteBackendMockRecorder
+	isgomock struct{}
+}
+
+// MockRemoteBackendMockRecorder is the mock recorder for MockRemoteBackend.
+type MockRemoteBackendMockRecorder struct {
+	mock *MockRemoteBackend
+}
+
+// NewMockRemoteBackend creates a new mock instance.
+func NewMockRemoteBackend(ctrl *gomock.Controller) *MockRemoteBackend {
+	mock := &MockRemoteBackend{ctrl: ctrl}
+	mock.recorder = &MockRemoteBackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRemoteBackend) EXPECT() *MockRemoteBackendMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *MockRemoteBackend) Decrypt(ctx context.Context, payload []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", ctx, payload)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockRemoteBackendMockRecorder) Decrypt(ctx, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockRemoteBackend)(nil).Decrypt), ctx, payload)
+}
+
+// PublicKey mocks base method.
+func (m *MockRemoteBackend) PublicKey(ctx context.Context) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicKey", ctx)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublicKey indicates an expected call of PublicKey.
+func (mr *MockRemoteBackendMockRecorder) PublicKey(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicKey", reflect.TypeOf((*MockRemoteBackend)(nil).PublicKey), ctx)
+}
+
+// SignHash mocks base method.
+func (m *MockRemoteBackend) SignHash(ctx context.Context, hash []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignHash", ctx, hash)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignHash indicates an expected call of SignHash.
+func (mr *MockRemoteBackendMockRecorder) SignHash(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignHash", reflect.TypeOf((*MockRemoteBackend)(nil).SignHash), ctx, hash)
+}
@@ -0,0 +1,351 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/1inch/p2p-network/contracts"
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	defaultCachePollInterval = 2 * time.Second
+	// defaultRotationLookback covers key rotation grace period on chains with block time of 0.5s and more
+	defaultRotationLookback = 7200
+	// maxLogRange is max count of blocks in one logs query, larger ranges are split or reloaded
+	maxLogRange = 2000
+)
+
+var (
+	// ErrResolverNotFound error represents public key which is not registered.
+	ErrResolverNotFound = errors.New("resolver not found")
+	// ErrCacheNotLoaded error represents lookup before registry state is loaded.
+	ErrCacheNotLoaded = errors.New("registry cache is not loaded")
+)
+
+// CacheConfig represents registry cache config.
+type CacheConfig struct {
+	// PollInterval is interval between checks of new blocks
+	PollInterval time.Duration
+	// RotationLookback is count of blocks searched for key rotations which are still in grace period
+	// when state is loaded, 7200 by default
+	RotationLookback uint64
+}
+
+// rotatedKey is resolver key which is still resolved to the node of the new key after key rotation.
+type rotatedKey struct {
+	newPublicKey string
+	validUntil   time.Time
+}
+
+// Cache keeps registry state in memory and reloads it when contract emits events.
+type Cache struct {
+	client           *Client
+	logger           *slog.Logger
+	pollInterval     time.Duration
+	rotationLookback uint64
+
+	mu           sync.RWMutex
+	loaded       bool
+	block        uint64
+	blockHash    common.Hash
+	resolverKeys [][]byte
+	// resolvers are keyed by public key
+	resolvers map[string]string
+	// stakes are active resolver stakes keyed by public key
+	stakes map[string]*big.Int
+	// capabilities are keyed by public key
+	capabilities map[string]contracts.NodeRegistryCapabilities
+	// rotated are keyed by old public key
+	rotated  map[string]rotatedKey
+	relayers []contracts.NodeRegistryRelayer
+	// primaryRelayer is address of the primary relayer, empty if it isn't set
+	primaryRelayer string
+}
+
+// NewCache creates registry cache, Load or Run must be called before lookups.
+func NewCache(client *Client, logger *slog.Logger, cfg CacheConfig) *Cache {
+	pollInterval := cfg.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultCachePollInterval
+	}
+
+	rotationLookback := cfg.RotationLookback
+	if rotationLookback == 0 {
+		rotationLookback = defaultRotationLookback
+	}
+
+	return &Cache{
+		client:           client,
+		logger:           logger.WithGroup("registry-cache"),
+		pollInterval:     pollInterval,
+		rotationLookback: rotationLookback,
+		resolvers:        make(map[string]string),
+		stakes:           make(map[string]*big.Int),
+		capabilities:     make(map[string]contracts.NodeRegistryCapabilities),
+		rotated:          make(map[string]rotatedKey),
+	}
+}
+
+// Load loads full registry state at the latest block.
+func (c *Cache) Load(ctx context.Context) error {
+	header, err := c.client.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	return c.load(ctx, header.Number.Uint64(), header.Hash())
+}
+
+// Run keeps registry state current until context is cancelled.
+func (c *Cache) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.sync(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Warn("failed to sync registry state", slog.Any("err", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetResolver returns resolver address associated with the given public key.
+func (c *Cache) GetResolver(publicKey []byte) (string, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.loaded {
+		return "", ErrCacheNotLoaded
+	}
+
+	publicKey, ok := c.activeKey(publicKey)
+	if !ok {
+		return "", ErrResolverNotFound
+	}
+
+	return c.resolvers[string(publicKey)], nil
+}
+
+// GetResolverCapabilities returns capabilities of resolver associated with the given public key.
+func (c *Cache) GetResolverCapabilities(publicKey []byte) (contracts.NodeRegistryCapabilities, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.loaded {
+		return contracts.NodeRegistryCapabilities{}, ErrCacheNotLoaded
+	}
+
+	publicKey, ok := c.activeKey(publicKey)
+	if !ok {
+		return contracts.NodeRegistryCapabilities{}, ErrResolverNotFound
+	}
+
+	return c.capabilities[string(publicKey)], nil
+}
+
+// activeKey returns registered public key, rotated key is replaced by the new key during grace period, mu must be held.
+func (c *Cache) activeKey(publicKey []byte) ([]byte, bool) {
+	if _, ok := c.resolvers[string(publicKey)]; ok {
+		return publicKey, true
+	}
+
+	if rotated, ok := c.rotated[string(publicKey)]; ok && time.Now().Before(rotated.validUntil) {
+		if _, ok := c.resolvers[rotated.newPublicKey]; ok {
+			return []byte(rotated.newPublicKey), true
+		}
+	}
+
+	return nil, false
+}
+
+// GetResolvers returns public keys of all resolvers and their active stakes in wei in the same order.
+func (c *Cache) GetResolvers() ([][]byte, []*big.Int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.loaded {
+		return nil, nil, ErrCacheNotLoaded
+	}
+
+	stakes := make([]*big.Int, len(c.resolverKeys))
+	for i, publicKey := range c.resolverKeys {
+		stakes[i] = c.stakes[string(publicKey)]
+	}
+
+	return c.resolverKeys, stakes, nil
+}
+
+// GetRelayer returns address of the primary relayer and public keys of all resolvers.
+func (c *Cache) GetRelayer() (string, [][]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.loaded {
+		return "", nil, ErrCacheNotLoaded
+	}
+
+	return c.primaryRelayer, c.resolverKeys, nil
+}
+
+// GetRelayers returns all registered relayers with metadata.
+func (c *Cache) GetRelayers() ([]contracts.NodeRegistryRelayer, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.loaded {
+		return nil, ErrCacheNotLoaded
+	}
+
+	return c.relayers, nil
+}
+
+// sync checks new blocks for contract events and reloads state if registry is changed or chain is reorganized.
+func (c *Cache) sync(ctx context.Context) error {
+	c.mu.RLock()
+	loaded, block, blockHash := c.loaded, c.block, c.blockHash
+	c.mu.RUnlock()
+
+	if !loaded {
+		return c.Load(ctx)
+	}
+
+	latest, err := c.client.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	latestNumber := latest.Number.Uint64()
+	if latestNumber == block && latest.Hash() == blockHash {
+		return nil
+	}
+
+	synced, err := c.client.client.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+	if err != nil || synced.Hash() != blockHash || latestNumber < block {
+		c.logger.Info("chain reorganization detected, reload registry state", slog.Uint64("block", block))
+		return c.load(ctx, latestNumber, latest.Hash())
+	}
+
+	if latestNumber-block > maxLogRange {
+		c.logger.Info("registry state is behind, reload registry state", slog.Uint64("block", block))
+		return c.load(ctx, latestNumber, latest.Hash())
+	}
+
+	logs, err := c.client.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(block + 1),
+		ToBlock:   latest.Number,
+		Addresses: []common.Address{c.client.address},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to filter registry events: %w", err)
+	}
+
+	if len(logs) == 0 {
+		c.mu.Lock()
+		c.block, c.blockHash = latestNumber, latest.Hash()
+		c.mu.Unlock()
+		return nil
+	}
+
+	c.logger.Debug("registry events received, reload registry state", slog.Int("events", len(logs)))
+	return c.load(ctx, latestNumber, latest.Hash())
+}
+
+// load reads full registry state at the given block, rotated keys are read from key rotation events of rotation lookback.
+func (c *Cache) load(ctx context.Context, block uint64, blockHash common.Hash) error {
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(block)}
+
+	resolverInfos, err := c.client.getResolvers(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get resolvers: %w", err)
+	}
+
+	resolverKeys := make([][]byte, len(resolverInfos))
+	resolvers := make(map[string]string, len(resolverInfos))
+	stakes := make(map[string]*big.Int, len(resolverInfos))
+	capabilities := make(map[string]contracts.NodeRegistryCapabilities, len(resolverInfos))
+	for i, resolver := range resolverInfos {
+		resolverKeys[i] = resolver.PublicKey
+		resolvers[string(resolver.PublicKey)] = resolver.Ip
+		stakes[string(resolver.PublicKey)] = resolver.Stake
+		capabilities[string(resolver.PublicKey)] = resolver.Capabilities
+	}
+
+	relayers, err := c.client.Registry.GetRelayers(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get relayers: %w", err)
+	}
+
+	primaryRelayer, _, err := c.client.getRelayer(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get primary relayer: %w", err)
+	}
+
+	rotated, err := c.loadRotated(ctx, block)
+	if err != nil {
+		return fmt.Errorf("failed to get key rotations: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loaded = true
+	c.block, c.blockHash = block, blockHash
+	c.resolverKeys = resolverKeys
+	c.resolvers = resolvers
+	c.stakes = stakes
+	c.capabilities = capabilities
+	c.relayers = relayers
+	c.primaryRelayer = primaryRelayer
+	c.rotated = rotated
+
+	return nil
+}
+
+// loadRotated reads key rotations of rotation lookback up to the given block which are still in grace period,
+// logs are queried by ranges of maxLogRange blocks.
+func (c *Cache) loadRotated(ctx context.Context, block uint64) (map[string]rotatedKey, error) {
+	var from uint64
+	if block > c.rotationLookback {
+		from = block - c.rotationLookback + 1
+	}
+
+	now := time.Now()
+	rotated := make(map[string]rotatedKey)
+	for start := from; start <= block; start += maxLogRange {
+		end := min(start+maxLogRange-1, block)
+		events, err := c.client.Registry.FilterResolverKeyRotated(&bind.FilterOpts{Start: start, End: &end, Context: ctx})
+		if err != nil {
+			return nil, err
+		}
+
+		for events.Next() {
+			validUntil := time.Unix(events.Event.ValidUntil.Int64(), 0)
+			if now.Before(validUntil) {
+				rotated[string(events.Event.OldPublicKey)] = rotatedKey{
+					newPublicKey: string(events.Event.NewPublicKey),
+					validUntil:   validUntil,
+				}
+			}
+		}
+		err = events.Error()
+		events.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rotated, nil
+}
@@ -0,0 +1,96 @@
+//go:build deploy
+// +build deploy
+
+package registry
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rpcURL        = "http://127.0.0.1:8545"
+	privateKeyHex = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	_, client, err := DeployNodeRegistry(ctx, &Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	oldKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	oldPublicKey := crypto.CompressPubkey(&oldKey.PublicKey)
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newPublicKey := crypto.CompressPubkey(&newKey.PublicKey)
+
+	require.NoError(t, client.RegisterResolver(ctx, "127.0.0.1:8001", oldPublicKey))
+
+	cache := NewCache(client, slog.New(slog.NewTextHandler(os.Stdout, nil)), CacheConfig{})
+	_, err = cache.GetResolver(oldPublicKey)
+	require.ErrorIs(t, err, ErrCacheNotLoaded)
+
+	require.NoError(t, cache.Load(ctx))
+	ip, err := cache.GetResolver(oldPublicKey)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8001", ip)
+
+	// events
+	require.NoError(t, client.RegisterRelayer(ctx, "127.0.0.1:8080", RelayerMetadata{Region: "eu"}))
+	require.NoError(t, client.SetPrimaryRelayer(ctx, client.Auth.From))
+	require.NoError(t, client.RotateResolverKey(ctx, oldPublicKey, newPublicKey))
+	require.NoError(t, cache.sync(ctx))
+
+	for _, publicKey := range [][]byte{oldPublicKey, newPublicKey} {
+		ip, err = cache.GetResolver(publicKey)
+		require.NoError(t, err, "rotated key must be resolved during grace period")
+		require.Equal(t, "127.0.0.1:8001", ip)
+	}
+
+	relayers, err := cache.GetRelayers()
+	require.NoError(t, err)
+	require.Len(t, relayers, 1)
+	require.Equal(t, "eu", relayers[0].Region)
+
+	ip, publicKeys, err := cache.GetRelayer()
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8080", ip)
+	require.Equal(t, [][]byte{newPublicKey}, publicKeys)
+
+	// restart
+	restarted := NewCache(client, slog.New(slog.NewTextHandler(os.Stdout, nil)), CacheConfig{})
+	require.NoError(t, restarted.Load(ctx))
+	ip, err = restarted.GetResolver(oldPublicKey)
+	require.NoError(t, err, "rotated key must be resolved after restart during grace period")
+	require.Equal(t, "127.0.0.1:8001", ip)
+
+	restarted.mu.Lock()
+	restarted.blockHash = common.Hash{}
+	restarted.mu.Unlock()
+	require.NoError(t, restarted.sync(ctx))
+	_, err = restarted.GetResolver(oldPublicKey)
+	require.NoError(t, err, "rotated key must be resolved after full reload")
+
+	// reorganization
+	require.NoError(t, client.DeregisterResolver(ctx, newPublicKey))
+	cache.mu.Lock()
+	cache.blockHash = common.Hash{}
+	cache.mu.Unlock()
+	require.NoError(t, cache.sync(ctx))
+
+	_, err = cache.GetResolver(newPublicKey)
+	require.ErrorIs(t, err, ErrResolverNotFound)
+	_, err = cache.GetResolver(oldPublicKey)
+	require.ErrorIs(t, err, ErrResolverNotFound, "rotated key must not be resolved after new key is removed")
+}
@@ -4,28 +4,58 @@ package registry
 import (
 	"context"
 	"errors"
+	"math/big"
+	"sync"
 	"time"
 
 	"github.com/1inch/p2p-network/contracts"
-	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/1inch/p2p-network/internal/signer"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
+// resolverPageSize is count of resolvers fetched by one contract call.
+const resolverPageSize = 100
+
 var (
-	// ErrContextCancelled error represents context cancellation.
+	// ErrContextCancelled error represents context cancellation, it wraps context error.
 	ErrContextCancelled = errors.New("context cancelled")
-	// ErrTransactionFailed error represents transaction failure.
+	// ErrTransactionFailed error represents transaction failure, it's wrapped with revert reason when it's known.
 	ErrTransactionFailed = errors.New("transaction failed")
+	// ErrReadOnlyClient error represents write attempt with client which has no key.
+	ErrReadOnlyClient = errors.New("registry client has no key for sending transactions")
 )
 
 // Config represents registry client config.
 type Config struct {
-	DialURI         string
-	PrivateKey      string
+	DialURI string
+	// PrivateKey is used for sending transactions when Signer is not set
+	PrivateKey string
+	// Signer is used for sending transactions, client is read-only when neither Signer nor PrivateKey is set
+	Signer          signer.Signer
 	ContractAddress string
+	// Tx is fee, gas and replacement policy of transactions
+	Tx TxConfig
+}
+
+// RelayerMetadata describes relayer node in the registry.
+type RelayerMetadata struct {
+	// Region is hint for clients which relayer is closer
+	Region string
+	// Capacity is max count of client connections
+	Capacity uint32
+	// PublicKey is compressed public key of relayer
+	PublicKey []byte
+}
+
+// ResolverOptions describes optional parameters of resolver registration.
+type ResolverOptions struct {
+	// Stake is sent on registration in wei, nil means no stake
+	Stake *big.Int
+	// Capabilities describe requests served by resolver, zero value means capabilities are not published
+	Capabilities contracts.NodeRegistryCapabilities
 }
 
 // Client represents storage client.
@@ -33,7 +63,12 @@ type Client struct {
 	Registry *contracts.NodeRegistry
 	Auth     *bind.TransactOpts
 	client   *ethclient.Client
-	ticker   *time.Ticker
+	address  common.Address
+
+	txConfig TxConfig
+	nonceMu  sync.Mutex
+	// pendingNonce is the next nonce after the last sent transaction, nil if nonce is synced with node
+	pendingNonce *uint64
 }
 
 // Dial creates eth client, new smart-contract instance, auth.
@@ -43,22 +78,13 @@ func Dial(ctx context.Context, config *Config) (*Client, error) {
 		return &Client{}, err
 	}
 
-	privateKey, err := crypto.HexToECDSA(config.PrivateKey)
-	if err != nil {
-		return &Client{}, err
-	}
-
-	chainID, err := client.ChainID(ctx)
-	if err != nil {
-		return &Client{}, err
-	}
-
-	registry, err := contracts.NewNodeRegistry(common.HexToAddress(config.ContractAddress), client)
+	auth, err := newTransactor(ctx, client, config)
 	if err != nil {
 		return &Client{}, err
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	address := common.HexToAddress(config.ContractAddress)
+	registry, err := contracts.NewNodeRegistry(address, client)
 	if err != nil {
 		return &Client{}, err
 	}
@@ -67,7 +93,8 @@ func Dial(ctx context.Context, config *Config) (*Client, error) {
 		Registry: registry,
 		Auth:     auth,
 		client:   client,
-		ticker:   time.NewTicker(200 * time.Millisecond),
+		address:  address,
+		txConfig: config.Tx.withDefaults(),
 	}, nil
 }
 
@@ -78,39 +105,66 @@ func DeployNodeRegistry(ctx context.Context, config *Config) (common.Address, *C
 		return common.Address{}, &Client{}, err
 	}
 
-	privateKey, err := crypto.HexToECDSA(config.PrivateKey)
+	auth, err := newTransactor(ctx, ethClient, config)
 	if err != nil {
 		return common.Address{}, &Client{}, err
 	}
 
-	chainID, err := ethClient.ChainID(ctx)
-	if err != nil {
-		return common.Address{}, &Client{}, err
+	if auth == nil {
+		return common.Address{}, &Client{}, ErrReadOnlyClient
 	}
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
-	if err != nil {
-		return common.Address{}, &Client{}, err
+	client := &Client{
+		Auth:     auth,
+		client:   ethClient,
+		txConfig: config.Tx.withDefaults(),
 	}
 
-	address, tx, registry, err := contracts.DeployNodeRegistry(auth, ethClient)
+	// replacement of deployment transaction has the same nonce, so contract address is the same
+	_, err = client.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		address, tx, registry, err := contracts.DeployNodeRegistry(opts, ethClient)
+		if err == nil {
+			client.address, client.Registry = address, registry
+		}
+		return tx, err
+	})
 	if err != nil {
 		return common.Address{}, &Client{}, err
 	}
 
-	client := &Client{
-		Registry: registry,
-		Auth:     auth,
-		client:   ethClient,
-		ticker:   time.NewTicker(200 * time.Millisecond),
+	return client.address, client, nil
+}
+
+// newTransactor returns nil auth when config has no key.
+func newTransactor(ctx context.Context, client *ethclient.Client, config *Config) (*bind.TransactOpts, error) {
+	txSigner := config.Signer
+	if txSigner == nil {
+		if config.PrivateKey == "" {
+			return nil, nil
+		}
+
+		var err error
+		txSigner, err = signer.NewFromHex(config.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return address, client, client.WaitForTx(ctx, tx.Hash())
+	return signer.NewTransactor(txSigner, chainID)
 }
 
-// GetRelayer retrieves the current relayer address from the registry.
+// GetRelayer retrieves the address of the primary relayer and public keys of all resolvers from the registry.
 func (c *Client) GetRelayer() (string, [][]byte, error) {
-	resp, err := c.Registry.GetRelayer(&bind.CallOpts{})
+	return c.getRelayer(&bind.CallOpts{})
+}
+
+func (c *Client) getRelayer(opts *bind.CallOpts) (string, [][]byte, error) {
+	resp, err := c.Registry.GetRelayer(opts)
 	if err != nil {
 		return "", nil, err
 	}
@@ -118,29 +172,171 @@ func (c *Client) GetRelayer() (string, [][]byte, error) {
 	return resp.Ip, resp.PublicKeys, nil
 }
 
-// GetResolver fetches the resolver address associated with the given public key.
-func (c *Client) GetResolver(publicKey []byte) (string, error) {
-	return c.Registry.GetResolver(&bind.CallOpts{}, publicKey)
+// GetRelayers retrieves all registered relayers with metadata.
+func (c *Client) GetRelayers() ([]contracts.NodeRegistryRelayer, error) {
+	return c.Registry.GetRelayers(&bind.CallOpts{})
 }
 
-// RegisterRelayer registers a new relayer with the specified IP address.
-func (c *Client) RegisterRelayer(ctx context.Context, ipAddress string) error {
-	tx, err := c.Registry.RegisterRelayer(c.Auth, ipAddress)
+// GetResolverCount fetches the count of registered resolvers.
+func (c *Client) GetResolverCount() (uint64, error) {
+	count, err := c.Registry.GetResolverCount(&bind.CallOpts{})
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return c.WaitForTx(ctx, tx.Hash())
+	return count.Uint64(), nil
 }
 
-// RegisterResolver registers a new resolver with the given IP address and public key.
-func (c *Client) RegisterResolver(ctx context.Context, ipAddress string, publicKey []byte) error {
-	tx, err := c.Registry.RegisterResolver(c.Auth, ipAddress, publicKey)
+// GetResolvers fetches all registered resolvers with metadata page by page at the latest block.
+func (c *Client) GetResolvers(ctx context.Context) ([]contracts.NodeRegistryResolverInfo, error) {
+	block, err := c.client.BlockNumber(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return c.getResolvers(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(block)})
+}
+
+// getResolvers fetches all registered resolvers page by page, opts should be pinned to a block so pages are consistent.
+func (c *Client) getResolvers(opts *bind.CallOpts) ([]contracts.NodeRegistryResolverInfo, error) {
+	var resolvers []contracts.NodeRegistryResolverInfo
+	for offset := int64(0); ; offset += resolverPageSize {
+		page, err := c.Registry.GetResolvers(opts, big.NewInt(offset), big.NewInt(resolverPageSize))
+		if err != nil {
+			return nil, err
+		}
+
+		resolvers = append(resolvers, page...)
+		if len(page) < resolverPageSize {
+			return resolvers, nil
+		}
 	}
+}
+
+// GetResolver fetches the resolver address associated with the given public key.
+func (c *Client) GetResolver(publicKey []byte) (string, error) {
+	return c.Registry.GetResolver(&bind.CallOpts{}, publicKey)
+}
+
+// RegisterRelayer registers or updates relayer of the client account with the specified IP address.
+func (c *Client) RegisterRelayer(ctx context.Context, ipAddress string, metadata RelayerMetadata) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.RegisterRelayer(opts, ipAddress, metadata.Region, metadata.Capacity, metadata.PublicKey)
+	})
+	return err
+}
+
+// RegisterResolver registers a new resolver with the given IP address and public key without stake and capabilities.
+func (c *Client) RegisterResolver(ctx context.Context, ipAddress string, publicKey []byte) error {
+	return c.RegisterResolverWithOptions(ctx, ipAddress, publicKey, ResolverOptions{})
+}
+
+// RegisterResolverWithOptions registers a new resolver with stake and capabilities.
+func (c *Client) RegisterResolverWithOptions(ctx context.Context, ipAddress string, publicKey []byte, options ResolverOptions) error {
+	_, err := c.Transact(ctx, options.Stake, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.RegisterResolver(opts, ipAddress, publicKey, options.Capabilities)
+	})
+	return err
+}
+
+// GetResolverCapabilities fetches capabilities of the resolver with the given public key.
+func (c *Client) GetResolverCapabilities(publicKey []byte) (contracts.NodeRegistryCapabilities, error) {
+	return c.Registry.GetResolverCapabilities(&bind.CallOpts{}, publicKey)
+}
+
+// SetResolverCapabilities changes capabilities of the resolver, must be called by the account which registered it.
+func (c *Client) SetResolverCapabilities(ctx context.Context, publicKey []byte, capabilities contracts.NodeRegistryCapabilities) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.SetResolverCapabilities(opts, publicKey, capabilities)
+	})
+	return err
+}
 
-	return c.WaitForTx(ctx, tx.Hash())
+// DeregisterRelayer removes the relayer registered by the client account.
+func (c *Client) DeregisterRelayer(ctx context.Context) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.DeregisterRelayer(opts, c.Auth.From)
+	})
+	return err
+}
+
+// RemoveRelayer removes the relayer registered by the given account, must be called by this account or the contract owner.
+func (c *Client) RemoveRelayer(ctx context.Context, relayerOwner common.Address) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.DeregisterRelayer(opts, relayerOwner)
+	})
+	return err
+}
+
+// SetRelayerApproval allows or forbids the account to register relayer, must be called by the contract owner.
+// Relayer of forbidden account is removed.
+func (c *Client) SetRelayerApproval(ctx context.Context, relayerOwner common.Address, approved bool) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.SetRelayerApproval(opts, relayerOwner, approved)
+	})
+	return err
+}
+
+// SetPrimaryRelayer chooses the relayer returned by GetRelayer, must be called by the contract owner.
+// Zero address unsets primary relayer.
+func (c *Client) SetPrimaryRelayer(ctx context.Context, relayerOwner common.Address) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.SetPrimaryRelayer(opts, relayerOwner)
+	})
+	return err
+}
+
+// GetResolverOwner fetches the account which registered the resolver with the given public key.
+func (c *Client) GetResolverOwner(publicKey []byte) (common.Address, error) {
+	return c.Registry.GetResolverOwner(&bind.CallOpts{}, publicKey)
+}
+
+// UpdateResolver changes IP address of the resolver, must be called by the account which registered it.
+func (c *Client) UpdateResolver(ctx context.Context, publicKey []byte, ipAddress string) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.UpdateResolver(opts, publicKey, ipAddress)
+	})
+	return err
+}
+
+// UpdateResolverWithCapabilities changes IP address and capabilities of the resolver in one transaction.
+func (c *Client) UpdateResolverWithCapabilities(ctx context.Context, publicKey []byte, ipAddress string, capabilities contracts.NodeRegistryCapabilities) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.UpdateResolverWithCapabilities(opts, publicKey, ipAddress, capabilities)
+	})
+	return err
+}
+
+// DeregisterResolver removes the resolver, must be called by the account which registered it.
+func (c *Client) DeregisterResolver(ctx context.Context, publicKey []byte) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.DeregisterResolver(opts, publicKey)
+	})
+	return err
+}
+
+// RotateResolverKey replaces public key of registered resolver, old key is still resolved during contract grace period.
+func (c *Client) RotateResolverKey(ctx context.Context, oldPublicKey, newPublicKey []byte) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.RotateResolverKey(opts, oldPublicKey, newPublicKey)
+	})
+	return err
+}
+
+// PruneResolverKey removes rotated key after its grace period, can be called by any account.
+func (c *Client) PruneResolverKey(ctx context.Context, publicKey []byte) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.PruneResolverKey(opts, publicKey)
+	})
+	return err
+}
+
+// SetKeyRotationGracePeriod changes grace period of keys rotated later, must be called by the contract owner.
+func (c *Client) SetKeyRotationGracePeriod(ctx context.Context, period time.Duration) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.SetKeyRotationGracePeriod(opts, big.NewInt(int64(period.Seconds())))
+	})
+	return err
 }
 
 // Close closes ethereum client.
@@ -148,27 +344,9 @@ func (c *Client) Close() {
 	c.client.Close()
 }
 
-// WaitForTx block execution until transaction receipt is received or context is cancelled.
+// WaitForTx blocks until transaction is mined and confirmed by configured count of blocks or context is cancelled.
+// Receipts dropped by reorg are waited again, failed transaction is returned as ErrTransactionFailed with revert reason.
 func (c *Client) WaitForTx(ctx context.Context, hash common.Hash) error {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ErrContextCancelled
-		case <-c.ticker.C:
-			receipt, err := c.client.TransactionReceipt(ctx, hash)
-			if err == nil {
-				if receipt.Status == 1 {
-					return nil
-				}
-
-				return ErrTransactionFailed
-			}
-			if !errors.Is(err, ethereum.NotFound) {
-				return err
-			}
-		}
-	}
+	_, err := c.waitForAny(ctx, []common.Hash{hash}, nil)
+	return err
 }
@@ -0,0 +1,404 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1inch/p2p-network/contracts"
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultFilePollInterval = 5 * time.Second
+	// signatureFileSuffix is appended to node list path to get signature path
+	signatureFileSuffix = ".sig"
+)
+
+var (
+	// ErrInvalidNodeList error represents node list which can't be parsed or has invalid node.
+	ErrInvalidNodeList = errors.New("invalid node list")
+	// ErrInvalidNodeListSignature error represents node list which is not signed by configured signer.
+	ErrInvalidNodeListSignature = errors.New("invalid node list signature")
+)
+
+// FileConfig represents node list file config.
+type FileConfig struct {
+	// Path to YAML or JSON node list
+	Path string `yaml:"path"`
+	// Signer is address of account which signs node list, signature is read from Path + ".sig".
+	// Node list is not verified if empty
+	Signer string `yaml:"signer"`
+	// PollInterval is interval between checks of node list changes
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+// NodeList represents content of node list file.
+type NodeList struct {
+	Relayers  []RelayerEntry  `yaml:"relayers"`
+	Resolvers []ResolverEntry `yaml:"resolvers"`
+}
+
+// RelayerEntry represents relayer in node list.
+type RelayerEntry struct {
+	IP       string `yaml:"ip"`
+	Region   string `yaml:"region"`
+	Capacity uint32 `yaml:"capacity"`
+	// PublicKey is compressed public key in hex
+	PublicKey string `yaml:"public_key"`
+}
+
+// ResolverEntry represents resolver in node list.
+type ResolverEntry struct {
+	IP string `yaml:"ip"`
+	// PublicKey is compressed public key in hex
+	PublicKey string `yaml:"public_key"`
+	// Stake is decimal amount in wei
+	Stake        string            `yaml:"stake"`
+	Capabilities CapabilitiesEntry `yaml:"capabilities"`
+}
+
+// CapabilitiesEntry represents resolver capabilities in node list.
+type CapabilitiesEntry struct {
+	Methods         []string `yaml:"methods"`
+	ChainIds        []uint64 `yaml:"chain_ids"`
+	ProtocolVersion uint32   `yaml:"protocol_version"`
+	Encrypted       bool     `yaml:"encrypted"`
+}
+
+// fileState is registry state parsed from node list file.
+type fileState struct {
+	resolverKeys [][]byte
+	// resolvers, stakes and capabilities are keyed by public key
+	resolvers    map[string]string
+	stakes       map[string]*big.Int
+	capabilities map[string]contracts.NodeRegistryCapabilities
+	relayers     []contracts.NodeRegistryRelayer
+}
+
+// FileRegistry keeps registry state loaded from node list file and reloads it when file is changed.
+type FileRegistry struct {
+	logger       *slog.Logger
+	path         string
+	signer       common.Address
+	verify       bool
+	pollInterval time.Duration
+
+	mu      sync.RWMutex
+	state   fileState
+	modTime time.Time
+}
+
+// NewFileRegistry loads node list file, error is returned if file is invalid.
+func NewFileRegistry(logger *slog.Logger, cfg FileConfig) (*FileRegistry, error) {
+	pollInterval := cfg.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultFilePollInterval
+	}
+
+	registry := &FileRegistry{
+		logger:       logger.WithGroup("file-registry"),
+		path:         cfg.Path,
+		pollInterval: pollInterval,
+	}
+
+	if cfg.Signer != "" {
+		if !common.IsHexAddress(cfg.Signer) {
+			return nil, fmt.Errorf("%w: signer is not an address: %s", ErrInvalidNodeList, cfg.Signer)
+		}
+		registry.signer, registry.verify = common.HexToAddress(cfg.Signer), true
+	}
+
+	if err := registry.reload(); err != nil {
+		return nil, err
+	}
+
+	return registry, nil
+}
+
+// Run reloads node list when file is changed until context is cancelled, invalid node list is ignored.
+func (r *FileRegistry) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		modTime, err := r.lastModified()
+		if err != nil {
+			r.logger.Warn("failed to check node list", slog.Any("err", err))
+			continue
+		}
+
+		r.mu.RLock()
+		changed := !modTime.Equal(r.modTime)
+		r.mu.RUnlock()
+		if !changed {
+			continue
+		}
+
+		if err := r.reload(); err != nil {
+			r.logger.Warn("failed to reload node list, previous node list is used", slog.Any("err", err))
+			continue
+		}
+		r.logger.Info("node list reloaded", slog.String("path", r.path))
+	}
+}
+
+// GetResolver returns resolver address associated with the given public key.
+func (r *FileRegistry) GetResolver(publicKey []byte) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ip, ok := r.state.resolvers[string(publicKey)]
+	if !ok {
+		return "", ErrResolverNotFound
+	}
+
+	return ip, nil
+}
+
+// GetResolverCapabilities returns capabilities of resolver associated with the given public key.
+func (r *FileRegistry) GetResolverCapabilities(publicKey []byte) (contracts.NodeRegistryCapabilities, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	capabilities, ok := r.state.capabilities[string(publicKey)]
+	if !ok {
+		return contracts.NodeRegistryCapabilities{}, ErrResolverNotFound
+	}
+
+	return capabilities, nil
+}
+
+// GetResolvers returns public keys of all resolvers and their stakes in wei in the same order.
+func (r *FileRegistry) GetResolvers() ([][]byte, []*big.Int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stakes := make([]*big.Int, len(r.state.resolverKeys))
+	for i, publicKey := range r.state.resolverKeys {
+		stakes[i] = r.state.stakes[string(publicKey)]
+	}
+
+	return r.state.resolverKeys, stakes, nil
+}
+
+// GetRelayer returns address of the first relayer of the list, it is the primary relayer, and public keys of all resolvers.
+func (r *FileRegistry) GetRelayer() (string, [][]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var ip string
+	if len(r.state.relayers) > 0 {
+		ip = r.state.relayers[0].Ip
+	}
+
+	return ip, r.state.resolverKeys, nil
+}
+
+// GetRelayers returns all relayers with metadata.
+func (r *FileRegistry) GetRelayers() ([]contracts.NodeRegistryRelayer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.state.relayers, nil
+}
+
+// reload reads, verifies and parses node list file, state is not changed on error.
+func (r *FileRegistry) reload() error {
+	// modification time is read before content, so changes during read are reloaded on the next check
+	modTime, err := r.lastModified()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read node list: %w", err)
+	}
+
+	if r.verify {
+		if err := r.verifySignature(data); err != nil {
+			return err
+		}
+	}
+
+	var nodeList NodeList
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&nodeList); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidNodeList, err)
+	}
+
+	state, err := nodeList.state()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.state, r.modTime = state, modTime
+	return nil
+}
+
+// lastModified returns the latest modification time of node list and its signature.
+func (r *FileRegistry) lastModified() (time.Time, error) {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to stat node list: %w", err)
+	}
+	modTime := info.ModTime()
+
+	if r.verify {
+		info, err := os.Stat(r.path + signatureFileSuffix)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to stat node list signature: %w", err)
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+
+	return modTime, nil
+}
+
+func (r *FileRegistry) verifySignature(data []byte) error {
+	signatureHex, err := os.ReadFile(r.path + signatureFileSuffix)
+	if err != nil {
+		return fmt.Errorf("failed to read node list signature: %w", err)
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(signatureHex)), "0x"))
+	if err != nil || len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidNodeListSignature)
+	}
+
+	// accept both 0/1 and 27/28 recovery ids
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(crypto.Keccak256(data), signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidNodeListSignature, err)
+	}
+
+	if crypto.PubkeyToAddress(*publicKey) != r.signer {
+		return fmt.Errorf("%w: signed by %s", ErrInvalidNodeListSignature, crypto.PubkeyToAddress(*publicKey))
+	}
+
+	return nil
+}
+
+// SignNodeList signs node list file content, signature is returned in hex for the signature file.
+func SignNodeList(nodeSigner signer.Signer, data []byte) (string, error) {
+	signature, err := nodeSigner.SignHash(crypto.Keccak256(data))
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(signature), nil
+}
+
+// SignNodeListFile signs node list file and writes signature file next to it.
+func SignNodeListFile(nodeSigner signer.Signer, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read node list: %w", err)
+	}
+
+	signature, err := SignNodeList(nodeSigner, data)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path+signatureFileSuffix, []byte(signature+"\n"), 0o644)
+}
+
+func (l NodeList) state() (fileState, error) {
+	state := fileState{
+		resolverKeys: make([][]byte, 0, len(l.Resolvers)),
+		resolvers:    make(map[string]string, len(l.Resolvers)),
+		stakes:       make(map[string]*big.Int, len(l.Resolvers)),
+		capabilities: make(map[string]contracts.NodeRegistryCapabilities, len(l.Resolvers)),
+		relayers:     make([]contracts.NodeRegistryRelayer, 0, len(l.Relayers)),
+	}
+
+	for i, resolver := range l.Resolvers {
+		if resolver.IP == "" {
+			return fileState{}, fmt.Errorf("%w: resolver %d: empty ip", ErrInvalidNodeList, i)
+		}
+
+		publicKey, err := decodePublicKey(resolver.PublicKey)
+		if err != nil || len(publicKey) == 0 {
+			return fileState{}, fmt.Errorf("%w: resolver %d: invalid public key", ErrInvalidNodeList, i)
+		}
+
+		if _, ok := state.resolvers[string(publicKey)]; ok {
+			return fileState{}, fmt.Errorf("%w: resolver %d: duplicate public key", ErrInvalidNodeList, i)
+		}
+
+		stake := new(big.Int)
+		if resolver.Stake != "" {
+			if _, ok := stake.SetString(resolver.Stake, 10); !ok || stake.Sign() < 0 {
+				return fileState{}, fmt.Errorf("%w: resolver %d: invalid stake", ErrInvalidNodeList, i)
+			}
+		}
+
+		chainIds := make([]*big.Int, len(resolver.Capabilities.ChainIds))
+		for j, chainId := range resolver.Capabilities.ChainIds {
+			chainIds[j] = new(big.Int).SetUint64(chainId)
+		}
+
+		state.resolverKeys = append(state.resolverKeys, publicKey)
+		state.resolvers[string(publicKey)] = resolver.IP
+		state.stakes[string(publicKey)] = stake
+		state.capabilities[string(publicKey)] = contracts.NodeRegistryCapabilities{
+			Methods:         resolver.Capabilities.Methods,
+			ChainIds:        chainIds,
+			ProtocolVersion: resolver.Capabilities.ProtocolVersion,
+			Encrypted:       resolver.Capabilities.Encrypted,
+		}
+	}
+
+	for i, relayer := range l.Relayers {
+		if relayer.IP == "" {
+			return fileState{}, fmt.Errorf("%w: relayer %d: empty ip", ErrInvalidNodeList, i)
+		}
+
+		publicKey, err := decodePublicKey(relayer.PublicKey)
+		if err != nil {
+			return fileState{}, fmt.Errorf("%w: relayer %d: invalid public key", ErrInvalidNodeList, i)
+		}
+
+		state.relayers = append(state.relayers, contracts.NodeRegistryRelayer{
+			Ip:        relayer.IP,
+			Region:    relayer.Region,
+			Capacity:  relayer.Capacity,
+			PublicKey: publicKey,
+		})
+	}
+
+	return state, nil
+}
+
+func decodePublicKey(publicKeyHex string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(publicKeyHex, "0x"))
+}
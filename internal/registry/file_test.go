@@ -0,0 +1,176 @@
+package registry
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/stretchr/testify/require"
+)
+
+const testNodeList = `
+relayers:
+  - ip: 127.0.0.1:8080
+    region: eu-west
+    capacity: 100
+resolvers:
+  - ip: 127.0.0.1:8001
+    public_key: "0x0102"
+    stake: "1000"
+    capabilities:
+      methods: [GetWalletBalance]
+      chain_ids: [1, 137]
+      protocol_version: 1
+      encrypted: true
+`
+
+func writeNodeList(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestFileRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nodes.yaml")
+	writeNodeList(t, path, testNodeList)
+
+	fileRegistry, err := NewFileRegistry(slog.New(slog.NewTextHandler(os.Stdout, nil)), FileConfig{Path: path})
+	require.NoError(t, err)
+
+	ip, err := fileRegistry.GetResolver([]byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8001", ip)
+
+	_, err = fileRegistry.GetResolver([]byte{3})
+	require.ErrorIs(t, err, ErrResolverNotFound)
+
+	capabilities, err := fileRegistry.GetResolverCapabilities([]byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"GetWalletBalance"}, capabilities.Methods)
+	require.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(137)}, capabilities.ChainIds)
+	require.Equal(t, uint32(1), capabilities.ProtocolVersion)
+	require.True(t, capabilities.Encrypted)
+
+	keys, stakes, err := fileRegistry.GetResolvers()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{1, 2}}, keys)
+	require.Equal(t, []*big.Int{big.NewInt(1000)}, stakes)
+
+	relayerIP, _, err := fileRegistry.GetRelayer()
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8080", relayerIP)
+
+	relayers, err := fileRegistry.GetRelayers()
+	require.NoError(t, err)
+	require.Len(t, relayers, 1)
+	require.Equal(t, "eu-west", relayers[0].Region)
+	require.Equal(t, uint32(100), relayers[0].Capacity)
+}
+
+func TestFileRegistryInvalidNodeList(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "unknown field", content: "resolvers:\n  - ip: 127.0.0.1:8001\n    public_key: \"01\"\n    weight: 1\n"},
+		{name: "empty ip", content: "resolvers:\n  - public_key: \"01\"\n"},
+		{name: "invalid public key", content: "resolvers:\n  - ip: 127.0.0.1:8001\n    public_key: xyz\n"},
+		{name: "duplicate public key", content: "resolvers:\n  - ip: 127.0.0.1:8001\n    public_key: \"01\"\n  - ip: 127.0.0.1:8002\n    public_key: \"01\"\n"},
+		{name: "invalid stake", content: "resolvers:\n  - ip: 127.0.0.1:8001\n    public_key: \"01\"\n    stake: \"-1\"\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "nodes.yaml")
+			writeNodeList(t, path, testCase.content)
+
+			_, err := NewFileRegistry(slog.New(slog.NewTextHandler(os.Stdout, nil)), FileConfig{Path: path})
+			require.ErrorIs(t, err, ErrInvalidNodeList)
+		})
+	}
+}
+
+func TestFileRegistrySignature(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nodes.json")
+	writeNodeList(t, path, `{"resolvers": [{"ip": "127.0.0.1:8001", "public_key": "0102"}]}`)
+
+	nodeSigner, err := signer.Generate()
+	require.NoError(t, err)
+	otherSigner, err := signer.Generate()
+	require.NoError(t, err)
+
+	cfg := FileConfig{Path: path, Signer: nodeSigner.Address().Hex()}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	_, err = NewFileRegistry(logger, cfg)
+	require.Error(t, err, "signature file is required")
+
+	require.NoError(t, SignNodeListFile(otherSigner, path))
+	_, err = NewFileRegistry(logger, cfg)
+	require.ErrorIs(t, err, ErrInvalidNodeListSignature)
+
+	require.NoError(t, SignNodeListFile(nodeSigner, path))
+	fileRegistry, err := NewFileRegistry(logger, cfg)
+	require.NoError(t, err)
+
+	keys, _, err := fileRegistry.GetResolvers()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{1, 2}}, keys)
+
+	writeNodeList(t, path, `{"resolvers": [{"ip": "127.0.0.1:8666", "public_key": "0102"}]}`)
+	_, err = NewFileRegistry(logger, cfg)
+	require.ErrorIs(t, err, ErrInvalidNodeListSignature, "node list is changed after signing")
+
+	signature, err := SignNodeList(nodeSigner, []byte("other"))
+	require.NoError(t, err)
+	_, err = hex.DecodeString(signature)
+	require.NoError(t, err)
+}
+
+func TestFileRegistryReload(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "nodes.yaml")
+	writeNodeList(t, path, testNodeList)
+
+	fileRegistry, err := NewFileRegistry(slog.New(slog.NewTextHandler(os.Stdout, nil)), FileConfig{
+		Path:         path,
+		PollInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- fileRegistry.Run(ctx)
+	}()
+
+	// invalid node list is ignored, previous node list is used
+	writeNodeList(t, path, "resolvers: [")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	time.Sleep(50 * time.Millisecond)
+
+	ip, err := fileRegistry.GetResolver([]byte{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8001", ip)
+
+	writeNodeList(t, path, "resolvers:\n  - ip: 127.0.0.1:8002\n    public_key: \"0102\"\n")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+
+	require.Eventually(t, func() bool {
+		ip, err := fileRegistry.GetResolver([]byte{1, 2})
+		return err == nil && ip == "127.0.0.1:8002"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	relayers, err := fileRegistry.GetRelayers()
+	require.NoError(t, err)
+	require.Empty(t, relayers)
+
+	cancel()
+	require.NoError(t, <-done)
+}
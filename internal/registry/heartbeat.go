@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrInvalidHeartbeat error represents heartbeat which is not signed by resolver key.
+var ErrInvalidHeartbeat = errors.New("invalid heartbeat")
+
+// HeartbeatHash returns hash which is signed by resolver in heartbeat: keccak256(challenge || timestamp || publicKey),
+// timestamp is unix time in seconds encoded as 8 bytes big endian.
+func HeartbeatHash(challenge []byte, timestamp int64, publicKey []byte) []byte {
+	var encodedTimestamp [8]byte
+	binary.BigEndian.PutUint64(encodedTimestamp[:], uint64(timestamp))
+
+	return crypto.Keccak256(challenge, encodedTimestamp[:], publicKey)
+}
+
+// VerifyHeartbeat checks that heartbeat is signed by the compressed public key.
+func VerifyHeartbeat(publicKey, challenge []byte, timestamp int64, signature []byte) error {
+	if len(signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidHeartbeat)
+	}
+
+	signer, err := crypto.SigToPub(HeartbeatHash(challenge, timestamp, publicKey), signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeartbeat, err)
+	}
+
+	if !bytes.Equal(crypto.CompressPubkey(signer), publicKey) {
+		return fmt.Errorf("%w: signed by another key", ErrInvalidHeartbeat)
+	}
+
+	return nil
+}
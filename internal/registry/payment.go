@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/1inch/p2p-network/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PaymentChannelState represents channel of payer to payee in payment channel contract.
+type PaymentChannelState struct {
+	// Deposit is total deposit of payer in wei
+	Deposit *big.Int
+	// Settled is cumulative amount in wei which is already paid to payee
+	Settled *big.Int
+	// WithdrawableAt is time after which payer can withdraw unsettled deposit, zero if withdrawal is not requested
+	WithdrawableAt time.Time
+}
+
+// PaymentChannel represents client of payment channel contract, transactions are sent with policy of registry client.
+type PaymentChannel struct {
+	Channel *contracts.PaymentChannel
+	client  *Client
+	address common.Address
+}
+
+// PaymentChannel creates client of payment channel contract with the given address.
+func (c *Client) PaymentChannel(address common.Address) (*PaymentChannel, error) {
+	channel, err := contracts.NewPaymentChannel(address, c.client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaymentChannel{Channel: channel, client: c, address: address}, nil
+}
+
+// DeployPaymentChannel deploys payment channel contract with the given withdrawal delay and returns it's client.
+func (c *Client) DeployPaymentChannel(ctx context.Context, withdrawalDelay time.Duration) (*PaymentChannel, error) {
+	channel := &PaymentChannel{client: c}
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		address, tx, contract, err := contracts.DeployPaymentChannel(opts, c.client, big.NewInt(int64(withdrawalDelay.Seconds())))
+		if err == nil {
+			channel.address, channel.Channel = address, contract
+		}
+		return tx, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+// Address returns address of the contract.
+func (p *PaymentChannel) Address() common.Address {
+	return p.address
+}
+
+// Domain returns EIP-712 domain of vouchers of the contract.
+func (p *PaymentChannel) Domain(ctx context.Context) (PaymentDomain, error) {
+	chainId, err := p.client.client.ChainID(ctx)
+	if err != nil {
+		return PaymentDomain{}, err
+	}
+
+	return PaymentDomain{ChainId: chainId, Contract: p.address}, nil
+}
+
+// GetChannel fetches channel of the payer to the payee.
+func (p *PaymentChannel) GetChannel(ctx context.Context, payer, payee common.Address) (PaymentChannelState, error) {
+	channel, err := p.Channel.Channels(&bind.CallOpts{Context: ctx}, payer, payee)
+	if err != nil {
+		return PaymentChannelState{}, err
+	}
+
+	state := PaymentChannelState{Deposit: channel.Deposit, Settled: channel.Settled}
+	if channel.WithdrawableAt.Sign() > 0 {
+		state.WithdrawableAt = time.Unix(channel.WithdrawableAt.Int64(), 0)
+	}
+	return state, nil
+}
+
+// Deposit adds the given amount to the deposit of the client account for the payee, requested withdrawal is cancelled.
+func (p *PaymentChannel) Deposit(ctx context.Context, payee common.Address, amount *big.Int) error {
+	_, err := p.client.Transact(ctx, amount, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Channel.Deposit(opts, payee)
+	})
+	return err
+}
+
+// Settle pays the difference between voucher amount and settled amount to the payee, it can be sent by any account.
+func (p *PaymentChannel) Settle(ctx context.Context, voucher Voucher) (common.Hash, error) {
+	return p.client.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Channel.Settle(opts, voucher.Payer, voucher.Payee, voucher.Amount, voucher.Signature)
+	})
+}
+
+// RequestWithdrawal starts withdrawal delay of unsettled deposit of the client account.
+func (p *PaymentChannel) RequestWithdrawal(ctx context.Context, payee common.Address) error {
+	_, err := p.client.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Channel.RequestWithdrawal(opts, payee)
+	})
+	return err
+}
+
+// Withdraw withdraws unsettled deposit of the client account after withdrawal delay.
+func (p *PaymentChannel) Withdraw(ctx context.Context, payee common.Address) error {
+	_, err := p.client.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return p.Channel.Withdraw(opts, payee)
+	})
+	return err
+}
@@ -0,0 +1,30 @@
+package registry
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/1inch/p2p-network/contracts"
+)
+
+// Registry provides registered nodes. Cache is backed by node registry contract,
+// FileRegistry is backed by node list file for deployments without Ethereum node.
+type Registry interface {
+	// GetResolver returns resolver address associated with the given public key.
+	GetResolver(publicKey []byte) (string, error)
+	// GetResolverCapabilities returns capabilities of resolver associated with the given public key.
+	GetResolverCapabilities(publicKey []byte) (contracts.NodeRegistryCapabilities, error)
+	// GetResolvers returns public keys of all resolvers and their stakes in wei in the same order.
+	GetResolvers() ([][]byte, []*big.Int, error)
+	// GetRelayer returns address of the primary relayer and public keys of all resolvers.
+	GetRelayer() (string, [][]byte, error)
+	// GetRelayers returns all registered relayers with metadata.
+	GetRelayers() ([]contracts.NodeRegistryRelayer, error)
+	// Run keeps registry state current until context is cancelled.
+	Run(ctx context.Context) error
+}
+
+var (
+	_ Registry = (*Cache)(nil)
+	_ Registry = (*FileRegistry)(nil)
+)
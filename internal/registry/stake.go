@@ -0,0 +1,82 @@
+package registry
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ResolverStake represents stake of resolver in wei.
+type ResolverStake struct {
+	// Stake is active stake
+	Stake *big.Int
+	// Unbonding is stake which is withdrawn after AvailableAt, it can still be slashed
+	Unbonding   *big.Int
+	AvailableAt time.Time
+}
+
+// GetResolverStake fetches the stake of the resolver with the given public key.
+func (c *Client) GetResolverStake(publicKey []byte) (ResolverStake, error) {
+	stake, err := c.Registry.GetResolverStake(&bind.CallOpts{}, publicKey)
+	if err != nil {
+		return ResolverStake{}, err
+	}
+
+	return ResolverStake{
+		Stake:       stake.Stake,
+		Unbonding:   stake.Unbonding,
+		AvailableAt: time.Unix(stake.AvailableAt.Int64(), 0),
+	}, nil
+}
+
+// StakeResolver adds the given amount to the resolver stake, must be called by the account which registered it.
+func (c *Client) StakeResolver(ctx context.Context, publicKey []byte, amount *big.Int) error {
+	_, err := c.Transact(ctx, amount, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.StakeResolver(opts, publicKey)
+	})
+	return err
+}
+
+// UnstakeResolver starts unbonding of the given amount of the resolver stake, must be called by the account which registered it.
+func (c *Client) UnstakeResolver(ctx context.Context, publicKey []byte, amount *big.Int) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.UnstakeResolver(opts, publicKey, amount)
+	})
+	return err
+}
+
+// WithdrawResolverStake withdraws unbonded stake of the resolver after the unbonding period.
+func (c *Client) WithdrawResolverStake(ctx context.Context, publicKey []byte) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.WithdrawResolverStake(opts, publicKey)
+	})
+	return err
+}
+
+// SlashResolver slashes up to the given amount of the resolver stake, must be called by the contract owner or a slasher.
+func (c *Client) SlashResolver(ctx context.Context, publicKey []byte, amount *big.Int) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.Slash(opts, publicKey, amount)
+	})
+	return err
+}
+
+// SetStakeConfig changes min resolver stake and unbonding period, must be called by the contract owner.
+func (c *Client) SetStakeConfig(ctx context.Context, minStake *big.Int, unbondingPeriod time.Duration) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.SetStakeConfig(opts, minStake, big.NewInt(int64(unbondingPeriod.Seconds())))
+	})
+	return err
+}
+
+// SetSlasher allows or forbids the account to slash resolver stakes, must be called by the contract owner.
+func (c *Client) SetSlasher(ctx context.Context, slasher common.Address, allowed bool) error {
+	_, err := c.Transact(ctx, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return c.Registry.SetSlasher(opts, slasher, allowed)
+	})
+	return err
+}
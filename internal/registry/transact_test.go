@@ -0,0 +1,134 @@
+//go:build deploy
+// +build deploy
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/contracts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestTransact(t *testing.T) {
+	ctx := context.Background()
+
+	address, client, err := DeployNodeRegistry(ctx, &Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	// parallel sends get sequential nonces
+	var group errgroup.Group
+	publicKeys := make([][]byte, 5)
+	for i := range publicKeys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		publicKeys[i] = crypto.CompressPubkey(&key.PublicKey)
+
+		group.Go(func() error {
+			return client.RegisterResolver(ctx, "127.0.0.1:8001", publicKeys[i])
+		})
+	}
+	require.NoError(t, group.Wait())
+
+	count, err := client.GetResolverCount()
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(publicKeys)), count)
+
+	dryRunClient, err := Dial(ctx, &Config{
+		DialURI:         rpcURL,
+		PrivateKey:      privateKeyHex,
+		ContractAddress: address.Hex(),
+		Tx:              TxConfig{DryRun: true},
+	})
+	require.NoError(t, err)
+
+	err = dryRunClient.DeregisterResolver(ctx, publicKeys[0])
+	var estimate *TxEstimate
+	require.True(t, errors.As(err, &estimate))
+	require.ErrorIs(t, err, ErrDryRun)
+	require.NotZero(t, estimate.Gas)
+	require.Positive(t, estimate.Cost.Sign())
+
+	_, err = dryRunClient.GetResolver(publicKeys[0])
+	require.NoError(t, err, "resolver is not deregistered in dry run")
+
+	cappedClient, err := Dial(ctx, &Config{
+		DialURI:         rpcURL,
+		PrivateKey:      privateKeyHex,
+		ContractAddress: address.Hex(),
+		Tx:              TxConfig{MaxFeePerGas: big.NewInt(1)},
+	})
+	require.NoError(t, err)
+	require.ErrorIs(t, cappedClient.DeregisterResolver(ctx, publicKeys[0]), ErrFeeCapExceeded)
+
+	fixedGasClient, err := Dial(ctx, &Config{
+		DialURI:         rpcURL,
+		PrivateKey:      privateKeyHex,
+		ContractAddress: address.Hex(),
+		Tx:              TxConfig{GasLimit: 25000},
+	})
+	require.NoError(t, err)
+	require.ErrorIs(t, fixedGasClient.DeregisterResolver(ctx, publicKeys[0]), ErrTransactionFailed, "transaction runs out of gas")
+
+	require.NoError(t, client.DeregisterResolver(ctx, publicKeys[0]), "nonce is synced after transaction of another client")
+}
+
+func TestWaitForTx(t *testing.T) {
+	ctx := context.Background()
+
+	address, client, err := DeployNodeRegistry(ctx, &Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	confirmations := uint64(3)
+	confirmingClient, err := Dial(ctx, &Config{
+		DialURI:         rpcURL,
+		PrivateKey:      privateKeyHex,
+		ContractAddress: address.Hex(),
+		Tx:              TxConfig{Confirmations: confirmations, ReceiptPollInterval: 50 * time.Millisecond},
+	})
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	publicKey := crypto.CompressPubkey(&key.PublicKey)
+
+	tx, err := confirmingClient.Registry.RegisterResolver(confirmingClient.Auth, "127.0.0.1:8001", publicKey, contracts.NodeRegistryCapabilities{})
+	require.NoError(t, err)
+	require.NoError(t, confirmingClient.WaitForTx(ctx, tx.Hash()))
+
+	receipt, err := client.client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	head, err := client.client.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, head+1, receipt.BlockNumber.Uint64()+confirmations, "transaction is confirmed by blocks on top")
+
+	// gas limit is fixed, so failing transaction is sent without estimation
+	fixedGasClient, err := Dial(ctx, &Config{
+		DialURI:         rpcURL,
+		PrivateKey:      privateKeyHex,
+		ContractAddress: address.Hex(),
+		Tx:              TxConfig{GasLimit: 300000},
+	})
+	require.NoError(t, err)
+	err = fixedGasClient.RegisterResolver(ctx, "127.0.0.1:8002", publicKey)
+	require.ErrorIs(t, err, ErrTransactionFailed)
+	require.ErrorContains(t, err, "Resolver already registered", "revert reason is returned")
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err = client.WaitForTx(cancelledCtx, tx.Hash())
+	require.ErrorIs(t, err, ErrContextCancelled)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,459 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultGasMargin      = 20
+	defaultFeeBumpPercent = 20
+	defaultMaxFeeBumps    = 3
+	// defaultConfirmations is count of blocks including block with transaction after which transaction is final
+	defaultConfirmations       = 1
+	defaultReceiptPollInterval = 200 * time.Millisecond
+	// minFeeBumpPercent is min fee increase which nodes accept for replacement transaction
+	minFeeBumpPercent = 10
+	// zeroFeeBump is fee of replacement transaction if fee is zero, because percent bump of zero fee is zero
+	zeroFeeBump = params.GWei
+)
+
+var (
+	// ErrFeeCapExceeded error represents base fee which is higher than configured max fee per gas.
+	ErrFeeCapExceeded = errors.New("base fee exceeds max fee per gas")
+	// ErrDryRun error represents transaction which is estimated and not sent, it's wrapped by TxEstimate.
+	ErrDryRun = errors.New("dry run, transaction is not sent")
+
+	// errTxStuck is returned when transaction is not mined before stuck timeout
+	errTxStuck = errors.New("transaction is not mined before timeout")
+)
+
+// TxConfig represents fee, gas and replacement policy of registry transactions.
+type TxConfig struct {
+	// MaxFeePerGas caps max fee per gas in wei, gas price on chains without EIP-1559, not capped if nil
+	MaxFeePerGas *big.Int `yaml:"max_fee_per_gas"`
+	// MaxPriorityFeePerGas caps priority fee per gas in wei, not capped if nil
+	MaxPriorityFeePerGas *big.Int `yaml:"max_priority_fee_per_gas"`
+	// GasLimit is used instead of gas estimation if not zero
+	GasLimit uint64 `yaml:"gas_limit"`
+	// GasMargin is percent which is added to estimated gas, 20 if zero
+	GasMargin uint64 `yaml:"gas_margin"`
+	// StuckTimeout is time after which pending transaction is replaced with bumped fees, not replaced if zero
+	StuckTimeout time.Duration `yaml:"stuck_timeout"`
+	// FeeBumpPercent is fee increase of replacement transaction, 20 if zero, at least 10
+	FeeBumpPercent uint64 `yaml:"fee_bump_percent"`
+	// MaxFeeBumps is max count of replacements of one transaction, 3 if zero
+	MaxFeeBumps int `yaml:"max_fee_bumps"`
+	// Confirmations is count of blocks including block with transaction after which transaction is final, 1 if zero
+	Confirmations uint64 `yaml:"confirmations"`
+	// ReceiptPollInterval is interval between checks of transaction receipt, 200ms if zero
+	ReceiptPollInterval time.Duration `yaml:"receipt_poll_interval"`
+	// DryRun estimates transactions without sending them, writes return TxEstimate error
+	DryRun bool `yaml:"dry_run"`
+}
+
+// withDefaults returns config with default values for zero fields.
+func (c TxConfig) withDefaults() TxConfig {
+	if c.GasMargin == 0 {
+		c.GasMargin = defaultGasMargin
+	}
+	if c.FeeBumpPercent == 0 {
+		c.FeeBumpPercent = defaultFeeBumpPercent
+	}
+	c.FeeBumpPercent = max(c.FeeBumpPercent, minFeeBumpPercent)
+	if c.MaxFeeBumps == 0 {
+		c.MaxFeeBumps = defaultMaxFeeBumps
+	}
+	if c.Confirmations == 0 {
+		c.Confirmations = defaultConfirmations
+	}
+	if c.ReceiptPollInterval <= 0 {
+		c.ReceiptPollInterval = defaultReceiptPollInterval
+	}
+
+	return c
+}
+
+// TxEstimate describes transaction which is not sent in dry run mode.
+type TxEstimate struct {
+	Nonce uint64
+	// Gas is gas limit including margin
+	Gas uint64
+	// GasFeeCap is max fee per gas, gas price on chains without EIP-1559
+	GasFeeCap *big.Int
+	// GasTipCap is max priority fee per gas, nil on chains without EIP-1559
+	GasTipCap *big.Int
+	// Cost is max cost of transaction in wei including sent value
+	Cost *big.Int
+}
+
+// Error implements error, so dry run is reported by every write method.
+func (e *TxEstimate) Error() string {
+	return fmt.Sprintf("%s: nonce %d, gas %d, max fee per gas %s wei, max cost %s wei", ErrDryRun, e.Nonce, e.Gas, e.GasFeeCap, e.Cost)
+}
+
+// Unwrap returns ErrDryRun.
+func (e *TxEstimate) Unwrap() error {
+	return ErrDryRun
+}
+
+// txFees are fees of transaction, gasPrice is set on chains without EIP-1559.
+type txFees struct {
+	gasPrice  *big.Int
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+}
+
+// maxFeePerGas returns max fee per gas of any transaction type.
+func (f txFees) maxFeePerGas() *big.Int {
+	if f.gasPrice != nil {
+		return f.gasPrice
+	}
+	return f.gasFeeCap
+}
+
+// SendFunc sends contract transaction with the given options, contract bindings are used as is.
+type SendFunc func(opts *bind.TransactOpts) (*types.Transaction, error)
+
+// Transact sends registry transaction with fee, gas and nonce policy of the client and waits until it's mined.
+// Stuck transaction is replaced with bumped fees, hash of the mined transaction is returned.
+func (c *Client) Transact(ctx context.Context, value *big.Int, send SendFunc) (common.Hash, error) {
+	if c.Auth == nil {
+		return common.Hash{}, ErrReadOnlyClient
+	}
+
+	tx, fees, err := c.sendNew(ctx, value, send)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return c.waitMined(ctx, tx, fees, value, send)
+}
+
+// sendNew sends transaction with the next nonce, nonce lock is held until transaction is sent,
+// so parallel sends get sequential nonces.
+func (c *Client) sendNew(ctx context.Context, value *big.Int, send SendFunc) (*types.Transaction, txFees, error) {
+	c.nonceMu.Lock()
+	defer c.nonceMu.Unlock()
+
+	nonce, err := c.nextNonce(ctx)
+	if err != nil {
+		return nil, txFees{}, err
+	}
+
+	fees, err := c.suggestFees(ctx)
+	if err != nil {
+		return nil, txFees{}, err
+	}
+
+	opts := c.transactOpts(ctx, value, nonce, fees, c.txConfig.GasLimit)
+	if opts.GasLimit == 0 {
+		// bindings estimate gas and sign transaction without sending it
+		opts.NoSend = true
+		estimated, err := send(opts)
+		if err != nil {
+			return nil, txFees{}, err
+		}
+		opts.GasLimit = estimated.Gas() * (100 + c.txConfig.GasMargin) / 100
+		opts.NoSend = false
+	}
+
+	if c.txConfig.DryRun {
+		cost := new(big.Int).Mul(fees.maxFeePerGas(), new(big.Int).SetUint64(opts.GasLimit))
+		if value != nil {
+			cost.Add(cost, value)
+		}
+
+		return nil, txFees{}, &TxEstimate{
+			Nonce:     nonce,
+			Gas:       opts.GasLimit,
+			GasFeeCap: fees.maxFeePerGas(),
+			GasTipCap: fees.gasTipCap,
+			Cost:      cost,
+		}
+	}
+
+	tx, err := send(opts)
+	if err != nil {
+		// nonce is synced with node on the next send
+		c.pendingNonce = nil
+		return nil, txFees{}, err
+	}
+
+	next := nonce + 1
+	c.pendingNonce = &next
+	return tx, fees, nil
+}
+
+// nextNonce returns pending nonce of the account, local nonce is used if node doesn't see sent transactions yet.
+func (c *Client) nextNonce(ctx context.Context) (uint64, error) {
+	nonce, err := c.client.PendingNonceAt(ctx, c.Auth.From)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.pendingNonce != nil && *c.pendingNonce > nonce {
+		return *c.pendingNonce, nil
+	}
+
+	return nonce, nil
+}
+
+// suggestFees returns fees suggested by node and capped by config.
+func (c *Client) suggestFees(ctx context.Context) (txFees, error) {
+	head, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return txFees{}, err
+	}
+
+	if head.BaseFee == nil {
+		gasPrice, err := c.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return txFees{}, err
+		}
+
+		return txFees{gasPrice: capped(gasPrice, c.txConfig.MaxFeePerGas)}, nil
+	}
+
+	if c.txConfig.MaxFeePerGas != nil && c.txConfig.MaxFeePerGas.Cmp(head.BaseFee) < 0 {
+		return txFees{}, fmt.Errorf("%w: base fee %s wei", ErrFeeCapExceeded, head.BaseFee)
+	}
+
+	gasTipCap, err := c.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return txFees{}, err
+	}
+	gasTipCap = capped(gasTipCap, c.txConfig.MaxPriorityFeePerGas)
+
+	// fee cap covers doubled base fee, so transaction stays valid during several full blocks
+	gasFeeCap := new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), gasTipCap)
+	gasFeeCap = capped(gasFeeCap, c.txConfig.MaxFeePerGas)
+
+	return txFees{gasFeeCap: gasFeeCap, gasTipCap: capped(gasTipCap, gasFeeCap)}, nil
+}
+
+// bumpFees increases fees by FeeBumpPercent, zero fees are increased to 1 gwei.
+// False is returned if bumped fees exceed caps.
+func (c *Client) bumpFees(fees txFees) (txFees, bool) {
+	bump := func(fee *big.Int) *big.Int {
+		if fee.Sign() == 0 {
+			return big.NewInt(zeroFeeBump)
+		}
+		bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+c.txConfig.FeeBumpPercent))
+		// rounding up, so small fees are increased too
+		return bumped.Add(bumped, big.NewInt(99)).Div(bumped, big.NewInt(100))
+	}
+	exceeds := func(fee, limit *big.Int) bool {
+		return limit != nil && fee.Cmp(limit) > 0
+	}
+
+	if fees.gasPrice != nil {
+		gasPrice := bump(fees.gasPrice)
+		return txFees{gasPrice: gasPrice}, !exceeds(gasPrice, c.txConfig.MaxFeePerGas)
+	}
+
+	bumped := txFees{gasFeeCap: bump(fees.gasFeeCap), gasTipCap: bump(fees.gasTipCap)}
+	// bumped zero tip can exceed small fee cap, fee cap must not be lower than tip
+	if bumped.gasFeeCap.Cmp(bumped.gasTipCap) < 0 {
+		bumped.gasFeeCap = new(big.Int).Set(bumped.gasTipCap)
+	}
+	if exceeds(bumped.gasFeeCap, c.txConfig.MaxFeePerGas) || exceeds(bumped.gasTipCap, c.txConfig.MaxPriorityFeePerGas) {
+		return txFees{}, false
+	}
+
+	return bumped, true
+}
+
+// waitMined waits until the transaction or one of its replacements is mined.
+func (c *Client) waitMined(ctx context.Context, tx *types.Transaction, fees txFees, value *big.Int, send SendFunc) (common.Hash, error) {
+	hashes := []common.Hash{tx.Hash()}
+
+	for bumps := 0; ; bumps++ {
+		var timer *time.Timer
+		var stuck <-chan time.Time
+		if c.txConfig.StuckTimeout > 0 && bumps < c.txConfig.MaxFeeBumps {
+			timer = time.NewTimer(c.txConfig.StuckTimeout)
+			stuck = timer.C
+		}
+
+		hash, err := c.waitForAny(ctx, hashes, stuck)
+		if timer != nil {
+			timer.Stop()
+		}
+		if !errors.Is(err, errTxStuck) {
+			return hash, err
+		}
+
+		bumped, ok := c.bumpFees(fees)
+		if !ok {
+			// fees can't be bumped within caps, wait for sent transactions
+			bumps = c.txConfig.MaxFeeBumps
+			continue
+		}
+
+		replacement, err := send(c.transactOpts(ctx, value, tx.Nonce(), bumped, tx.Gas()))
+		if err != nil {
+			// replacement is rejected, e.g. previous transaction is mined meanwhile
+			continue
+		}
+
+		hashes, fees = append(hashes, replacement.Hash()), bumped
+	}
+}
+
+// waitForAny waits until one of transactions is mined and confirmed, errTxStuck is returned when stuck channel fires
+// before any transaction is mined. Receipts are checked again after confirmation, so transaction which is dropped
+// from chain by reorg is waited again.
+func (c *Client) waitForAny(ctx context.Context, hashes []common.Hash, stuck <-chan time.Time) (common.Hash, error) {
+	ticker := time.NewTicker(c.txConfig.ReceiptPollInterval)
+	defer ticker.Stop()
+
+	var mined bool
+	for {
+		select {
+		case <-ctx.Done():
+			return common.Hash{}, fmt.Errorf("%w: %w", ErrContextCancelled, ctx.Err())
+		case <-stuck:
+			// mined transaction is not replaced while it's confirmed
+			if !mined {
+				return common.Hash{}, errTxStuck
+			}
+			continue
+		case <-ticker.C:
+		}
+
+		receipt, err := c.findReceipt(ctx, hashes)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		mined = receipt != nil
+		if !mined {
+			continue
+		}
+
+		confirmed, err := c.isConfirmed(ctx, receipt)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if !confirmed {
+			continue
+		}
+
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return receipt.TxHash, c.failure(ctx, receipt)
+		}
+
+		return receipt.TxHash, nil
+	}
+}
+
+// findReceipt returns receipt of the first mined transaction, nil if none is mined.
+func (c *Client) findReceipt(ctx context.Context, hashes []common.Hash) (*types.Receipt, error) {
+	for _, hash := range hashes {
+		receipt, err := c.client.TransactionReceipt(ctx, hash)
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// isConfirmed reports whether block with receipt has enough blocks on top and is still in canonical chain.
+func (c *Client) isConfirmed(ctx context.Context, receipt *types.Receipt) (bool, error) {
+	head, err := c.client.BlockNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if head+1 < receipt.BlockNumber.Uint64()+c.txConfig.Confirmations {
+		return false, nil
+	}
+
+	// receipt can be read before reorg which replaces its block
+	header, err := c.client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if err != nil {
+		return false, err
+	}
+
+	return header.Hash() == receipt.BlockHash, nil
+}
+
+// failure returns ErrTransactionFailed with revert reason, reason is found by replaying transaction on parent block.
+func (c *Client) failure(ctx context.Context, receipt *types.Receipt) error {
+	reason, err := c.revertReason(ctx, receipt)
+	if err != nil || reason == "" {
+		return fmt.Errorf("%w: transaction %s", ErrTransactionFailed, receipt.TxHash)
+	}
+
+	return fmt.Errorf("%w: transaction %s: %s", ErrTransactionFailed, receipt.TxHash, reason)
+}
+
+func (c *Client) revertReason(ctx context.Context, receipt *types.Receipt) (string, error) {
+	tx, _, err := c.client.TransactionByHash(ctx, receipt.TxHash)
+	if err != nil {
+		return "", err
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return "", err
+	}
+
+	parent := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	_, err = c.client.CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}, parent)
+	if err == nil {
+		// transaction succeeds on parent block state, e.g. it's reverted by transaction before it in the same block
+		return "", nil
+	}
+
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, unpackErr := abi.UnpackRevert(common.FromHex(data)); unpackErr == nil {
+				return reason, nil
+			}
+		}
+	}
+
+	return err.Error(), nil
+}
+
+// transactOpts returns copy of client auth with the given value, nonce, fees and gas limit.
+func (c *Client) transactOpts(ctx context.Context, value *big.Int, nonce uint64, fees txFees, gasLimit uint64) *bind.TransactOpts {
+	opts := *c.Auth
+	opts.Context = ctx
+	opts.Value = value
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	opts.GasPrice, opts.GasFeeCap, opts.GasTipCap = fees.gasPrice, fees.gasFeeCap, fees.gasTipCap
+	opts.GasLimit = gasLimit
+	return &opts
+}
+
+// capped returns min of value and limit, value is returned if limit is nil.
+func capped(value, limit *big.Int) *big.Int {
+	if limit != nil && value.Cmp(limit) > 0 {
+		return limit
+	}
+	return value
+}
@@ -0,0 +1,111 @@
+package registry
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpFees(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   TxConfig
+		fees     txFees
+		expected txFees
+		ok       bool
+	}{
+		{
+			name:     "Dynamic fees",
+			config:   TxConfig{},
+			fees:     txFees{gasFeeCap: big.NewInt(100), gasTipCap: big.NewInt(10)},
+			expected: txFees{gasFeeCap: big.NewInt(120), gasTipCap: big.NewInt(12)},
+			ok:       true,
+		},
+		{
+			name:     "Legacy gas price",
+			config:   TxConfig{FeeBumpPercent: 50},
+			fees:     txFees{gasPrice: big.NewInt(100)},
+			expected: txFees{gasPrice: big.NewInt(150)},
+			ok:       true,
+		},
+		{
+			name:     "Small fees are rounded up",
+			config:   TxConfig{FeeBumpPercent: 5},
+			fees:     txFees{gasFeeCap: big.NewInt(3), gasTipCap: big.NewInt(1)},
+			expected: txFees{gasFeeCap: big.NewInt(4), gasTipCap: big.NewInt(2)},
+			ok:       true,
+		},
+		{
+			name:     "Zero tip",
+			config:   TxConfig{},
+			fees:     txFees{gasFeeCap: big.NewInt(100 * params.GWei), gasTipCap: big.NewInt(0)},
+			expected: txFees{gasFeeCap: big.NewInt(120 * params.GWei), gasTipCap: big.NewInt(params.GWei)},
+			ok:       true,
+		},
+		{
+			name:     "Zero tip above small fee cap",
+			config:   TxConfig{},
+			fees:     txFees{gasFeeCap: big.NewInt(100), gasTipCap: big.NewInt(0)},
+			expected: txFees{gasFeeCap: big.NewInt(params.GWei), gasTipCap: big.NewInt(params.GWei)},
+			ok:       true,
+		},
+		{
+			name:     "Zero gas price",
+			config:   TxConfig{},
+			fees:     txFees{gasPrice: big.NewInt(0)},
+			expected: txFees{gasPrice: big.NewInt(params.GWei)},
+			ok:       true,
+		},
+		{
+			name:   "Zero tip bump exceeds max priority fee per gas",
+			config: TxConfig{MaxPriorityFeePerGas: big.NewInt(params.GWei / 2)},
+			fees:   txFees{gasFeeCap: big.NewInt(100 * params.GWei), gasTipCap: big.NewInt(0)},
+			ok:     false,
+		},
+		{
+			name:   "Max fee per gas exceeded",
+			config: TxConfig{MaxFeePerGas: big.NewInt(110)},
+			fees:   txFees{gasFeeCap: big.NewInt(100), gasTipCap: big.NewInt(10)},
+			ok:     false,
+		},
+		{
+			name:   "Max priority fee per gas exceeded",
+			config: TxConfig{MaxPriorityFeePerGas: big.NewInt(10)},
+			fees:   txFees{gasFeeCap: big.NewInt(100), gasTipCap: big.NewInt(10)},
+			ok:     false,
+		},
+		{
+			name:   "Max gas price exceeded",
+			config: TxConfig{MaxFeePerGas: big.NewInt(100)},
+			fees:   txFees{gasPrice: big.NewInt(100)},
+			ok:     false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &Client{txConfig: testCase.config.withDefaults()}
+
+			bumped, ok := client.bumpFees(testCase.fees)
+			require.Equal(t, testCase.ok, ok)
+			if ok {
+				assert.Equal(t, testCase.expected, bumped)
+			}
+		})
+	}
+}
+
+func TestTxConfigDefaults(t *testing.T) {
+	config := TxConfig{}.withDefaults()
+	assert.Equal(t, uint64(defaultGasMargin), config.GasMargin)
+	assert.Equal(t, uint64(defaultFeeBumpPercent), config.FeeBumpPercent)
+	assert.Equal(t, defaultMaxFeeBumps, config.MaxFeeBumps)
+	assert.Equal(t, uint64(defaultConfirmations), config.Confirmations)
+	assert.Equal(t, defaultReceiptPollInterval, config.ReceiptPollInterval)
+
+	config = TxConfig{FeeBumpPercent: 1}.withDefaults()
+	assert.Equal(t, uint64(minFeeBumpPercent), config.FeeBumpPercent, "replacement with lower bump is rejected by nodes")
+}
@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrInvalidVoucher error represents voucher which is malformed or is not signed by its payer.
+var ErrInvalidVoucher = errors.New("invalid payment voucher")
+
+// signatureV is added to recovery id of voucher signature, as ecrecover of payment channel contract expects it.
+const signatureV = 27
+
+var (
+	voucherTypeHash = crypto.Keccak256([]byte("Voucher(address payer,address payee,uint256 amount)"))
+	domainTypeHash  = crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	domainNameHash  = crypto.Keccak256([]byte("PaymentChannel"))
+	domainVersion   = crypto.Keccak256([]byte("1"))
+)
+
+// PaymentDomain is EIP-712 domain of vouchers of payment channel contract.
+type PaymentDomain struct {
+	ChainId  *big.Int
+	Contract common.Address
+}
+
+// Voucher is payer signature of cumulative amount which is paid to payee by payment channel contract.
+type Voucher struct {
+	Payer common.Address
+	Payee common.Address
+	// Amount is total amount in wei paid by all vouchers of the payer to the payee
+	Amount *big.Int
+	// Signature is EIP-712 signature in [R || S || V] format, V is 27 or 28
+	Signature []byte
+}
+
+// separator returns EIP-712 domain separator.
+func (d PaymentDomain) separator() []byte {
+	return crypto.Keccak256(
+		domainTypeHash,
+		domainNameHash,
+		domainVersion,
+		common.LeftPadBytes(d.ChainId.Bytes(), 32),
+		common.LeftPadBytes(d.Contract.Bytes(), 32),
+	)
+}
+
+// VoucherHash returns EIP-712 hash which is signed by payer, it's the same as voucherHash of the contract.
+func (d PaymentDomain) VoucherHash(payer, payee common.Address, amount *big.Int) []byte {
+	structHash := crypto.Keccak256(
+		voucherTypeHash,
+		common.LeftPadBytes(payer.Bytes(), 32),
+		common.LeftPadBytes(payee.Bytes(), 32),
+		common.LeftPadBytes(amount.Bytes(), 32),
+	)
+
+	return crypto.Keccak256([]byte("\x19\x01"), d.separator(), structHash)
+}
+
+// SignVoucher signs cumulative amount paid to the payee with the payer key.
+func (d PaymentDomain) SignVoucher(payer signer.Signer, payee common.Address, amount *big.Int) (Voucher, error) {
+	signature, err := payer.SignHash(d.VoucherHash(payer.Address(), payee, amount))
+	if err != nil {
+		return Voucher{}, err
+	}
+	signature[crypto.RecoveryIDOffset] += signatureV
+
+	return Voucher{
+		Payer:     payer.Address(),
+		Payee:     payee,
+		Amount:    new(big.Int).Set(amount),
+		Signature: signature,
+	}, nil
+}
+
+// VerifyVoucher checks that voucher is signed by its payer, V of signature can be 0, 1, 27 or 28.
+func (d PaymentDomain) VerifyVoucher(voucher Voucher) error {
+	if voucher.Amount == nil || voucher.Amount.Sign() <= 0 || voucher.Amount.BitLen() > 256 {
+		return fmt.Errorf("%w: amount must be positive uint256", ErrInvalidVoucher)
+	}
+	if len(voucher.Signature) != crypto.SignatureLength {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidVoucher)
+	}
+
+	signature := common.CopyBytes(voucher.Signature)
+	if signature[crypto.RecoveryIDOffset] >= signatureV {
+		signature[crypto.RecoveryIDOffset] -= signatureV
+	}
+
+	// the contract rejects malleable signatures too
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:64])
+	if !crypto.ValidateSignatureValues(signature[crypto.RecoveryIDOffset], r, s, true) {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidVoucher)
+	}
+
+	publicKey, err := crypto.SigToPub(d.VoucherHash(voucher.Payer, voucher.Payee, voucher.Amount), signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidVoucher, err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != voucher.Payer {
+		return fmt.Errorf("%w: voucher isn't signed by payer", ErrInvalidVoucher)
+	}
+
+	return nil
+}
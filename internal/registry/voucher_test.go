@@ -0,0 +1,70 @@
+package registry
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyVoucher(t *testing.T) {
+	payer, err := signer.Generate()
+	require.NoError(t, err)
+	other, err := signer.Generate()
+	require.NoError(t, err)
+
+	domain := PaymentDomain{ChainId: big.NewInt(31337), Contract: common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")}
+	payee := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	voucher, err := domain.SignVoucher(payer, payee, big.NewInt(1000))
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, voucher.Signature[crypto.RecoveryIDOffset])
+
+	otherVoucher, err := domain.SignVoucher(other, payee, big.NewInt(1000))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		domain PaymentDomain
+		modify func(v *Voucher)
+		valid  bool
+	}{
+		{name: "Valid voucher", domain: domain, modify: func(v *Voucher) {}, valid: true},
+		{name: "Recovery id without offset", domain: domain, modify: func(v *Voucher) { v.Signature[crypto.RecoveryIDOffset] -= signatureV }, valid: true},
+		{name: "Changed amount", domain: domain, modify: func(v *Voucher) { v.Amount = big.NewInt(1001) }},
+		{name: "Changed payee", domain: domain, modify: func(v *Voucher) { v.Payee = other.Address() }},
+		{name: "Signed by other account", domain: domain, modify: func(v *Voucher) { v.Signature = otherVoucher.Signature }},
+		{name: "Other chain", domain: PaymentDomain{ChainId: big.NewInt(1), Contract: domain.Contract}, modify: func(v *Voucher) {}},
+		{name: "Other contract", domain: PaymentDomain{ChainId: domain.ChainId, Contract: payee}, modify: func(v *Voucher) {}},
+		{name: "Zero amount", domain: domain, modify: func(v *Voucher) { v.Amount = new(big.Int) }},
+		{name: "Malformed signature", domain: domain, modify: func(v *Voucher) { v.Signature = v.Signature[:64] }},
+		{
+			name:   "Malleable signature",
+			domain: domain,
+			modify: func(v *Voucher) {
+				s := new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(v.Signature[32:64]))
+				copy(v.Signature[32:64], common.LeftPadBytes(s.Bytes(), 32))
+				v.Signature[crypto.RecoveryIDOffset] ^= 1
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			modified := voucher
+			modified.Signature = common.CopyBytes(voucher.Signature)
+			testCase.modify(&modified)
+
+			err := testCase.domain.VerifyVoucher(modified)
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidVoucher)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package registry
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const defaultWatchPollInterval = 2 * time.Second
+
+// EventType represents kind of registry change.
+type EventType string
+
+const (
+	// EventRelayerRegistered is emitted when relayer is registered or its metadata is updated.
+	EventRelayerRegistered EventType = "relayer_registered"
+	// EventRelayerRemoved is emitted when relayer is deregistered.
+	EventRelayerRemoved EventType = "relayer_removed"
+	// EventResolverRegistered is emitted when resolver is registered.
+	EventResolverRegistered EventType = "resolver_registered"
+	// EventResolverUpdated is emitted when resolver address is changed.
+	EventResolverUpdated EventType = "resolver_updated"
+	// EventResolverRemoved is emitted when resolver is deregistered.
+	EventResolverRemoved EventType = "resolver_removed"
+	// EventResolverKeyRotated is emitted when resolver public key is rotated.
+	EventResolverKeyRotated EventType = "resolver_key_rotated"
+)
+
+// RegistryEvent represents change of registry state.
+type RegistryEvent struct {
+	Type EventType
+	// IP is node address, empty for removal and key rotation events
+	IP string
+	// Owner is node owner, set for registration of relayer and resolver and removal of relayer
+	Owner common.Address
+	// PublicKey is resolver public key, old public key for key rotation
+	PublicKey []byte
+	// NewPublicKey is new resolver public key, set for key rotation only
+	NewPublicKey []byte
+	// ValidUntil is end of old key grace period, set for key rotation only
+	ValidUntil time.Time
+
+	BlockNumber uint64
+	TxHash      common.Hash
+}
+
+// Watch polls new blocks for registry events and delivers them in order.
+// Events emitted before the call are not delivered, RPC errors are retried on the next poll.
+// The channel is closed when context is cancelled.
+func (c *Client) Watch(ctx context.Context) <-chan RegistryEvent {
+	events := make(chan RegistryEvent)
+
+	// events are delivered from the block following the latest one at the time of the call
+	var from uint64
+	started := false
+	if latest, err := c.client.BlockNumber(ctx); err == nil {
+		from, started = latest+1, true
+	}
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(defaultWatchPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			latest, err := c.client.BlockNumber(ctx)
+			if err != nil {
+				continue
+			}
+
+			if !started {
+				from, started = latest+1, true
+				continue
+			}
+
+			if latest < from {
+				continue
+			}
+
+			logs, err := c.client.FilterLogs(ctx, ethereum.FilterQuery{
+				FromBlock: new(big.Int).SetUint64(from),
+				ToBlock:   new(big.Int).SetUint64(latest),
+				Addresses: []common.Address{c.address},
+			})
+			if err != nil {
+				continue
+			}
+
+			for _, log := range logs {
+				event, ok := c.parseEvent(log)
+				if !ok {
+					continue
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case events <- event:
+				}
+			}
+
+			from = latest + 1
+		}
+	}()
+
+	return events
+}
+
+// parseEvent converts contract log to registry event, false is returned for unknown logs.
+func (c *Client) parseEvent(log types.Log) (RegistryEvent, bool) {
+	event := RegistryEvent{BlockNumber: log.BlockNumber, TxHash: log.TxHash}
+
+	if relayerRegistered, err := c.Registry.ParseRelayerRegistered(log); err == nil {
+		event.Type = EventRelayerRegistered
+		event.Owner, event.IP = relayerRegistered.Owner, relayerRegistered.Ip
+	} else if relayerRemoved, err := c.Registry.ParseRelayerRemoved(log); err == nil {
+		event.Type = EventRelayerRemoved
+		event.Owner = relayerRemoved.Owner
+	} else if resolverRegistered, err := c.Registry.ParseResolverRegistered(log); err == nil {
+		event.Type = EventResolverRegistered
+		event.PublicKey, event.Owner, event.IP = resolverRegistered.PublicKey, resolverRegistered.Owner, resolverRegistered.Ip
+	} else if resolverUpdated, err := c.Registry.ParseResolverUpdated(log); err == nil {
+		event.Type = EventResolverUpdated
+		event.PublicKey, event.IP = resolverUpdated.PublicKey, resolverUpdated.Ip
+	} else if resolverRemoved, err := c.Registry.ParseResolverRemoved(log); err == nil {
+		event.Type = EventResolverRemoved
+		event.PublicKey = resolverRemoved.PublicKey
+	} else if keyRotated, err := c.Registry.ParseResolverKeyRotated(log); err == nil {
+		event.Type = EventResolverKeyRotated
+		event.PublicKey, event.NewPublicKey = keyRotated.OldPublicKey, keyRotated.NewPublicKey
+		event.ValidUntil = time.Unix(keyRotated.ValidUntil.Int64(), 0)
+	} else {
+		return RegistryEvent{}, false
+	}
+
+	return event, true
+}
@@ -0,0 +1,73 @@
+//go:build deploy
+// +build deploy
+
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, client, err := DeployNodeRegistry(ctx, &Config{
+		DialURI:    rpcURL,
+		PrivateKey: privateKeyHex,
+	})
+	require.NoError(t, err, "contract deployment failed")
+
+	oldKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	oldPublicKey := crypto.CompressPubkey(&oldKey.PublicKey)
+	newKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newPublicKey := crypto.CompressPubkey(&newKey.PublicKey)
+
+	require.NoError(t, client.RegisterResolver(ctx, "127.0.0.1:8000", oldPublicKey), "events before watch must not be delivered")
+
+	events := client.Watch(ctx)
+
+	require.NoError(t, client.RegisterRelayer(ctx, "127.0.0.1:8080", RelayerMetadata{Region: "eu"}))
+	require.NoError(t, client.UpdateResolver(ctx, oldPublicKey, "127.0.0.1:8001"))
+	require.NoError(t, client.RotateResolverKey(ctx, oldPublicKey, newPublicKey))
+	require.NoError(t, client.DeregisterResolver(ctx, newPublicKey))
+	require.NoError(t, client.DeregisterRelayer(ctx))
+
+	expected := []RegistryEvent{
+		{Type: EventRelayerRegistered, IP: "127.0.0.1:8080", Owner: client.Auth.From},
+		{Type: EventResolverUpdated, IP: "127.0.0.1:8001", PublicKey: oldPublicKey},
+		{Type: EventResolverKeyRotated, PublicKey: oldPublicKey, NewPublicKey: newPublicKey},
+		{Type: EventResolverRemoved, PublicKey: newPublicKey},
+		{Type: EventRelayerRemoved, Owner: client.Auth.From},
+	}
+
+	for _, expectedEvent := range expected {
+		var event RegistryEvent
+		select {
+		case event = <-events:
+		case <-ctx.Done():
+			t.Fatalf("event %s is not received", expectedEvent.Type)
+		}
+
+		require.Equal(t, expectedEvent.Type, event.Type)
+		require.Equal(t, expectedEvent.IP, event.IP)
+		require.Equal(t, expectedEvent.Owner, event.Owner)
+		require.Equal(t, expectedEvent.PublicKey, event.PublicKey)
+		require.Equal(t, expectedEvent.NewPublicKey, event.NewPublicKey)
+		require.NotZero(t, event.BlockNumber)
+
+		if event.Type == EventResolverKeyRotated {
+			require.True(t, event.ValidUntil.After(time.Now()))
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,68 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// ErrPassphraseEnvNotSet error represents missing environment variable with keystore passphrase.
+var ErrPassphraseEnvNotSet = errors.New("keystore passphrase environment variable is not set")
+
+// KeystoreConfig represents configuration of go-ethereum encrypted keystore file.
+type KeystoreConfig struct {
+	// Path to keystore JSON file
+	Path string `yaml:"path"`
+	// PassphraseFile is path to file which contains keystore passphrase
+	PassphraseFile string `yaml:"passphrase_file"`
+	// PassphraseEnv is name of environment variable which contains keystore passphrase
+	PassphraseEnv string `yaml:"passphrase_env"`
+}
+
+// NewFromKeystore creates signer from go-ethereum encrypted keystore file.
+func NewFromKeystore(cfg KeystoreConfig) (Signer, error) {
+	keyJson, err := os.ReadFile(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	passphrase, err := cfg.passphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(keyJson, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore file: %w", err)
+	}
+
+	return NewFromECDSA(key.PrivateKey), nil
+}
+
+// passphrase reads passphrase from file or environment, file takes precedence.
+func (cfg KeystoreConfig) passphrase() (string, error) {
+	if cfg.PassphraseFile != "" {
+		data, err := os.ReadFile(cfg.PassphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read keystore passphrase file: %w", err)
+		}
+
+		// editors usually add trailing new line
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	if cfg.PassphraseEnv != "" {
+		passphrase, ok := os.LookupEnv(cfg.PassphraseEnv)
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrPassphraseEnvNotSet, cfg.PassphraseEnv)
+		}
+
+		return passphrase, nil
+	}
+
+	// keystore can be encrypted with empty passphrase
+	return "", nil
+}
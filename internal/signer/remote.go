@@ -0,0 +1,106 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const defaultRemoteTimeout = 10 * time.Second
+
+// ErrInvalidRemoteSignature error represents signature which doesn't match public key of remote signer.
+var ErrInvalidRemoteSignature = errors.New("remote signer returned invalid signature")
+
+// RemoteBackend is implemented by remote key services (KMS, HSM or signing daemon), so the key never leaves the service.
+// Node configs don't select remote signers, NewRemote is for code which embeds the signer.
+type RemoteBackend interface {
+	// PublicKey returns public key of the remote key in uncompressed or compressed form.
+	PublicKey(ctx context.Context) ([]byte, error)
+	// SignHash signs 32 bytes hash, signature is returned in [R || S || V] format.
+	SignHash(ctx context.Context, hash []byte) ([]byte, error)
+	// Decrypt decrypts ECIES payload encrypted with public key of the remote key.
+	Decrypt(ctx context.Context, payload []byte) ([]byte, error)
+}
+
+// remoteSigner delegates key operations to remote backend.
+type remoteSigner struct {
+	backend   RemoteBackend
+	publicKey *ecdsa.PublicKey
+	timeout   time.Duration
+}
+
+// NewRemote creates signer backed by remote key service, zero timeout means default timeout for every call.
+func NewRemote(ctx context.Context, backend RemoteBackend, timeout time.Duration) (Signer, error) {
+	if timeout <= 0 {
+		timeout = defaultRemoteTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	publicKeyBytes, err := backend.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key from remote signer: %w", err)
+	}
+
+	var publicKey *ecdsa.PublicKey
+	if len(publicKeyBytes) == 33 {
+		publicKey, err = crypto.DecompressPubkey(publicKeyBytes)
+	} else {
+		publicKey, err = crypto.UnmarshalPubkey(publicKeyBytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key of remote signer: %w", err)
+	}
+
+	return &remoteSigner{
+		backend:   backend,
+		publicKey: publicKey,
+		timeout:   timeout,
+	}, nil
+}
+
+// PublicKey returns public key of the node.
+func (s *remoteSigner) PublicKey() *ecdsa.PublicKey {
+	return s.publicKey
+}
+
+// Address returns ethereum account address of the node.
+func (s *remoteSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(*s.publicKey)
+}
+
+// SignHash signs 32 bytes hash with remote key and verifies returned signature.
+func (s *remoteSigner) SignHash(hash []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	signature, err := s.backend.SignHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	recovered, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRemoteSignature, err)
+	}
+
+	if !recovered.Equal(s.publicKey) {
+		return nil, ErrInvalidRemoteSignature
+	}
+
+	return signature, nil
+}
+
+// Decrypt decrypts ECIES payload with remote key.
+func (s *remoteSigner) Decrypt(payload []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	return s.backend.Decrypt(ctx, payload)
+}
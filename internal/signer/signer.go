@@ -0,0 +1,133 @@
+// Package signer provides node key management.
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	ecies "github.com/ecies/go/v2"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrNoChainID error represents missing chain id for transactor.
+	ErrNoChainID = errors.New("no chain id specified")
+	// ErrNoKeyConfigured error represents missing key source in configuration.
+	ErrNoKeyConfigured = errors.New("neither private key nor keystore configured")
+)
+
+// Signer provides operations with node key without exposing the key itself.
+type Signer interface {
+	// PublicKey returns public key of the node.
+	PublicKey() *ecdsa.PublicKey
+	// Address returns ethereum account address of the node.
+	Address() common.Address
+	// SignHash signs 32 bytes hash, signature is returned in [R || S || V] format.
+	SignHash(hash []byte) ([]byte, error)
+	// Decrypt decrypts ECIES payload encrypted with public key of the node.
+	Decrypt(payload []byte) ([]byte, error)
+}
+
+// localSigner keeps private key in memory.
+type localSigner struct {
+	key      *ecdsa.PrivateKey
+	eciesKey *ecies.PrivateKey
+}
+
+// NewFromECDSA creates signer from private key.
+func NewFromECDSA(key *ecdsa.PrivateKey) Signer {
+	return &localSigner{
+		key:      key,
+		eciesKey: ecies.NewPrivateKeyFromBytes(crypto.FromECDSA(key)),
+	}
+}
+
+// NewFromHex creates signer from secp256k1 private key in hex.
+func NewFromHex(privateKeyHex string) (Signer, error) {
+	key, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromECDSA(key), nil
+}
+
+// Generate creates signer with new random private key.
+func Generate() (Signer, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromECDSA(key), nil
+}
+
+// New creates signer by configuration, keystore takes precedence over raw private key.
+func New(privateKeyHex string, keystore KeystoreConfig) (Signer, error) {
+	if keystore.Path != "" {
+		return NewFromKeystore(keystore)
+	}
+
+	if privateKeyHex != "" {
+		return NewFromHex(privateKeyHex)
+	}
+
+	return nil, ErrNoKeyConfigured
+}
+
+// PublicKey returns public key of the node.
+func (s *localSigner) PublicKey() *ecdsa.PublicKey {
+	return &s.key.PublicKey
+}
+
+// Address returns ethereum account address of the node.
+func (s *localSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+// SignHash signs 32 bytes hash.
+func (s *localSigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+// Decrypt decrypts ECIES payload.
+func (s *localSigner) Decrypt(payload []byte) ([]byte, error) {
+	return ecies.Decrypt(s.eciesKey, payload)
+}
+
+// CompressedPublicKey returns public key of signer in compressed form, as it's stored in node registry.
+func CompressedPublicKey(s Signer) []byte {
+	return crypto.CompressPubkey(s.PublicKey())
+}
+
+// NewTransactor creates transaction signer for contract bindings, analogue of bind.NewKeyedTransactorWithChainID.
+func NewTransactor(s Signer, chainID *big.Int) (*bind.TransactOpts, error) {
+	if chainID == nil {
+		return nil, ErrNoChainID
+	}
+
+	address := s.Address()
+	txSigner := types.LatestSignerForChainID(chainID)
+
+	return &bind.TransactOpts{
+		From: address,
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != address {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			signature, err := s.SignHash(txSigner.Hash(tx).Bytes())
+			if err != nil {
+				return nil, err
+			}
+
+			return tx.WithSignature(txSigner, signature)
+		},
+		Context: context.Background(),
+	}, nil
+}
@@ -0,0 +1,232 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/1inch/p2p-network/internal/encryption"
+	"github.com/1inch/p2p-network/internal/mock"
+	ecies "github.com/ecies/go/v2"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	testPrivateKey = "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"
+	testAddress    = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	testPassphrase = "test passphrase"
+	testPassEnv    = "TEST_SIGNER_KEYSTORE_PASSPHRASE"
+)
+
+type SignerTestSuite struct {
+	suite.Suite
+
+	keystorePath string
+}
+
+func (s *SignerTestSuite) SetupTest() {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	s.Require().NoError(err)
+
+	ks := keystore.NewKeyStore(s.T().TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, testPassphrase)
+	s.Require().NoError(err)
+
+	s.keystorePath = account.URL.Path
+}
+
+func TestSignerTestSuite(t *testing.T) {
+	suite.Run(t, new(SignerTestSuite))
+}
+
+func (s *SignerTestSuite) TestNewFromHex() {
+	signer, err := NewFromHex(testPrivateKey)
+	s.Require().NoError(err)
+	s.Equal(common.HexToAddress(testAddress), signer.Address())
+
+	_, err = NewFromHex("invalid")
+	s.Error(err)
+}
+
+func (s *SignerTestSuite) TestNew() {
+	_, err := New("", KeystoreConfig{})
+	s.ErrorIs(err, ErrNoKeyConfigured)
+
+	other, err := Generate()
+	s.Require().NoError(err)
+	otherKey := common.Bytes2Hex(crypto.FromECDSA(other.(*localSigner).key))
+
+	signer, err := New(otherKey, KeystoreConfig{})
+	s.Require().NoError(err)
+	s.Equal(other.Address(), signer.Address())
+
+	passphraseFile := filepath.Join(s.T().TempDir(), "passphrase.txt")
+	s.Require().NoError(os.WriteFile(passphraseFile, []byte(testPassphrase+"\n"), 0o600))
+
+	signer, err = New(otherKey, KeystoreConfig{Path: s.keystorePath, PassphraseFile: passphraseFile})
+	s.Require().NoError(err)
+	s.Equal(common.HexToAddress(testAddress), signer.Address(), "keystore must take precedence over private key")
+}
+
+func (s *SignerTestSuite) TestNewFromKeystore() {
+	passphraseFile := filepath.Join(s.T().TempDir(), "passphrase.txt")
+	s.Require().NoError(os.WriteFile(passphraseFile, []byte(testPassphrase+"\r\n"), 0o600))
+
+	testCases := []struct {
+		name        string
+		setup       func()
+		cfg         KeystoreConfig
+		expectedErr error
+	}{
+		{
+			name: "Passphrase from file",
+			cfg:  KeystoreConfig{Path: s.keystorePath, PassphraseFile: passphraseFile},
+		},
+		{
+			name:  "Passphrase from env",
+			setup: func() { s.T().Setenv(testPassEnv, testPassphrase) },
+			cfg:   KeystoreConfig{Path: s.keystorePath, PassphraseEnv: testPassEnv},
+		},
+		{
+			name:        "Env is not set",
+			cfg:         KeystoreConfig{Path: s.keystorePath, PassphraseEnv: testPassEnv},
+			expectedErr: ErrPassphraseEnvNotSet,
+		},
+		{
+			name:        "Wrong passphrase",
+			cfg:         KeystoreConfig{Path: s.keystorePath},
+			expectedErr: keystore.ErrDecrypt,
+		},
+		{
+			name:        "Missing keystore file",
+			cfg:         KeystoreConfig{Path: filepath.Join(s.T().TempDir(), "missing.json")},
+			expectedErr: os.ErrNotExist,
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.Run(testCase.name, func() {
+			if testCase.setup != nil {
+				testCase.setup()
+			}
+
+			signer, err := NewFromKeystore(testCase.cfg)
+			if testCase.expectedErr != nil {
+				s.ErrorIs(err, testCase.expectedErr)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(common.HexToAddress(testAddress), signer.Address())
+		})
+	}
+}
+
+func (s *SignerTestSuite) TestDecrypt() {
+	signer, err := Generate()
+	s.Require().NoError(err)
+
+	publicKey, err := ecies.NewPublicKeyFromBytes(crypto.FromECDSAPub(signer.PublicKey()))
+	s.Require().NoError(err)
+
+	payload := []byte("test payload")
+	encrypted, err := encryption.Encrypt(payload, publicKey)
+	s.Require().NoError(err)
+
+	decrypted, err := signer.Decrypt(encrypted)
+	s.Require().NoError(err)
+	s.Equal(payload, decrypted)
+}
+
+func (s *SignerTestSuite) TestNewTransactor() {
+	signer, err := NewFromHex(testPrivateKey)
+	s.Require().NoError(err)
+
+	_, err = NewTransactor(signer, nil)
+	s.ErrorIs(err, ErrNoChainID)
+
+	chainID := big.NewInt(1337)
+	auth, err := NewTransactor(signer, chainID)
+	s.Require().NoError(err)
+
+	tx := types.NewTransaction(0, common.HexToAddress(testAddress), big.NewInt(1), 21000, big.NewInt(1), nil)
+	signedTx, err := auth.Signer(auth.From, tx)
+	s.Require().NoError(err)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	s.Require().NoError(err)
+	s.Equal(signer.Address(), sender)
+}
+
+func (s *SignerTestSuite) TestRemoteSigner() {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	s.Require().NoError(err)
+	local := NewFromECDSA(key)
+
+	backend := mock.NewMockRemoteBackend(gomock.NewController(s.T()))
+	backend.EXPECT().PublicKey(gomock.Any()).Return(crypto.CompressPubkey(&key.PublicKey), nil)
+	backend.EXPECT().SignHash(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, hash []byte) ([]byte, error) {
+		return local.SignHash(hash)
+	})
+	backend.EXPECT().Decrypt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, payload []byte) ([]byte, error) {
+		return local.Decrypt(payload)
+	})
+
+	signer, err := NewRemote(context.Background(), backend, 0)
+	s.Require().NoError(err)
+	s.Equal(common.HexToAddress(testAddress), signer.Address())
+
+	chainID := big.NewInt(1337)
+	auth, err := NewTransactor(signer, chainID)
+	s.Require().NoError(err)
+
+	tx := types.NewTransaction(0, common.HexToAddress(testAddress), big.NewInt(1), 21000, big.NewInt(1), nil)
+	signedTx, err := auth.Signer(auth.From, tx)
+	s.Require().NoError(err)
+
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	s.Require().NoError(err)
+	s.Equal(signer.Address(), sender)
+
+	publicKey, err := ecies.NewPublicKeyFromBytes(crypto.FromECDSAPub(signer.PublicKey()))
+	s.Require().NoError(err)
+	encrypted, err := encryption.Encrypt([]byte("test payload"), publicKey)
+	s.Require().NoError(err)
+
+	decrypted, err := signer.Decrypt(encrypted)
+	s.Require().NoError(err)
+	s.Equal([]byte("test payload"), decrypted)
+}
+
+func (s *SignerTestSuite) TestRemoteSignerErrors() {
+	key, err := crypto.HexToECDSA(testPrivateKey)
+	s.Require().NoError(err)
+
+	otherKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+
+	backend := mock.NewMockRemoteBackend(gomock.NewController(s.T()))
+	backend.EXPECT().PublicKey(gomock.Any()).Return(nil, errors.New("unavailable"))
+
+	_, err = NewRemote(context.Background(), backend, 0)
+	s.Error(err)
+
+	backend.EXPECT().PublicKey(gomock.Any()).Return(crypto.FromECDSAPub(&key.PublicKey), nil)
+	backend.EXPECT().SignHash(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, hash []byte) ([]byte, error) {
+		return crypto.Sign(hash, otherKey)
+	})
+
+	signer, err := NewRemote(context.Background(), backend, 0)
+	s.Require().NoError(err)
+
+	_, err = signer.SignHash(crypto.Keccak256([]byte("test")))
+	s.ErrorIs(err, ErrInvalidRemoteSignature)
+}
@@ -115,6 +115,7 @@ func New(ctx context.Context, t *testing.T, relayerCount, resolverCount int, opt
 	}
 
 	cfg := relayer.DefaultConfig()
+	cfg.PrivateKey = relayerPrivateKeys[0]
 
 	if tnCfg.WithNodeRegistry {
 		cfg.DiscoveryConfig.WithNodeRegistry = tnCfg.WithNodeRegistry
@@ -134,18 +135,7 @@ func New(ctx context.Context, t *testing.T, relayerCount, resolverCount int, opt
 	}))
 	level.Set(slog.LevelDebug)
 
-	for i := 0; i < relayerCount; i++ {
-		relayerNode, err := relayer.New(&cfg, logger)
-		require.NoError(testNetwork.t, err)
-
-		port, err := parsePort(relayerNode.HTTPServer.Addr())
-		require.NoError(testNetwork.t, err)
-
-		testNetwork.HTTPPorts[i] = port
-
-		testNetwork.RelayerNodes[i] = relayerNode
-	}
-
+	// resolvers are registered before relayers start, so relayers load them with node registry
 	for i := 0; i < resolverCount; i++ {
 		resolverCfg := getResolverConfig()
 		resolverCfg.PrivateKey = resolverPrivateKeys[i]
@@ -167,6 +157,18 @@ func New(ctx context.Context, t *testing.T, relayerCount, resolverCount int, opt
 		testNetwork.ResolverNodes[i] = resolverNode
 	}
 
+	for i := 0; i < relayerCount; i++ {
+		relayerNode, err := relayer.New(&cfg, logger)
+		require.NoError(testNetwork.t, err)
+
+		port, err := parsePort(relayerNode.HTTPServer.Addr())
+		require.NoError(testNetwork.t, err)
+
+		testNetwork.HTTPPorts[i] = port
+
+		testNetwork.RelayerNodes[i] = relayerNode
+	}
+
 	return testNetwork
 }
 
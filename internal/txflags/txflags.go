@@ -0,0 +1,123 @@
+// Package txflags provides command line flags which override transaction policy of registry writes.
+package txflags
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/urfave/cli"
+)
+
+// ErrInvalidWei error represents amount which is not non-negative decimal number of wei.
+var ErrInvalidWei = errors.New("invalid amount in wei")
+
+// Flags override fields of registry.TxConfig, flags which are not set keep config file values.
+var Flags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "max_fee_per_gas",
+		Usage: "Max fee per gas in wei, gas price on chains without EIP-1559",
+	},
+	&cli.StringFlag{
+		Name:  "max_priority_fee_per_gas",
+		Usage: "Max priority fee per gas in wei",
+	},
+	&cli.Uint64Flag{
+		Name:  "gas_limit",
+		Usage: "Gas limit which is used instead of estimation",
+	},
+	&cli.Uint64Flag{
+		Name:  "gas_margin",
+		Usage: "Percent which is added to estimated gas, 20 by default",
+	},
+	&cli.Uint64Flag{
+		Name:  "confirmations",
+		Usage: "Count of blocks including block with transaction after which transaction is final, 1 by default",
+	},
+	&cli.DurationFlag{
+		Name:  "stuck_timeout",
+		Usage: "Time after which pending transaction is replaced with bumped fees, not replaced by default",
+	},
+	&cli.Uint64Flag{
+		Name:  "fee_bump_percent",
+		Usage: "Fee increase of replacement transaction in percent, 20 by default",
+	},
+	&cli.IntFlag{
+		Name:  "max_fee_bumps",
+		Usage: "Max count of replacements of one transaction, 3 by default",
+	},
+}
+
+// DryRun flag estimates transactions without sending them, it's not used by commands which run nodes.
+var DryRun = &cli.BoolFlag{
+	Name:  "dry_run",
+	Usage: "Estimate gas and fees without sending transaction",
+}
+
+// Apply overrides config file values of transaction policy with flags which are set.
+func Apply(c *cli.Context, cfg *registry.TxConfig) error {
+	for name, fee := range map[string]**big.Int{
+		"max_fee_per_gas":          &cfg.MaxFeePerGas,
+		"max_priority_fee_per_gas": &cfg.MaxPriorityFeePerGas,
+	} {
+		value := c.String(name)
+		if value == "" {
+			continue
+		}
+
+		wei, err := ParseWei(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		*fee = wei
+	}
+	if c.IsSet("gas_limit") {
+		cfg.GasLimit = c.Uint64("gas_limit")
+	}
+	if c.IsSet("gas_margin") {
+		cfg.GasMargin = c.Uint64("gas_margin")
+	}
+	if c.IsSet("confirmations") {
+		cfg.Confirmations = c.Uint64("confirmations")
+	}
+	if c.IsSet("stuck_timeout") {
+		cfg.StuckTimeout = c.Duration("stuck_timeout")
+	}
+	if c.IsSet("fee_bump_percent") {
+		cfg.FeeBumpPercent = c.Uint64("fee_bump_percent")
+	}
+	if c.IsSet("max_fee_bumps") {
+		cfg.MaxFeeBumps = c.Int("max_fee_bumps")
+	}
+	if c.Bool("dry_run") {
+		cfg.DryRun = true
+	}
+
+	return nil
+}
+
+// ParseWei parses non-negative decimal amount in wei.
+func ParseWei(amount string) (*big.Int, error) {
+	wei, ok := new(big.Int).SetString(amount, 10)
+	if !ok || wei.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidWei, amount)
+	}
+	return wei, nil
+}
+
+// LogDryRun logs transaction estimate and returns true if err is returned by dry run.
+func LogDryRun(logger *slog.Logger, name string, err error) bool {
+	var estimate *registry.TxEstimate
+	if !errors.As(err, &estimate) {
+		return false
+	}
+
+	logger.Info("dry run for "+name,
+		slog.Uint64("nonce", estimate.Nonce),
+		slog.Uint64("gas", estimate.Gas),
+		slog.String("max-fee-per-gas", estimate.GasFeeCap.String()),
+		slog.String("max-cost", estimate.Cost.String()))
+	return true
+}
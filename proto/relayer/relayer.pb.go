@@ -30,6 +30,7 @@ const (
 	ErrorCode_ERR_GRPC_EXECUTION_FAILED         ErrorCode = 2 // gRPC execution failure.
 	ErrorCode_ERR_RESPONSE_SERIALIZATION_FAILED ErrorCode = 3 // Failed to serialize the response.
 	ErrorCode_ERR_DATA_CHANNEL_SEND_FAILED      ErrorCode = 4 // Failed to send the response via the data channel.
+	ErrorCode_ERR_NO_CAPABLE_RESOLVER           ErrorCode = 5 // No resolver can serve the request.
 )
 
 // Enum value maps for ErrorCode.
@@ -40,6 +41,7 @@ var (
 		2: "ERR_GRPC_EXECUTION_FAILED",
 		3: "ERR_RESPONSE_SERIALIZATION_FAILED",
 		4: "ERR_DATA_CHANNEL_SEND_FAILED",
+		5: "ERR_NO_CAPABLE_RESOLVER",
 	}
 	ErrorCode_value = map[string]int32{
 		"ERR_INVALID_MESSAGE_FORMAT":        0,
@@ -47,6 +49,7 @@ var (
 		"ERR_GRPC_EXECUTION_FAILED":         2,
 		"ERR_RESPONSE_SERIALIZATION_FAILED": 3,
 		"ERR_DATA_CHANNEL_SEND_FAILED":      4,
+		"ERR_NO_CAPABLE_RESOLVER":           5,
 	}
 )
 
@@ -300,7 +303,7 @@ var file_relayer_proto_rawDesc = []byte{
 	0x65, 0x72, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
 	0x72, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x42,
-	0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2a, 0xb3, 0x01, 0x0a, 0x09, 0x45, 0x72,
+	0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2a, 0xd0, 0x01, 0x0a, 0x09, 0x45, 0x72,
 	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x52, 0x52, 0x5f, 0x49,
 	0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x4d, 0x45, 0x53, 0x53, 0x41, 0x47, 0x45, 0x5f, 0x46,
 	0x4f, 0x52, 0x4d, 0x41, 0x54, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x52, 0x52, 0x5f, 0x52,
@@ -311,11 +314,13 @@ var file_relayer_proto_rawDesc = []byte{
 	0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x41,
 	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x20, 0x0a,
 	0x1c, 0x45, 0x52, 0x52, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x4e, 0x45,
-	0x4c, 0x5f, 0x53, 0x45, 0x4e, 0x44, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x42,
-	0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x31, 0x69,
-	0x6e, 0x63, 0x68, 0x2f, 0x70, 0x32, 0x70, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4c, 0x5f, 0x53, 0x45, 0x4e, 0x44, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x12,
+	0x1b, 0x0a, 0x17, 0x45, 0x52, 0x52, 0x5f, 0x4e, 0x4f, 0x5f, 0x43, 0x41, 0x50, 0x41, 0x42, 0x4c,
+	0x45, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x45, 0x52, 0x10, 0x05, 0x42, 0x2c, 0x5a, 0x2a,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x31, 0x69, 0x6e, 0x63, 0x68,
+	0x2f, 0x70, 0x32, 0x70, 0x2d, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
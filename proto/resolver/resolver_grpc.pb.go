@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Execute_Execute_FullMethodName = "/resolver.Execute/Execute"
+	Execute_Execute_FullMethodName     = "/resolver.Execute/Execute"
+	Execute_ListMethods_FullMethodName = "/resolver.Execute/ListMethods"
 )
 
 // ExecuteClient is the client API for Execute service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ExecuteClient interface {
 	Execute(ctx context.Context, in *ResolverRequest, opts ...grpc.CallOption) (*ResolverResponse, error)
+	ListMethods(ctx context.Context, in *ListMethodsRequest, opts ...grpc.CallOption) (*ListMethodsResponse, error)
 }
 
 type executeClient struct {
@@ -47,11 +49,22 @@ func (c *executeClient) Execute(ctx context.Context, in *ResolverRequest, opts .
 	return out, nil
 }
 
+func (c *executeClient) ListMethods(ctx context.Context, in *ListMethodsRequest, opts ...grpc.CallOption) (*ListMethodsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMethodsResponse)
+	err := c.cc.Invoke(ctx, Execute_ListMethods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecuteServer is the server API for Execute service.
 // All implementations must embed UnimplementedExecuteServer
 // for forward compatibility.
 type ExecuteServer interface {
 	Execute(context.Context, *ResolverRequest) (*ResolverResponse, error)
+	ListMethods(context.Context, *ListMethodsRequest) (*ListMethodsResponse, error)
 	mustEmbedUnimplementedExecuteServer()
 }
 
@@ -65,6 +78,9 @@ type UnimplementedExecuteServer struct{}
 func (UnimplementedExecuteServer) Execute(context.Context, *ResolverRequest) (*ResolverResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
 }
+func (UnimplementedExecuteServer) ListMethods(context.Context, *ListMethodsRequest) (*ListMethodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMethods not implemented")
+}
 func (UnimplementedExecuteServer) mustEmbedUnimplementedExecuteServer() {}
 func (UnimplementedExecuteServer) testEmbeddedByValue()                 {}
 
@@ -104,6 +120,24 @@ func _Execute_Execute_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Execute_ListMethods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMethodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecuteServer).ListMethods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Execute_ListMethods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecuteServer).ListMethods(ctx, req.(*ListMethodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Execute_ServiceDesc is the grpc.ServiceDesc for Execute service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +149,112 @@ var Execute_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Execute",
 			Handler:    _Execute_Execute_Handler,
 		},
+		{
+			MethodName: "ListMethods",
+			Handler:    _Execute_ListMethods_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "resolver.proto",
+}
+
+const (
+	Liveness_Heartbeat_FullMethodName = "/resolver.Liveness/Heartbeat"
+)
+
+// LivenessClient is the client API for Liveness service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LivenessClient interface {
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+}
+
+type livenessClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLivenessClient(cc grpc.ClientConnInterface) LivenessClient {
+	return &livenessClient{cc}
+}
+
+func (c *livenessClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, Liveness_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LivenessServer is the server API for Liveness service.
+// All implementations must embed UnimplementedLivenessServer
+// for forward compatibility.
+type LivenessServer interface {
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	mustEmbedUnimplementedLivenessServer()
+}
+
+// UnimplementedLivenessServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLivenessServer struct{}
+
+func (UnimplementedLivenessServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedLivenessServer) mustEmbedUnimplementedLivenessServer() {}
+func (UnimplementedLivenessServer) testEmbeddedByValue()                  {}
+
+// UnsafeLivenessServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LivenessServer will
+// result in compilation errors.
+type UnsafeLivenessServer interface {
+	mustEmbedUnimplementedLivenessServer()
+}
+
+func RegisterLivenessServer(s grpc.ServiceRegistrar, srv LivenessServer) {
+	// If the following call pancis, it indicates UnimplementedLivenessServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Liveness_ServiceDesc, srv)
+}
+
+func _Liveness_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LivenessServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Liveness_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LivenessServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Liveness_ServiceDesc is the grpc.ServiceDesc for Liveness service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Liveness_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resolver.Liveness",
+	HandlerType: (*LivenessServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Liveness_Heartbeat_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "resolver.proto",
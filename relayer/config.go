@@ -1,14 +1,22 @@
 package relayer
 
-import "time"
+import (
+	"time"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+)
 
 // Config represents the configuration for the relayer node.
 type Config struct {
-	LogLevel        string          `yaml:"log_level"`
-	HTTPEndpoint    string          `yaml:"http_endpoint"`
-	PrivateKey      string          `yaml:"private_key"`
-	DiscoveryConfig DiscoveryConfig `yaml:"discovery"`
-	WebrtcConfig    WebrtcConfig    `yaml:"webrtc"`
+	LogLevel     string `yaml:"log_level"`
+	HTTPEndpoint string `yaml:"http_endpoint"`
+	// PrivateKey is used for registration in node registry, not required for read-only access
+	PrivateKey string `yaml:"private_key"`
+	// Keystore takes precedence over PrivateKey
+	Keystore        signer.KeystoreConfig `yaml:"keystore"`
+	DiscoveryConfig DiscoveryConfig       `yaml:"discovery"`
+	WebrtcConfig    WebrtcConfig          `yaml:"webrtc"`
 }
 
 // WebrtcConfig represents the configuration for webrtc server
@@ -31,6 +39,26 @@ type DiscoveryConfig struct {
 	RpcUrl           string `yaml:"rpc_url"`
 	WithNodeRegistry bool   `yaml:"with_node_registry"`
 	ContractAddress  string `yaml:"contract_address"`
+	// PollInterval is interval between checks of node registry events
+	PollInterval time.Duration `yaml:"poll_interval"`
+	// RotationLookback is count of blocks searched for resolver key rotations on load of node registry
+	RotationLookback uint64 `yaml:"rotation_lookback"`
+	// Region of the relayer which is registered in node registry
+	Region string `yaml:"region"`
+	// Capacity is max count of client connections which is registered in node registry
+	Capacity uint32 `yaml:"capacity"`
+	// Strategy of relayer selection for clients: round_robin or health
+	Strategy string `yaml:"strategy"`
+	// HealthCheckInterval is interval between health checks of registered relayers
+	HealthCheckInterval time.Duration `yaml:"health_check_interval"`
+	// HeartbeatInterval is interval between heartbeat requests to registered resolvers, liveness is not tracked if zero
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"`
+	// StaleAfter is time without heartbeat after which resolver is unavailable, 3 heartbeat intervals if zero
+	StaleAfter time.Duration `yaml:"stale_after"`
+	// Transaction is fee, gas and replacement policy of relayer registration transactions
+	Transaction registry.TxConfig `yaml:"transaction"`
+	// NodeList is node list file which is used instead of node registry contract if path is set
+	NodeList registry.FileConfig `yaml:"node_list"`
 }
 
 // RetryConfig represents the configuration for retry request to resolver
@@ -55,11 +83,11 @@ func DefaultConfig() Config {
 	return Config{
 		LogLevel:     "DEBUG",
 		HTTPEndpoint: "127.0.0.1:0",
-		PrivateKey:   "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
 		DiscoveryConfig: DiscoveryConfig{
 			RpcUrl:           "http://127.0.0.1:8545",
 			WithNodeRegistry: false,
 			ContractAddress:  "0x5fbdb2315678afecb367f032d93f642f64180aa3",
+			Strategy:         "round_robin",
 		},
 		WebrtcConfig: WebrtcConfig{
 			ICEServers: []ICEServerConfig{{
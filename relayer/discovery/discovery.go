@@ -0,0 +1,235 @@
+// Package discovery selects relayer node for clients.
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/1inch/p2p-network/contracts"
+)
+
+const (
+	// StrategyRoundRobin selects relayers in turn.
+	StrategyRoundRobin = "round_robin"
+	// StrategyHealth selects healthy relayer with the lowest health check latency.
+	StrategyHealth = "health"
+
+	defaultHealthCheckInterval = 30 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+)
+
+var (
+	// ErrNoRelayers is returned when no relayer is registered.
+	ErrNoRelayers = errors.New("no relayer registered")
+	// ErrUnknownStrategy is returned for unsupported selection strategy.
+	ErrUnknownStrategy = errors.New("unknown selection strategy")
+)
+
+// Registry provides registered relayers.
+type Registry interface {
+	GetRelayers() ([]contracts.NodeRegistryRelayer, error)
+}
+
+// Config represents the configuration for relayer selection.
+type Config struct {
+	// Strategy is default selection strategy, round_robin if empty
+	Strategy string
+	// HealthCheckInterval is interval between health checks of registered relayers
+	HealthCheckInterval time.Duration
+}
+
+// Hint represents client preferences for relayer selection.
+type Hint struct {
+	// Region is preferred region, ignored if no relayer is registered in the region
+	Region string
+	// Strategy overrides default selection strategy
+	Strategy string
+}
+
+type relayerHealth struct {
+	healthy bool
+	latency time.Duration
+}
+
+// Service selects relayer from registry.
+type Service struct {
+	logger   *slog.Logger
+	registry Registry
+	strategy string
+	interval time.Duration
+	client   *http.Client
+
+	next atomic.Uint64
+
+	mu sync.RWMutex
+	// health is keyed by relayer IP
+	health map[string]relayerHealth
+}
+
+// New creates relayer selection service.
+func New(logger *slog.Logger, registry Registry, cfg Config) (*Service, error) {
+	strategy := cfg.Strategy
+	if strategy == "" {
+		strategy = StrategyRoundRobin
+	}
+	if err := validateStrategy(strategy); err != nil {
+		return nil, err
+	}
+
+	interval := cfg.HealthCheckInterval
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	return &Service{
+		logger:   logger.WithGroup("discovery"),
+		registry: registry,
+		strategy: strategy,
+		interval: interval,
+		client:   &http.Client{Timeout: defaultHealthCheckTimeout},
+		health:   make(map[string]relayerHealth),
+	}, nil
+}
+
+// Pick selects relayer by client hint and strategy.
+func (s *Service) Pick(hint Hint) (contracts.NodeRegistryRelayer, error) {
+	strategy := hint.Strategy
+	if strategy == "" {
+		strategy = s.strategy
+	}
+	if err := validateStrategy(strategy); err != nil {
+		return contracts.NodeRegistryRelayer{}, err
+	}
+
+	relayers, err := s.registry.GetRelayers()
+	if err != nil {
+		return contracts.NodeRegistryRelayer{}, err
+	}
+
+	if hint.Region != "" {
+		var inRegion []contracts.NodeRegistryRelayer
+		for _, relayer := range relayers {
+			if relayer.Region == hint.Region {
+				inRegion = append(inRegion, relayer)
+			}
+		}
+
+		if len(inRegion) > 0 {
+			relayers = inRegion
+		}
+	}
+
+	if len(relayers) == 0 {
+		return contracts.NodeRegistryRelayer{}, ErrNoRelayers
+	}
+
+	if strategy == StrategyHealth {
+		if relayer, ok := s.healthiest(relayers); ok {
+			return relayer, nil
+		}
+		// nothing is measured yet, fallback to round robin
+	}
+
+	index := (s.next.Add(1) - 1) % uint64(len(relayers))
+	return relayers[index], nil
+}
+
+// Run checks health of registered relayers until context is cancelled.
+func (s *Service) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.checkHealth(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) healthiest(relayers []contracts.NodeRegistryRelayer) (contracts.NodeRegistryRelayer, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var best contracts.NodeRegistryRelayer
+	var bestLatency time.Duration
+	found := false
+	for _, relayer := range relayers {
+		health, ok := s.health[relayer.Ip]
+		if !ok || !health.healthy {
+			continue
+		}
+
+		if !found || health.latency < bestLatency {
+			best, bestLatency, found = relayer, health.latency, true
+		}
+	}
+
+	return best, found
+}
+
+func (s *Service) checkHealth(ctx context.Context) {
+	relayers, err := s.registry.GetRelayers()
+	if err != nil {
+		s.logger.Warn("failed to get relayers for health check", slog.Any("err", err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	health := make([]relayerHealth, len(relayers))
+	for i, relayer := range relayers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			health[i] = s.probe(ctx, relayer.Ip)
+		}()
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// drop deregistered relayers
+	s.health = make(map[string]relayerHealth, len(relayers))
+	for i, relayer := range relayers {
+		s.health[relayer.Ip] = health[i]
+	}
+}
+
+func (s *Service) probe(ctx context.Context, ip string) relayerHealth {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/health", ip), nil)
+	if err != nil {
+		return relayerHealth{}
+	}
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Debug("relayer health check failed", slog.String("ip", ip), slog.Any("err", err))
+		return relayerHealth{}
+	}
+	defer resp.Body.Close()
+
+	return relayerHealth{
+		healthy: resp.StatusCode == http.StatusOK,
+		latency: time.Since(start),
+	}
+}
+
+func validateStrategy(strategy string) error {
+	switch strategy {
+	case StrategyRoundRobin, StrategyHealth:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownStrategy, strategy)
+	}
+}
@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/1inch/p2p-network/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticRegistry []contracts.NodeRegistryRelayer
+
+func (r staticRegistry) GetRelayers() ([]contracts.NodeRegistryRelayer, error) {
+	return r, nil
+}
+
+func newTestService(t *testing.T, relayers staticRegistry, strategy string) *Service {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	service, err := New(logger, relayers, Config{Strategy: strategy})
+	require.NoError(t, err)
+	return service
+}
+
+func TestPickRoundRobin(t *testing.T) {
+	service := newTestService(t, staticRegistry{
+		{Ip: "127.0.0.1:8080", Region: "eu"},
+		{Ip: "127.0.0.1:8081", Region: "us"},
+		{Ip: "127.0.0.1:8082", Region: "eu"},
+	}, "")
+
+	var picked []string
+	for range 4 {
+		relayer, err := service.Pick(Hint{})
+		require.NoError(t, err)
+		picked = append(picked, relayer.Ip)
+	}
+	assert.Equal(t, []string{"127.0.0.1:8080", "127.0.0.1:8081", "127.0.0.1:8082", "127.0.0.1:8080"}, picked)
+
+	relayer, err := service.Pick(Hint{Region: "us"})
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:8081", relayer.Ip)
+
+	relayer, err = service.Pick(Hint{Region: "asia"})
+	require.NoError(t, err, "unknown region must be ignored")
+	assert.NotEmpty(t, relayer.Ip)
+
+	_, err = service.Pick(Hint{Strategy: "random"})
+	assert.ErrorIs(t, err, ErrUnknownStrategy)
+}
+
+func TestPickNoRelayers(t *testing.T) {
+	service := newTestService(t, staticRegistry{}, StrategyHealth)
+
+	_, err := service.Pick(Hint{})
+	assert.ErrorIs(t, err, ErrNoRelayers)
+}
+
+func TestPickHealth(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	healthyIp := strings.TrimPrefix(healthy.URL, "http://")
+	unhealthyIp := strings.TrimPrefix(unhealthy.URL, "http://")
+	service := newTestService(t, staticRegistry{
+		{Ip: unhealthyIp},
+		{Ip: "127.0.0.1:1"},
+		{Ip: healthyIp},
+	}, StrategyHealth)
+
+	service.checkHealth(context.Background())
+
+	for range 3 {
+		relayer, err := service.Pick(Hint{})
+		require.NoError(t, err)
+		assert.Equal(t, healthyIp, relayer.Ip)
+	}
+
+	relayer, err := service.Pick(Hint{Strategy: StrategyRoundRobin})
+	require.NoError(t, err)
+	assert.Equal(t, unhealthyIp, relayer.Ip, "hint must override default strategy")
+}
+
+func TestNewUnknownStrategy(t *testing.T) {
+	_, err := New(slog.New(slog.NewTextHandler(os.Stdout, nil)), staticRegistry{}, Config{Strategy: "random"})
+	assert.ErrorIs(t, err, ErrUnknownStrategy)
+}
@@ -25,6 +25,10 @@ var grpcClientConfig = `{
 	}
 }`
 
+// resolverKeyCacheTTL is how long resolved public key is used without lookup in registry,
+// so rotated keys are dropped from cache after registry stops resolving them.
+const resolverKeyCacheTTL = time.Minute
+
 var (
 	// ErrResolverLookupFailed is returned when the registry client fails to resolve a public key.
 	ErrResolverLookupFailed = errors.New("resolver lookup failed")
@@ -34,19 +38,33 @@ var (
 	ErrGRPCConnectionCloseFailed = errors.New("gRPC connection close failed")
 )
 
+// Registry resolves resolver address by public key.
+type Registry interface {
+	GetResolver(publicKey []byte) (string, error)
+}
+
+type resolverKey struct {
+	address   string
+	expiresAt time.Time
+}
+
 // Client wraps the gRPC connection and Execute service client.
 type Client struct {
-	logger         *slog.Logger
-	conns          map[string]*grpc.ClientConn
-	registryClient *registry.Client
+	logger *slog.Logger
+	// conns are keyed by resolver address, so old and new keys of rotated resolver share connection
+	conns map[string]*grpc.ClientConn
+	// keys are keyed by resolver public key
+	keys           map[string]resolverKey
+	registryClient Registry
 	mu             sync.Mutex
 }
 
 // New initializes a new gRPC client with Execute service.
-func New(logger *slog.Logger, registryClient *registry.Client) *Client {
+func New(logger *slog.Logger, registryClient Registry) *Client {
 	return &Client{
 		logger:         logger.WithGroup("grpc-server"),
 		conns:          make(map[string]*grpc.ClientConn),
+		keys:           make(map[string]resolverKey),
 		registryClient: registryClient,
 	}
 }
@@ -67,6 +85,22 @@ func (c *Client) Execute(ctx context.Context, publicKey []byte, req *pb.Resolver
 	return response, nil
 }
 
+// Heartbeat wraps the Heartbeat RPC call.
+func (c *Client) Heartbeat(ctx context.Context, publicKey, challenge []byte) (*pb.HeartbeatResponse, error) {
+	conn, err := c.getConn(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	client := pb.NewLivenessClient(conn)
+	response, err := client.Heartbeat(ctx, &pb.HeartbeatRequest{Challenge: challenge})
+	if err != nil {
+		return nil, fmt.Errorf("%w: publicKey %s: %w", ErrGRPCExecutionFailed, hex.EncodeToString(publicKey), err)
+	}
+
+	return response, nil
+}
+
 // Close closes the gRPC connection.
 func (c *Client) Close() error {
 	c.mu.Lock()
@@ -90,15 +124,76 @@ func (c *Client) getConn(publicKey []byte) (*grpc.ClientConn, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if conn, exists := c.conns[string(publicKey)]; exists {
-		return conn, nil
+	now := time.Now()
+	if key, exists := c.keys[string(publicKey)]; exists && now.Before(key.expiresAt) {
+		return c.conns[key.address], nil
 	}
 
 	address, err := c.registryClient.GetResolver(publicKey)
 	if err != nil {
+		delete(c.keys, string(publicKey))
 		return nil, fmt.Errorf("%w: publicKey %s: %w", ErrResolverLookupFailed, hex.EncodeToString(publicKey), err)
 	}
 
+	c.keys[string(publicKey)] = resolverKey{address: address, expiresAt: now.Add(resolverKeyCacheTTL)}
+	conn, err := c.dial(address)
+	if err != nil {
+		delete(c.keys, string(publicKey))
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// Watch applies registry events to connections until events channel is closed:
+// connections of removed resolvers are closed and connections of new resolvers are warmed up.
+func (c *Client) Watch(events <-chan registry.RegistryEvent) {
+	for event := range events {
+		c.handleEvent(event)
+	}
+}
+
+func (c *Client) handleEvent(event registry.RegistryEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch event.Type {
+	case registry.EventResolverRegistered, registry.EventResolverUpdated:
+		oldKey, exists := c.keys[string(event.PublicKey)]
+		c.keys[string(event.PublicKey)] = resolverKey{address: event.IP, expiresAt: time.Now().Add(resolverKeyCacheTTL)}
+		if exists && oldKey.address != event.IP {
+			c.closeUnused(oldKey.address)
+		}
+
+		conn, err := c.dial(event.IP)
+		if err != nil {
+			delete(c.keys, string(event.PublicKey))
+			c.logger.Warn("failed to warm up resolver connection", slog.String("address", event.IP), slog.Any("err", err))
+			return
+		}
+		conn.Connect()
+	case registry.EventResolverRemoved:
+		key, exists := c.keys[string(event.PublicKey)]
+		if !exists {
+			return
+		}
+
+		delete(c.keys, string(event.PublicKey))
+		c.closeUnused(key.address)
+	case registry.EventResolverKeyRotated:
+		// new key shares connection with old key, old key expires by TTL
+		if key, exists := c.keys[string(event.PublicKey)]; exists {
+			c.keys[string(event.NewPublicKey)] = resolverKey{address: key.address, expiresAt: time.Now().Add(resolverKeyCacheTTL)}
+		}
+	}
+}
+
+// dial returns connection to the resolver address, creating it if needed, mu must be held.
+func (c *Client) dial(address string) (*grpc.ClientConn, error) {
+	if conn, exists := c.conns[address]; exists {
+		return conn, nil
+	}
+
 	conn, err := grpc.NewClient(address,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithDefaultServiceConfig(grpcClientConfig),
@@ -110,10 +205,29 @@ func (c *Client) getConn(publicKey []byte) (*grpc.ClientConn, error) {
 		return nil, err
 	}
 
-	c.conns[string(publicKey)] = conn
+	c.conns[address] = conn
 	return conn, nil
 }
 
+// closeUnused closes connection to the address if no resolver key refers to it, mu must be held.
+func (c *Client) closeUnused(address string) {
+	for _, key := range c.keys {
+		if key.address == address {
+			return
+		}
+	}
+
+	conn, exists := c.conns[address]
+	if !exists {
+		return
+	}
+
+	delete(c.conns, address)
+	if err := conn.Close(); err != nil {
+		c.logger.Warn("failed to close resolver connection", slog.String("address", address), slog.Any("err", err))
+	}
+}
+
 func loggingCallHandler(ctx context.Context, logger *slog.Logger, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	start := time.Now()
 
@@ -0,0 +1,180 @@
+// Package liveness tracks resolvers which answer signed heartbeats.
+package liveness
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	pb "github.com/1inch/p2p-network/proto/resolver"
+)
+
+const (
+	// defaultStaleIntervals is count of heartbeat intervals without heartbeat after which resolver is stale
+	defaultStaleIntervals   = 3
+	defaultHeartbeatTimeout = 5 * time.Second
+	challengeLength         = 32
+)
+
+var (
+	// ErrUnexpectedPublicKey is returned when heartbeat is signed by another resolver key.
+	ErrUnexpectedPublicKey = errors.New("heartbeat is signed by unexpected public key")
+	// ErrHeartbeatTimestamp is returned when heartbeat time is too far from local time.
+	ErrHeartbeatTimestamp = errors.New("heartbeat timestamp is out of accepted window")
+)
+
+// Registry provides registered resolvers.
+type Registry interface {
+	GetResolvers() ([][]byte, []*big.Int, error)
+}
+
+// Client requests heartbeat from resolver.
+type Client interface {
+	Heartbeat(ctx context.Context, publicKey, challenge []byte) (*pb.HeartbeatResponse, error)
+}
+
+// Config represents the configuration for resolver liveness tracking.
+type Config struct {
+	// Interval is interval between heartbeats, liveness is not tracked if zero
+	Interval time.Duration
+	// StaleAfter is time since the last heartbeat after which resolver is unavailable, 3 intervals if zero
+	StaleAfter time.Duration
+}
+
+// Monitor requests heartbeats from registered resolvers and marks resolvers without recent heartbeat as stale.
+type Monitor struct {
+	logger     *slog.Logger
+	registry   Registry
+	client     Client
+	interval   time.Duration
+	staleAfter time.Duration
+
+	mu sync.RWMutex
+	// lastSeen is keyed by resolver public key, zero time means resolver never answered
+	lastSeen map[string]time.Time
+}
+
+// New creates resolver liveness monitor.
+func New(logger *slog.Logger, registry Registry, client Client, cfg Config) *Monitor {
+	staleAfter := cfg.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = defaultStaleIntervals * cfg.Interval
+	}
+
+	return &Monitor{
+		logger:     logger.WithGroup("liveness"),
+		registry:   registry,
+		client:     client,
+		interval:   cfg.Interval,
+		staleAfter: staleAfter,
+		lastSeen:   make(map[string]time.Time),
+	}
+}
+
+// IsAlive reports whether resolver answered heartbeat recently, resolvers which are not checked yet are alive.
+func (m *Monitor) IsAlive(publicKey []byte) bool {
+	if m.interval <= 0 {
+		return true
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lastSeen, checked := m.lastSeen[string(publicKey)]
+	return !checked || time.Since(lastSeen) <= m.staleAfter
+}
+
+// Run requests heartbeats from registered resolvers until context is cancelled.
+func (m *Monitor) Run(ctx context.Context) error {
+	if m.interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.checkAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) checkAll(ctx context.Context) {
+	publicKeys, _, err := m.registry.GetResolvers()
+	if err != nil {
+		m.logger.Warn("failed to get resolvers for heartbeat", slog.Any("err", err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	alive := make([]bool, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := m.heartbeat(ctx, publicKey)
+			if err != nil {
+				m.logger.Debug("resolver heartbeat failed", slog.String("publicKey", hex.EncodeToString(publicKey)), slog.Any("err", err))
+			}
+			alive[i] = err == nil
+		}()
+	}
+	wg.Wait()
+
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// drop deregistered resolvers
+	lastSeen := make(map[string]time.Time, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		if alive[i] {
+			lastSeen[string(publicKey)] = now
+			continue
+		}
+
+		// zero time is kept for resolvers which never answered
+		lastSeen[string(publicKey)] = m.lastSeen[string(publicKey)]
+	}
+	m.lastSeen = lastSeen
+}
+
+// heartbeat requests heartbeat with random challenge and verifies its signature.
+func (m *Monitor) heartbeat(ctx context.Context, publicKey []byte) error {
+	challenge := make([]byte, challengeLength)
+	if _, err := rand.Read(challenge); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, min(defaultHeartbeatTimeout, m.interval))
+	defer cancel()
+
+	resp, err := m.client.Heartbeat(ctx, publicKey, challenge)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(resp.GetPublicKey(), publicKey) {
+		return ErrUnexpectedPublicKey
+	}
+
+	if skew := time.Since(time.Unix(resp.GetTimestamp(), 0)).Abs(); skew > m.staleAfter {
+		return ErrHeartbeatTimestamp
+	}
+
+	return registry.VerifyHeartbeat(publicKey, challenge, resp.GetTimestamp(), resp.GetSignature())
+}
@@ -0,0 +1,92 @@
+package liveness
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticRegistry [][]byte
+
+func (r staticRegistry) GetResolvers() ([][]byte, []*big.Int, error) {
+	return r, make([]*big.Int, len(r)), nil
+}
+
+// signerClient answers heartbeats with signers keyed by requested public key, other keys are unavailable.
+type signerClient map[string]signer.Signer
+
+func (c signerClient) Heartbeat(_ context.Context, publicKey, challenge []byte) (*pb.HeartbeatResponse, error) {
+	nodeSigner, ok := c[string(publicKey)]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+
+	signerPublicKey := signer.CompressedPublicKey(nodeSigner)
+	timestamp := time.Now().Unix()
+	signature, err := nodeSigner.SignHash(registry.HeartbeatHash(challenge, timestamp, signerPublicKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.HeartbeatResponse{PublicKey: signerPublicKey, Timestamp: timestamp, Signature: signature}, nil
+}
+
+func generatePublicKey(t *testing.T) (signer.Signer, []byte) {
+	nodeSigner, err := signer.Generate()
+	require.NoError(t, err)
+	return nodeSigner, signer.CompressedPublicKey(nodeSigner)
+}
+
+func TestMonitor(t *testing.T) {
+	aliveSigner, alivePublicKey := generatePublicKey(t)
+	_, deadPublicKey := generatePublicKey(t)
+	_, impostorPublicKey := generatePublicKey(t)
+	otherSigner, _ := generatePublicKey(t)
+	_, newPublicKey := generatePublicKey(t)
+
+	monitor := New(slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		staticRegistry{alivePublicKey, deadPublicKey, impostorPublicKey},
+		signerClient{
+			string(alivePublicKey): aliveSigner,
+			// answers with another key, e.g. endpoint is reused by another resolver
+			string(impostorPublicKey): otherSigner,
+		},
+		Config{Interval: time.Second})
+
+	for _, publicKey := range [][]byte{alivePublicKey, deadPublicKey, impostorPublicKey} {
+		assert.True(t, monitor.IsAlive(publicKey), "resolvers are alive until checked")
+	}
+
+	monitor.checkAll(context.Background())
+
+	assert.True(t, monitor.IsAlive(alivePublicKey))
+	assert.False(t, monitor.IsAlive(deadPublicKey))
+	assert.False(t, monitor.IsAlive(impostorPublicKey))
+	assert.True(t, monitor.IsAlive(newPublicKey), "resolver registered after check is alive until checked")
+
+	// resolver stays alive until stale period passes since the last heartbeat
+	monitor.client = signerClient{}
+	monitor.checkAll(context.Background())
+	assert.True(t, monitor.IsAlive(alivePublicKey))
+
+	monitor.lastSeen[string(alivePublicKey)] = time.Now().Add(-4 * time.Second)
+	assert.False(t, monitor.IsAlive(alivePublicKey))
+}
+
+func TestMonitorDisabled(t *testing.T) {
+	_, publicKey := generatePublicKey(t)
+
+	monitor := New(slog.New(slog.NewTextHandler(os.Stdout, nil)), staticRegistry{publicKey}, signerClient{}, Config{})
+	require.NoError(t, monitor.Run(context.Background()), "disabled monitor returns immediately")
+	assert.True(t, monitor.IsAlive(publicKey))
+}
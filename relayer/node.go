@@ -5,22 +5,33 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math/big"
 	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/1inch/p2p-network/relayer/discovery"
 	"github.com/1inch/p2p-network/relayer/grpc"
 	"github.com/1inch/p2p-network/relayer/httpapi"
+	"github.com/1inch/p2p-network/relayer/liveness"
 	"github.com/1inch/p2p-network/relayer/metrics"
 	webrtcserver "github.com/1inch/p2p-network/relayer/webrtc"
 	"github.com/pion/webrtc/v4"
 	"golang.org/x/sync/errgroup"
 )
 
+var (
+	errInvalidQueryParam    = errors.New("query param must be non-negative integer")
+	errNodeListWithRegistry = errors.New("node list can't be used with node registry registration")
+)
+
 type responseRecorder struct {
 	http.ResponseWriter
 	statusCode int
@@ -32,6 +43,12 @@ type Relayer struct {
 	Logger       *slog.Logger
 	WebRTCServer *webrtcserver.Server
 	HTTPServer   *httpapi.Server
+	Discovery    *discovery.Service
+	Liveness     *liveness.Monitor
+	Registry     registry.Registry
+	// RegistryClient is nil if node list file is used instead of node registry contract
+	RegistryClient *registry.Client
+	GRPCClient     *grpc.Client
 }
 
 // New initializes a new Relayer instance with provided configuration and logger.
@@ -40,6 +57,27 @@ func New(cfg *Config, logger *slog.Logger) (*Relayer, error) {
 		return nil, webrtcserver.ErrInvalidICEServer
 	}
 
+	registryClient, nodeRegistry, err := newRegistry(cfg, logger)
+	if err != nil {
+		logger.Error("failed to initialize node registry", slog.Any("err", err))
+		return nil, err
+	}
+
+	discoveryService, err := discovery.New(logger, nodeRegistry, discovery.Config{
+		Strategy:            cfg.DiscoveryConfig.Strategy,
+		HealthCheckInterval: cfg.DiscoveryConfig.HealthCheckInterval,
+	})
+	if err != nil {
+		logger.Error("failed to initialize discovery service", slog.Any("err", err))
+		return nil, err
+	}
+
+	grpcClient := grpc.New(logger, nodeRegistry)
+	livenessMonitor := liveness.New(logger, nodeRegistry, grpcClient, liveness.Config{
+		Interval:   cfg.DiscoveryConfig.HeartbeatInterval,
+		StaleAfter: cfg.DiscoveryConfig.StaleAfter,
+	})
+
 	sdpRequests := make(chan webrtcserver.SDPRequest)
 	iceCandidates := make(chan webrtcserver.ICECandidate)
 	var httpServer *httpapi.Server
@@ -53,36 +91,7 @@ func New(cfg *Config, logger *slog.Logger) (*Relayer, error) {
 		mux := http.NewServeMux()
 		mux.HandleFunc("POST /sdp", webrtcserver.SDPHandler(logger, sdpRequests))
 		mux.HandleFunc("POST /candidate", webrtcserver.CandidateHandler(logger, iceCandidates))
-		mux.HandleFunc("GET /relayer", func(w http.ResponseWriter, r *http.Request) {
-			client, err := registry.Dial(r.Context(), &registry.Config{
-				DialURI:         cfg.DiscoveryConfig.RpcUrl,
-				PrivateKey:      cfg.PrivateKey,
-				ContractAddress: cfg.DiscoveryConfig.ContractAddress,
-			})
-			if err != nil {
-				http.Error(w, "failed to connect to Ethereum node", http.StatusInternalServerError)
-				return
-			}
-			defer client.Close()
-
-			ip, resolvers, err := client.GetRelayer()
-			if err != nil {
-				http.Error(w, "failed to get closest relayer node", http.StatusInternalServerError)
-				return
-			}
-
-			resp := struct {
-				IPAddress string   `json:"ip_address"`
-				Resolvers [][]byte `json:"resolvers"`
-			}{IPAddress: ip, Resolvers: resolvers}
-
-			w.Header().Set("Content-Type", "application/json")
-			err = json.NewEncoder(w).Encode(resp)
-			if err != nil {
-				http.Error(w, "failed to encode response", http.StatusInternalServerError)
-				return
-			}
-		})
+		mux.HandleFunc("GET /relayer", relayerHandler(logger, nodeRegistry, livenessMonitor, discoveryService))
 		mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
 			logger.Debug("called /health endpoint")
 		})
@@ -94,19 +103,8 @@ func New(cfg *Config, logger *slog.Logger) (*Relayer, error) {
 	var werbrtcServer *webrtcserver.Server
 	{
 		// setup webrtc listener.
-		var err error
-		ctx := context.Background()
-		registryClient, err := registry.Dial(ctx, &registry.Config{
-			DialURI:         cfg.DiscoveryConfig.RpcUrl,
-			PrivateKey:      cfg.PrivateKey,
-			ContractAddress: cfg.DiscoveryConfig.ContractAddress,
-		})
-		if err != nil {
-			logger.Error("failed to initialize registry client", slog.Any("err", err))
-			return nil, err
-		}
-
-		werbrtcServer, err = webrtcserver.New(logger.WithGroup("webrtc"), iceServerByConfig(*cfg), grpc.New(logger, registryClient), sdpRequests, iceCandidates, webrtcOptionsByConfig(*cfg)...)
+		werbrtcServer, err = webrtcserver.New(logger.WithGroup("webrtc"), iceServerByConfig(*cfg), grpcClient, sdpRequests, iceCandidates,
+			append(webrtcOptionsByConfig(*cfg), webrtcserver.WithCapabilities(nodeRegistry), webrtcserver.WithLiveness(livenessMonitor))...)
 
 		if err != nil {
 			logger.Error("failed to create webrtc server", slog.Any("err", err))
@@ -115,10 +113,15 @@ func New(cfg *Config, logger *slog.Logger) (*Relayer, error) {
 	}
 
 	return &Relayer{
-		Config:       cfg,
-		Logger:       logger,
-		HTTPServer:   httpServer,
-		WebRTCServer: werbrtcServer,
+		Config:         cfg,
+		Logger:         logger,
+		HTTPServer:     httpServer,
+		WebRTCServer:   werbrtcServer,
+		Discovery:      discoveryService,
+		Liveness:       livenessMonitor,
+		Registry:       nodeRegistry,
+		RegistryClient: registryClient,
+		GRPCClient:     grpcClient,
 	}, nil
 }
 
@@ -141,6 +144,29 @@ func (r *Relayer) Run(ctx context.Context) error {
 		return nil
 	})
 
+	group.Go(func() error {
+		r.Logger.Info("node registry sync started")
+		return r.Registry.Run(childCtx)
+	})
+
+	if r.RegistryClient != nil {
+		group.Go(func() error {
+			r.Logger.Info("node registry watch started")
+			r.GRPCClient.Watch(r.RegistryClient.Watch(childCtx))
+			return nil
+		})
+	}
+
+	group.Go(func() error {
+		r.Logger.Info("resolver liveness tracking started", slog.Any("interval", r.Config.DiscoveryConfig.HeartbeatInterval))
+		return r.Liveness.Run(childCtx)
+	})
+
+	group.Go(func() error {
+		r.Logger.Info("relayer discovery started", slog.String("strategy", r.Config.DiscoveryConfig.Strategy))
+		return r.Discovery.Run(childCtx)
+	})
+
 	group.Go(func() error {
 		r.Logger.Info("webrtc server started")
 		err := r.WebRTCServer.Run(childCtx)
@@ -161,23 +187,194 @@ func (r *Relayer) Run(ctx context.Context) error {
 	return nil
 }
 
+// newRegistry returns node list file registry if node list path is set, otherwise node registry contract client and cache.
+func newRegistry(cfg *Config, logger *slog.Logger) (*registry.Client, registry.Registry, error) {
+	if cfg.DiscoveryConfig.NodeList.Path != "" {
+		if cfg.DiscoveryConfig.WithNodeRegistry {
+			return nil, nil, errNodeListWithRegistry
+		}
+
+		fileRegistry, err := registry.NewFileRegistry(logger, cfg.DiscoveryConfig.NodeList)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, fileRegistry, nil
+	}
+
+	registryClient, err := registry.Dial(context.Background(), &registry.Config{
+		DialURI:         cfg.DiscoveryConfig.RpcUrl,
+		ContractAddress: cfg.DiscoveryConfig.ContractAddress,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	registryCache := registry.NewCache(registryClient, logger, registry.CacheConfig{
+		PollInterval:     cfg.DiscoveryConfig.PollInterval,
+		RotationLookback: cfg.DiscoveryConfig.RotationLookback,
+	})
+	if err := registryCache.Load(context.Background()); err != nil {
+		// cache is loaded in Run when node becomes available
+		logger.Warn("failed to load node registry", slog.Any("err", err))
+	}
+
+	return registryClient, registryCache, nil
+}
+
 // RegisterRelayer registers the relayer node with the registry contract.
 func (r *Relayer) RegisterRelayer(ctx context.Context) error {
+	client, nodeSigner, err := r.dialRegistryWithKey(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	metadata := registry.RelayerMetadata{
+		Region:    r.Config.DiscoveryConfig.Region,
+		Capacity:  r.Config.DiscoveryConfig.Capacity,
+		PublicKey: signer.CompressedPublicKey(nodeSigner),
+	}
+	if err = client.RegisterRelayer(ctx, r.Config.HTTPEndpoint, metadata); err != nil {
+		return fmt.Errorf("failed to register relayer: %w", err)
+	}
+
+	return nil
+}
+
+// DeregisterRelayer removes the relayer node from the registry contract.
+func (r *Relayer) DeregisterRelayer(ctx context.Context) error {
+	client, _, err := r.dialRegistryWithKey(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err = client.DeregisterRelayer(ctx); err != nil {
+		return fmt.Errorf("failed to deregister relayer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Relayer) dialRegistryWithKey(ctx context.Context) (*registry.Client, signer.Signer, error) {
+	nodeSigner, err := signer.New(r.Config.PrivateKey, r.Config.Keystore)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load relayer key: %w", err)
+	}
+
 	client, err := registry.Dial(ctx, &registry.Config{
 		DialURI:         r.Config.DiscoveryConfig.RpcUrl,
-		PrivateKey:      r.Config.PrivateKey,
+		Signer:          nodeSigner,
 		ContractAddress: r.Config.DiscoveryConfig.ContractAddress,
+		Tx:              r.Config.DiscoveryConfig.Transaction,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to connect to Ethereum node: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
 	}
-	defer client.Close()
 
-	if err = client.RegisterRelayer(ctx, r.Config.HTTPEndpoint); err != nil {
-		return fmt.Errorf("failed to register relayer: %w", err)
+	return client, nodeSigner, nil
+}
+
+// relayerHandler returns relayer selected by query params "region" and "strategy" with public keys and stakes of live resolvers,
+// resolvers are paginated by query params "offset" and "limit", all resolvers are returned if limit is not set.
+func relayerHandler(logger *slog.Logger, nodeRegistry registry.Registry, livenessMonitor *liveness.Monitor, discoveryService *discovery.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		offset, err := queryUint(r, "offset")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		limit, err := queryUint(r, "limit")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		relayer, err := discoveryService.Pick(discovery.Hint{
+			Region:   r.URL.Query().Get("region"),
+			Strategy: r.URL.Query().Get("strategy"),
+		})
+		if errors.Is(err, discovery.ErrUnknownStrategy) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, discovery.ErrNoRelayers) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			logger.Error("failed to select relayer node", slog.Any("err", err))
+			http.Error(w, "failed to get closest relayer node", http.StatusInternalServerError)
+			return
+		}
+
+		resolvers, resolverStakes, err := nodeRegistry.GetResolvers()
+		if err != nil {
+			http.Error(w, "failed to get resolvers", http.StatusInternalServerError)
+			return
+		}
+
+		resolvers, resolverStakes = liveResolvers(livenessMonitor, resolvers, resolverStakes)
+
+		total := len(resolvers)
+		start, end := min(offset, total), total
+		if limit > 0 {
+			end = min(start+limit, total)
+		}
+		resolvers, resolverStakes = resolvers[start:end], resolverStakes[start:end]
+
+		// stakes are in wei as decimal strings in the order of resolvers, so clients can weight resolvers by stake
+		stakes := make([]string, len(resolverStakes))
+		for i, stake := range resolverStakes {
+			stakes[i] = stake.String()
+		}
+
+		resp := struct {
+			IPAddress string   `json:"ip_address"`
+			Region    string   `json:"region"`
+			PublicKey []byte   `json:"public_key"`
+			Resolvers [][]byte `json:"resolvers"`
+			Stakes    []string `json:"stakes"`
+			// Total is count of all live resolvers
+			Total int `json:"total"`
+		}{IPAddress: relayer.Ip, Region: relayer.Region, PublicKey: relayer.PublicKey, Resolvers: resolvers, Stakes: stakes, Total: total}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(resp)
+		if err != nil {
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+			return
+		}
 	}
+}
 
-	return nil
+// liveResolvers filters out stale resolvers and their stakes.
+func liveResolvers(livenessMonitor *liveness.Monitor, resolvers [][]byte, stakes []*big.Int) ([][]byte, []*big.Int) {
+	liveKeys := make([][]byte, 0, len(resolvers))
+	liveStakes := make([]*big.Int, 0, len(stakes))
+	for i, publicKey := range resolvers {
+		if livenessMonitor.IsAlive(publicKey) {
+			liveKeys, liveStakes = append(liveKeys, publicKey), append(liveStakes, stakes[i])
+		}
+	}
+
+	return liveKeys, liveStakes
+}
+
+// queryUint parses non-negative integer query param, zero is returned if param is not set.
+func queryUint(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("%w: %s", errInvalidQueryParam, name)
+	}
+
+	return parsed, nil
 }
 
 func corsMiddleware(w http.ResponseWriter, r *http.Request) {
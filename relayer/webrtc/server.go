@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/1inch/p2p-network/contracts"
 	pbrelayer "github.com/1inch/p2p-network/proto/relayer"
 	pbresolver "github.com/1inch/p2p-network/proto/resolver"
 	"github.com/1inch/p2p-network/relayer/metrics"
@@ -48,6 +50,16 @@ type RegistryClient interface {
 	GetResolver(publicKey []byte) (string, error)
 }
 
+// CapabilityRegistry provides resolver capabilities for request routing.
+type CapabilityRegistry interface {
+	GetResolverCapabilities(publicKey []byte) (contracts.NodeRegistryCapabilities, error)
+}
+
+// LivenessChecker reports whether resolver answered heartbeat recently.
+type LivenessChecker interface {
+	IsAlive(publicKey []byte) bool
+}
+
 // SDPRequest represents SDP request.
 type SDPRequest struct {
 	SessionID    string
@@ -67,6 +79,8 @@ type Server struct {
 	useTrickleICE bool
 	retryOpt      *Retry
 	peerPortOpt   *PeerRangePort
+	capabilities  CapabilityRegistry
+	liveness      LivenessChecker
 	logger        *slog.Logger
 	iceServers    []webrtc.ICEServer
 	grpcClient    GRPCClient
@@ -129,6 +143,20 @@ func WithTrickleICE() Option {
 	}
 }
 
+// WithCapabilities added routing of requests only to resolvers which can serve them
+func WithCapabilities(registry CapabilityRegistry) Option {
+	return func(s *Server) {
+		s.capabilities = registry
+	}
+}
+
+// WithLiveness added routing of requests only to resolvers which answer heartbeats
+func WithLiveness(liveness LivenessChecker) Option {
+	return func(s *Server) {
+		s.liveness = liveness
+	}
+}
+
 // HandleSDP processes an SDP offer, sets up a PeerConnection, and generates an SDP answer.
 func (w *Server) HandleSDP(candidateURL, sessionID string, offer webrtc.SessionDescription) (*webrtc.SessionDescription, error) {
 	start := time.Now()
@@ -327,10 +355,24 @@ func (w *Server) handleDataChannel(dc *webrtc.DataChannel, sessionID string) {
 
 		w.logger.Debug("received message", slog.Any("request", message.Request), slog.String("publicKeys", fmt.Sprintf("%x", message.PublicKeys)))
 
+		publicKeys := w.capableResolvers(message.PublicKeys, message.Request)
+		if len(publicKeys) == 0 {
+			respMessage := w.buildOutgoingMessageWithErr(nil,
+				pbrelayer.ErrorCode_ERR_NO_CAPABLE_RESOLVER,
+				"no resolver can serve the request")
+
+			if sendErr := w.sendResponse(dc, respMessage); sendErr != nil {
+				w.logger.Error("failed to send no capable resolver error response", slog.Any("err", sendErr))
+			}
+			metrics.DataChannelMessagesSent.WithLabelValues(sessionID, "failed").Inc()
+
+			return
+		}
+
 		doneChan := make(chan bool)
 		respChan := make(chan *pbrelayer.OutgoingMessage)
 
-		for _, publicKey := range message.PublicKeys {
+		for _, publicKey := range publicKeys {
 			go w.retryGetResponseFromResolver(publicKey, message.Request, doneChan, respChan)
 		}
 
@@ -403,6 +445,92 @@ func (w *Server) cleanup() {
 	}
 }
 
+// capableResolvers returns public keys of live resolvers which can serve the request,
+// resolvers without published capabilities and unknown resolvers are not filtered out.
+func (w *Server) capableResolvers(publicKeys [][]byte, request *pbresolver.ResolverRequest) [][]byte {
+	if w.capabilities == nil && w.liveness == nil {
+		return publicKeys
+	}
+
+	methods := requestMethods(request)
+	capable := make([][]byte, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		if w.liveness != nil && !w.liveness.IsAlive(publicKey) {
+			w.logger.Debug("resolver is stale", slog.String("publicKey", fmt.Sprintf("%x", publicKey)))
+			continue
+		}
+
+		if w.capabilities == nil {
+			capable = append(capable, publicKey)
+			continue
+		}
+
+		capabilities, err := w.capabilities.GetResolverCapabilities(publicKey)
+		if err != nil || canServe(capabilities, request.GetEncrypted(), methods) {
+			capable = append(capable, publicKey)
+			continue
+		}
+
+		w.logger.Debug("resolver can't serve request", slog.String("publicKey", fmt.Sprintf("%x", publicKey)), slog.Any("methods", methods))
+	}
+
+	return capable
+}
+
+// canServe checks request against resolver capabilities, resolver must serve all methods of batch request.
+// Empty methods mean methods are unknown.
+func canServe(capabilities contracts.NodeRegistryCapabilities, encrypted bool, methods []string) bool {
+	if capabilities.ProtocolVersion == 0 {
+		// capabilities are not published
+		return true
+	}
+
+	if encrypted && !capabilities.Encrypted {
+		return false
+	}
+
+	for _, method := range methods {
+		if !slices.Contains(capabilities.Methods, method) {
+			return false
+		}
+	}
+	return true
+}
+
+// requestMethods returns methods of plain request or batch request, nil for encrypted or malformed request.
+func requestMethods(request *pbresolver.ResolverRequest) []string {
+	if request.GetEncrypted() {
+		return nil
+	}
+
+	type jsonRequest struct {
+		Method string `json:"method"`
+	}
+
+	payload := bytes.TrimSpace(request.GetPayload())
+	if len(payload) > 0 && payload[0] == '[' {
+		var batch []jsonRequest
+		if err := json.Unmarshal(payload, &batch); err != nil {
+			return nil
+		}
+
+		methods := make([]string, 0, len(batch))
+		for _, req := range batch {
+			if req.Method != "" && !slices.Contains(methods, req.Method) {
+				methods = append(methods, req.Method)
+			}
+		}
+		return methods
+	}
+
+	var req jsonRequest
+	if err := json.Unmarshal(payload, &req); err != nil || req.Method == "" {
+		return nil
+	}
+
+	return []string{req.Method}
+}
+
 func (w *Server) retryGetResponseFromResolver(publicKey []byte, request *pbresolver.ResolverRequest, doneChan chan bool, respChan chan *pbrelayer.OutgoingMessage) {
 	w.logger.Debug("start request to resolver", slog.Any("public_key", string(publicKey)))
 
@@ -415,6 +543,7 @@ func (w *Server) retryGetResponseFromResolver(publicKey []byte, request *pbresol
 		retryRequest = w.retryOpt.Count
 		requestSleepInterval = w.retryOpt.Interval
 	}
+retry:
 	for attempt := range retryRequest {
 		// check doneChan before start try get response from resolver
 		select {
@@ -442,18 +571,29 @@ func (w *Server) retryGetResponseFromResolver(publicKey []byte, request *pbresol
 					}
 
 					w.logger.Debug("resolver returned supported error for retry")
-					w.logger.Debug("start sleep for interval", slog.Any("time_duration", requestSleepInterval))
-					time.Sleep(requestSleepInterval)
+					if !w.waitRetry(attempt, retryRequest, requestSleepInterval, doneChan) {
+						return
+					}
 					continue
 				}
-				// At this moment, if resolver return any error in ResolverResponse.Error that error not for retry
+
 				resp = &pbrelayer.OutgoingMessage{
 					PublicKey: publicKey,
 					Result: &pbrelayer.OutgoingMessage_Response{
 						Response: resolverResponse,
 					},
 				}
-				break
+
+				// resolver marks errors which may not repeat, e.g. upstream failure or timeout, as retryable,
+				// response with the last error is returned when attempts are over
+				if resolverResponse.GetError().GetRetryable() {
+					w.logger.Debug("resolver returned retryable error", slog.Any("code", resolverResponse.GetError().GetCode()))
+					if !w.waitRetry(attempt, retryRequest, requestSleepInterval, doneChan) {
+						return
+					}
+					continue
+				}
+				break retry
 			}
 		}
 	}
@@ -462,6 +602,26 @@ func (w *Server) retryGetResponseFromResolver(publicKey []byte, request *pbresol
 	doneChan <- true
 }
 
+// waitRetry waits interval before the next attempt, there is no wait after the last attempt.
+// It returns false if other goroutine returned response during the wait, so this goroutine must stop.
+func (w *Server) waitRetry(attempt, attempts uint8, interval time.Duration, doneChan chan bool) bool {
+	if attempt+1 >= attempts {
+		return true
+	}
+
+	w.logger.Debug("start sleep for interval", slog.Any("time_duration", interval))
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-doneChan:
+		w.logger.Debug("some gorotine returned response, stop this gorotine")
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 // create and configure new peer connection
 func (w *Server) newPeerConnection() (*webrtc.PeerConnection, error) {
 	s := webrtc.SettingEngine{}
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/1inch/p2p-network/contracts"
 	mocks "github.com/1inch/p2p-network/internal/mock"
 	pbrelayer "github.com/1inch/p2p-network/proto/relayer"
 	pbresolver "github.com/1inch/p2p-network/proto/resolver"
@@ -22,6 +23,18 @@ import (
 
 var iceServers = []webrtc.ICEServer{{URLs: []string{"stun:stun.l.google.com:19302"}}}
 
+type staticCapabilities map[string]contracts.NodeRegistryCapabilities
+
+func (c staticCapabilities) GetResolverCapabilities(publicKey []byte) (contracts.NodeRegistryCapabilities, error) {
+	return c[string(publicKey)], nil
+}
+
+type staticLiveness map[string]bool
+
+func (l staticLiveness) IsAlive(publicKey []byte) bool {
+	return l[string(publicKey)]
+}
+
 func TestWebRTCServer_HandleSDP(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(nil, nil))
 	sdpRequests := make(chan relayerwebrtc.SDPRequest, 1)
@@ -300,6 +313,131 @@ func TestWebRTCServer_DataChannel(t *testing.T) {
 			countPublicKeys: 1,
 			requestPayload:  "retry-requests",
 		},
+		{
+			description: "Retry request after retryable resolver error",
+			webrtcOptions: []relayerwebrtc.Option{
+				relayerwebrtc.WithRetry(
+					relayerwebrtc.Retry{
+						Count: 2,
+					},
+				),
+			},
+			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
+				gomock.InOrder(
+					mockGRPCClient.EXPECT().
+						Execute(gomock.Any(), []byte("public-key-1"), gomock.Any()).
+						Times(1).
+						Return(&pbresolver.ResolverResponse{
+							Id: reqID,
+							Result: &pbresolver.ResolverResponse_Error{
+								Error: &pbresolver.Error{
+									Code:      pbresolver.ErrorCode_ERR_UPSTREAM_FAILED,
+									Message:   "all upstreams failed",
+									Retryable: true,
+								},
+							},
+						}, nil),
+
+					mockGRPCClient.EXPECT().
+						Execute(gomock.Any(), []byte("public-key-1"), gomock.Any()).
+						Times(1).
+						Return(&pbresolver.ResolverResponse{
+							Id: reqID,
+							Result: &pbresolver.ResolverResponse_Payload{
+								Payload: []byte("test-response"),
+							},
+						}, nil),
+				)
+				mockGRPCClient.EXPECT().Close().AnyTimes()
+			},
+			outgoingExpectedErr:  nil,
+			expectedPickedPubKey: "public-key-1",
+			resolverExpectedResp: &pbresolver.ResolverResponse{
+				Id: reqID,
+				Result: &pbresolver.ResolverResponse_Payload{
+					Payload: []byte("test-response"),
+				},
+			},
+			countPublicKeys: 1,
+			requestPayload:  "retry-retryable-error",
+		},
+		{
+			description: "Resolver error which isn't retryable is not retried",
+			webrtcOptions: []relayerwebrtc.Option{
+				relayerwebrtc.WithRetry(
+					relayerwebrtc.Retry{
+						Count: 2,
+					},
+				),
+			},
+			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
+				mockGRPCClient.EXPECT().Execute(gomock.Any(), []byte("public-key-1"), gomock.Any()).
+					Times(1).
+					Return(&pbresolver.ResolverResponse{
+						Id: reqID,
+						Result: &pbresolver.ResolverResponse_Error{
+							Error: &pbresolver.Error{
+								Code:    pbresolver.ErrorCode_ERR_INVALID_PARAMS,
+								Message: "invalid params",
+							},
+						},
+					}, nil)
+				mockGRPCClient.EXPECT().Close().AnyTimes()
+			},
+			outgoingExpectedErr:  nil,
+			expectedPickedPubKey: "public-key-1",
+			resolverExpectedResp: &pbresolver.ResolverResponse{
+				Id: reqID,
+				Result: &pbresolver.ResolverResponse_Error{
+					Error: &pbresolver.Error{
+						Code:    pbresolver.ErrorCode_ERR_INVALID_PARAMS,
+						Message: "invalid params",
+					},
+				},
+			},
+			countPublicKeys: 1,
+			requestPayload:  "not-retryable-error",
+		},
+		{
+			description: "Retryable resolver error is returned without wait after the last attempt",
+			webrtcOptions: []relayerwebrtc.Option{
+				relayerwebrtc.WithRetry(
+					relayerwebrtc.Retry{
+						Count:    1,
+						Interval: time.Hour,
+					},
+				),
+			},
+			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
+				mockGRPCClient.EXPECT().Execute(gomock.Any(), []byte("public-key-1"), gomock.Any()).
+					Times(1).
+					Return(&pbresolver.ResolverResponse{
+						Id: reqID,
+						Result: &pbresolver.ResolverResponse_Error{
+							Error: &pbresolver.Error{
+								Code:      pbresolver.ErrorCode_ERR_TIMEOUT,
+								Message:   "upstream timeout",
+								Retryable: true,
+							},
+						},
+					}, nil)
+				mockGRPCClient.EXPECT().Close().AnyTimes()
+			},
+			outgoingExpectedErr:  nil,
+			expectedPickedPubKey: "public-key-1",
+			resolverExpectedResp: &pbresolver.ResolverResponse{
+				Id: reqID,
+				Result: &pbresolver.ResolverResponse_Error{
+					Error: &pbresolver.Error{
+						Code:      pbresolver.ErrorCode_ERR_TIMEOUT,
+						Message:   "upstream timeout",
+						Retryable: true,
+					},
+				},
+			},
+			countPublicKeys: 1,
+			requestPayload:  "last-attempt-retryable-error",
+		},
 		{
 			description: "Receive many response one is correct",
 			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
@@ -382,6 +520,131 @@ func TestWebRTCServer_DataChannel(t *testing.T) {
 			countPublicKeys:      3,
 			requestPayload:       "many-requests-return-errors",
 		},
+		{
+			description: "Route request only to resolver which serves method",
+			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
+				mockGRPCClient.EXPECT().Execute(gomock.Any(), []byte("public-key-2"), gomock.Any()).
+					Times(1).
+					Return(&pbresolver.ResolverResponse{
+						Id: reqID,
+						Result: &pbresolver.ResolverResponse_Payload{
+							Payload: []byte("test-response"),
+						},
+					}, nil)
+				mockGRPCClient.EXPECT().Close().AnyTimes()
+			},
+			webrtcOptions: []relayerwebrtc.Option{
+				relayerwebrtc.WithCapabilities(staticCapabilities{
+					"public-key-1": {Methods: []string{"GetBlockNumber"}, ProtocolVersion: 1, Encrypted: true},
+					"public-key-2": {Methods: []string{"GetWalletBalance"}, ProtocolVersion: 1, Encrypted: true},
+				}),
+			},
+			outgoingExpectedErr:  nil,
+			expectedPickedPubKey: "public-key-2",
+			resolverExpectedResp: &pbresolver.ResolverResponse{
+				Id: reqID,
+				Result: &pbresolver.ResolverResponse_Payload{
+					Payload: []byte("test-response"),
+				},
+			},
+			countPublicKeys: 2,
+			requestPayload:  `{"id":"1","method":"GetWalletBalance","params":[]}`,
+		},
+		{
+			description: "Route batch request only to resolver which serves all methods",
+			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
+				mockGRPCClient.EXPECT().Execute(gomock.Any(), []byte("public-key-2"), gomock.Any()).
+					Times(1).
+					Return(&pbresolver.ResolverResponse{
+						Id: reqID,
+						Result: &pbresolver.ResolverResponse_Payload{
+							Payload: []byte("test-response"),
+						},
+					}, nil)
+				mockGRPCClient.EXPECT().Close().AnyTimes()
+			},
+			webrtcOptions: []relayerwebrtc.Option{
+				relayerwebrtc.WithCapabilities(staticCapabilities{
+					"public-key-1": {Methods: []string{"GetWalletBalance"}, ProtocolVersion: 1, Encrypted: true},
+					"public-key-2": {Methods: []string{"GetWalletBalance", "GetBlockNumber"}, ProtocolVersion: 1, Encrypted: true},
+				}),
+			},
+			outgoingExpectedErr:  nil,
+			expectedPickedPubKey: "public-key-2",
+			resolverExpectedResp: &pbresolver.ResolverResponse{
+				Id: reqID,
+				Result: &pbresolver.ResolverResponse_Payload{
+					Payload: []byte("test-response"),
+				},
+			},
+			countPublicKeys: 2,
+			requestPayload:  `[{"jsonrpc":"2.0","id":1,"method":"GetWalletBalance","params":[]},{"jsonrpc":"2.0","id":2,"method":"GetBlockNumber"}]`,
+		},
+		{
+			description: "No resolver serves method",
+			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
+				mockGRPCClient.EXPECT().Close().AnyTimes()
+			},
+			webrtcOptions: []relayerwebrtc.Option{
+				relayerwebrtc.WithCapabilities(staticCapabilities{
+					"public-key-1": {Methods: []string{"GetBlockNumber"}, ProtocolVersion: 1, Encrypted: true},
+				}),
+			},
+			outgoingExpectedErr: &struct {
+				errorCode pbrelayer.ErrorCode
+				errorMsg  string
+			}{
+				errorCode: pbrelayer.ErrorCode_ERR_NO_CAPABLE_RESOLVER,
+				errorMsg:  "no resolver can serve the request",
+			},
+			countPublicKeys: 1,
+			requestPayload:  `{"id":"1","method":"GetWalletBalance","params":[]}`,
+		},
+		{
+			description: "Route request only to live resolver",
+			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
+				mockGRPCClient.EXPECT().Execute(gomock.Any(), []byte("public-key-2"), gomock.Any()).
+					Times(1).
+					Return(&pbresolver.ResolverResponse{
+						Id: reqID,
+						Result: &pbresolver.ResolverResponse_Payload{
+							Payload: []byte("test-response"),
+						},
+					}, nil)
+				mockGRPCClient.EXPECT().Close().AnyTimes()
+			},
+			webrtcOptions: []relayerwebrtc.Option{
+				relayerwebrtc.WithLiveness(staticLiveness{"public-key-2": true}),
+			},
+			outgoingExpectedErr:  nil,
+			expectedPickedPubKey: "public-key-2",
+			resolverExpectedResp: &pbresolver.ResolverResponse{
+				Id: reqID,
+				Result: &pbresolver.ResolverResponse_Payload{
+					Payload: []byte("test-response"),
+				},
+			},
+			countPublicKeys: 2,
+			requestPayload:  `{"id":"1","method":"GetWalletBalance","params":[]}`,
+		},
+		{
+			description: "No live resolver",
+			setupMock: func(mockGRPCClient *mocks.MockGRPCClient) {
+				mockGRPCClient.EXPECT().Close().AnyTimes()
+			},
+			webrtcOptions: []relayerwebrtc.Option{
+				relayerwebrtc.WithLiveness(staticLiveness{}),
+			},
+			outgoingExpectedErr: &struct {
+				errorCode pbrelayer.ErrorCode
+				errorMsg  string
+			}{
+				errorCode: pbrelayer.ErrorCode_ERR_NO_CAPABLE_RESOLVER,
+				errorMsg:  "no resolver can serve the request",
+			},
+			countPublicKeys: 2,
+			requestPayload:  `{"id":"1","method":"GetWalletBalance","params":[]}`,
+		},
 	}
 
 	for _, tc := range testCases {
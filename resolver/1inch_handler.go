@@ -2,13 +2,19 @@ package resolver
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/1inch/1inch-sdk-go/sdk-clients/balances"
 	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 const (
@@ -28,53 +34,85 @@ const (
 	chainIdLinea    = "59144"
 )
 
+var supportedChainIds = []string{
+	chainIdArbitrum, chainIdAvalance, chainIdAurora, chainIdBase, chainIdBinance,
+	chainIdEthereum, chainIdFantom, chainIdGnosis, chainIdKaia, chainIdLinea, chainIdPolygon,
+	chainIdZkSync, chainOptimism,
+}
+
 var (
 	errChainIdMustBeNumeric = errors.New("chainId must be numeric")
 	errChainIdNotSupported  = errors.New("chainId not supported")
 )
 
+// defaultOneInchTimeout limits request to 1inch API when upstream has no timeout
+const defaultOneInchTimeout = 10 * time.Second
+
+// oneInchResponseErrorPrefix is prefix of SDK errors of 1inch API response
+const oneInchResponseErrorPrefix = "processing response failed: "
+
 // oneInchApiHandler represends handler which would call 1inch api
 type oneInchApiHandler struct {
-	cfg            OneInchApiConfig
+	upstreams *upstreamPool[*oneInchUpstream]
+	logger    *slog.Logger
+}
+
+// oneInchUpstream creates balances clients of one 1inch API url
+type oneInchUpstream struct {
+	url            string
+	key            string
 	logger         *slog.Logger
+	mu             sync.Mutex
 	balanceClients map[uint64]*balances.Client // map<chainId, balances.Client>
 }
 
 // NewOneInchApiHandler creates an 1inch API handler instance
-func NewOneInchApiHandler(cfg OneInchApiConfig, logger *slog.Logger) ApiHandler {
-	return &oneInchApiHandler{
-		cfg:            cfg,
-		logger:         logger.WithGroup("1inch-handler-api"),
-		balanceClients: make(map[uint64]*balances.Client),
+func NewOneInchApiHandler(cfg OneInchApiConfig, logger *slog.Logger) (ApiHandler, error) {
+	logger = logger.WithGroup("1inch-handler-api")
+	upstreams, err := newUpstreamPool(upstreamsOf(cfg.Upstreams, apiUrl), defaultOneInchTimeout, cfg.Failover, func(url string) (*oneInchUpstream, error) {
+		return &oneInchUpstream{
+			url:            url,
+			key:            cfg.Key,
+			logger:         logger,
+			balanceClients: make(map[uint64]*balances.Client),
+		}, nil
+	}, logger)
+	if err != nil {
+		return nil, err
 	}
+
+	return &oneInchApiHandler{upstreams: upstreams, logger: logger}, nil
 }
 
-func (h *oneInchApiHandler) getClientByChainId(chainId uint64) (*balances.Client, error) {
-	if client, ok := h.balanceClients[chainId]; ok {
+func (u *oneInchUpstream) getClientByChainId(chainId uint64) (*balances.Client, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if client, ok := u.balanceClients[chainId]; ok {
 		return client, nil
 	}
 
 	config, err := balances.NewConfiguration(
 		balances.ConfigurationParams{
 			ChainId: chainId,
-			ApiUrl:  apiUrl,
-			ApiKey:  h.cfg.Key,
+			ApiUrl:  u.url,
+			ApiKey:  u.key,
 		},
 	)
 
 	if err != nil {
-		h.logger.Error(fmt.Sprintf("Failed create configuration for client of balances for chainId: %d", chainId), slog.Any("err", err.Error()))
+		u.logger.Error(fmt.Sprintf("Failed create configuration for client of balances for chainId: %d", chainId), slog.Any("err", err.Error()))
 		return nil, err
 	}
 
 	newClient, err := balances.NewClient(config)
 
 	if err != nil {
-		h.logger.Error(fmt.Sprintf("Failed create client of balances for chainId: %d", chainId), slog.Any("err", err.Error()))
+		u.logger.Error(fmt.Sprintf("Failed create client of balances for chainId: %d", chainId), slog.Any("err", err.Error()))
 		return nil, err
 	}
 
-	h.balanceClients[chainId] = newClient
+	u.balanceClients[chainId] = newClient
 
 	return newClient, nil
 }
@@ -82,8 +120,8 @@ func (h *oneInchApiHandler) getClientByChainId(chainId uint64) (*balances.Client
 // Process acts as an API wrapper for JSON payloads coming through gRPC
 func (h *oneInchApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
 	switch req.Method {
-	case "GetWalletBalance":
-		balances, err := h.getWalletBalance(req.Params)
+	case methodGetWalletBalance:
+		balances, err := h.getWalletBalance(req)
 		if err != nil {
 			return &types.JsonResponse{Id: req.Id, Result: 0}, err
 		}
@@ -93,13 +131,12 @@ func (h *oneInchApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse
 	}
 }
 
-func (h *oneInchApiHandler) getWalletBalance(params []string) (interface{}, error) {
-	if len(params) != 2 {
-		h.logger.Error("GetWalletBalance: wrong number of params", "len", len(params))
-		return nil, errWrongParamCount
+func (h *oneInchApiHandler) getWalletBalance(req *types.JsonRequest) (interface{}, error) {
+	var chainId, address string
+	if err := req.BindParams([]string{"chainId", "address"}, &chainId, &address); err != nil {
+		h.logger.Error("GetWalletBalance: failed to decode params", slog.Any("err", err))
+		return nil, err
 	}
-	chainId := params[0]
-	address := params[1]
 
 	chainIdInt, err := h.validateRequest(chainId, address)
 	if err != nil {
@@ -107,19 +144,22 @@ func (h *oneInchApiHandler) getWalletBalance(params []string) (interface{}, erro
 		return nil, err
 	}
 
-	client, err := h.getClientByChainId(chainIdInt)
-
-	if err != nil {
-		return nil, err
-	}
+	var resp *balances.BalancesByWalletAddressResponse
+	err = h.upstreams.call(func(ctx context.Context, upstream *oneInchUpstream) error {
+		client, err := upstream.getClientByChainId(chainIdInt)
+		if err != nil {
+			return err
+		}
 
-	// TODO add handler for errors with mapping after receive token
-	resp, err := client.GetBalancesByWalletAddress(
-		context.Background(),
-		balances.BalancesByWalletAddressParams{
-			Wallet: address,
-		},
-	)
+		// TODO add handler for errors with mapping after receive token
+		resp, err = client.GetBalancesByWalletAddress(
+			ctx,
+			balances.BalancesByWalletAddressParams{
+				Wallet: address,
+			},
+		)
+		return oneInchError(err)
+	})
 
 	if err != nil {
 		h.logger.Error("failed invoking JSON-RPC request", slog.Any("err", err.Error()))
@@ -134,6 +174,10 @@ func (h *oneInchApiHandler) validateRequest(chainId, address string) (uint64, er
 		return 0, errEmptyAddress
 	}
 
+	if !common.IsHexAddress(address) {
+		return 0, errInvalidFormatAddress
+	}
+
 	if chainId == "" {
 		return 0, errEmptyChainId
 	}
@@ -151,15 +195,42 @@ func (h *oneInchApiHandler) validateRequest(chainId, address string) (uint64, er
 	return chainIdInt, nil
 }
 
+// oneInchError returns upstream status error of SDK error of 1inch API response. SDK doesn't return status
+// of response, so it's taken from statusCode field of error body, it's 0 if body has no status.
+func oneInchError(err error) error {
+	if err == nil || !strings.HasPrefix(err.Error(), oneInchResponseErrorPrefix) {
+		return err
+	}
+
+	// body of successful response which can't be decoded is upstream failure
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return err
+	}
+
+	var body struct {
+		StatusCode int `json:"statusCode"`
+	}
+	_ = json.Unmarshal([]byte(strings.TrimPrefix(err.Error(), oneInchResponseErrorPrefix)), &body)
+
+	return &upstreamStatusError{StatusCode: body.StatusCode, Err: err}
+}
+
 func (h *oneInchApiHandler) isSupportedChainId(chainId string) bool {
-	switch chainId {
-	case chainIdArbitrum, chainIdAvalance, chainIdAurora, chainIdBase, chainIdBinance,
-		chainIdEthereum, chainIdFantom, chainIdGnosis, chainIdKaia, chainIdLinea, chainIdPolygon,
-		chainIdZkSync, chainOptimism:
-		{
-			return true
+	return slices.Contains(supportedChainIds, chainId)
+}
+
+// Capabilities returns requests served by the handler
+func (h *oneInchApiHandler) Capabilities() HandlerCapabilities {
+	chainIds := make([]uint64, 0, len(supportedChainIds))
+	for _, chainId := range supportedChainIds {
+		chainIdInt, err := strconv.ParseUint(chainId, 10, 64)
+		if err != nil {
+			continue
 		}
-	default:
-		return false
+		chainIds = append(chainIds, chainIdInt)
 	}
+
+	return HandlerCapabilities{Methods: []string{methodGetWalletBalance}, ChainIds: chainIds}
 }
@@ -2,11 +2,16 @@ package resolver
 
 import (
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 
+	pb "github.com/1inch/p2p-network/proto/resolver"
 	"github.com/1inch/p2p-network/resolver/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -22,12 +27,13 @@ func TestSuccessExecuteMethod(t *testing.T) {
 		Enabled: true,
 	}
 
-	handler := NewOneInchApiHandler(cfg, logger)
+	handler, err := NewOneInchApiHandler(cfg, logger)
+	require.NoError(t, err)
 
 	req := &types.JsonRequest{
-		Id:     "request-id",
+		Id:     types.StringId("request-id"),
 		Method: getWalletBalanceMethod,
-		Params: []string{ethereumChainId, ethereumAddressFromMainnet},
+		Params: types.Params(ethereumChainId, ethereumAddressFromMainnet),
 	}
 	resp, err := handler.Process(req)
 
@@ -44,7 +50,8 @@ func TestNegativeCases(t *testing.T) {
 		Enabled: true,
 	}
 
-	handler := NewOneInchApiHandler(cfg, logger)
+	handler, err := NewOneInchApiHandler(cfg, logger)
+	require.NoError(t, err)
 
 	negativeTestCases := []struct {
 		name        string
@@ -54,54 +61,63 @@ func TestNegativeCases(t *testing.T) {
 		{
 			name: "Unrecognized method name",
 			request: &types.JsonRequest{
-				Id:     "unrecognized-method name",
+				Id:     types.StringId("unrecognized-method name"),
 				Method: "unrecognized-method",
-				Params: []string{ethereumChainId, ethereumAddressFromMainnet},
+				Params: types.Params(ethereumChainId, ethereumAddressFromMainnet),
 			},
 			expectedErr: "unrecognized method",
 		},
 		{
 			name: "Count params less than need",
 			request: &types.JsonRequest{
-				Id:     "count-params-less-than-need",
+				Id:     types.StringId("count-params-less-than-need"),
 				Method: getWalletBalanceMethod,
-				Params: []string{ethereumAddressFromMainnet},
+				Params: types.Params(ethereumAddressFromMainnet),
 			},
 			expectedErr: "wrong number of params",
 		},
 		{
 			name: "Address param is empty",
 			request: &types.JsonRequest{
-				Id:     "empty-address",
+				Id:     types.StringId("empty-address"),
 				Method: getWalletBalanceMethod,
-				Params: []string{ethereumChainId, ""},
+				Params: types.Params(ethereumChainId, ""),
 			},
 			expectedErr: "empty address",
 		},
+		{
+			name: "Address param is invalid",
+			request: &types.JsonRequest{
+				Id:     types.StringId("invalid-address"),
+				Method: getWalletBalanceMethod,
+				Params: types.Params(ethereumChainId, "0x4838B106FCe9647Bdf1E7877BF73cE8B0BAD5f9"),
+			},
+			expectedErr: "invalid format for address",
+		},
 		{
 			name: "ChainId param is empty",
 			request: &types.JsonRequest{
-				Id:     "empty-chainId",
+				Id:     types.StringId("empty-chainId"),
 				Method: getWalletBalanceMethod,
-				Params: []string{"", ethereumAddressFromMainnet},
+				Params: types.Params("", ethereumAddressFromMainnet),
 			},
 			expectedErr: "empty chainId",
 		},
 		{
 			name: "ChainId is not numeric",
 			request: &types.JsonRequest{
-				Id:     "chainId-not-numeric",
+				Id:     types.StringId("chainId-not-numeric"),
 				Method: getWalletBalanceMethod,
-				Params: []string{"not-numeric", ethereumAddressFromMainnet},
+				Params: types.Params("not-numeric", ethereumAddressFromMainnet),
 			},
 			expectedErr: "chainId must be numeric",
 		},
 		{
 			name: "ChainId not supported",
 			request: &types.JsonRequest{
-				Id:     "chainId-not-supported",
+				Id:     types.StringId("chainId-not-supported"),
 				Method: getWalletBalanceMethod,
-				Params: []string{"11155111", ethereumAddressFromMainnet},
+				Params: types.Params("11155111", ethereumAddressFromMainnet),
 			},
 			expectedErr: "chainId not supported",
 		},
@@ -109,9 +125,9 @@ func TestNegativeCases(t *testing.T) {
 			// in handler set incorrect token, so need just put valid request
 			name: "Incorrect dev portal token",
 			request: &types.JsonRequest{
-				Id:     "incorrect-token",
+				Id:     types.StringId("incorrect-token"),
 				Method: getWalletBalanceMethod,
-				Params: []string{ethereumChainId, ethereumAddressFromMainnet},
+				Params: types.Params(ethereumChainId, ethereumAddressFromMainnet),
 			},
 			// TODO change this message after receive token
 			expectedErr: "processing response failed: failed to unmarshal error response body: invalid character 'I' looking for beginning of value",
@@ -137,3 +153,74 @@ func getOneInchPortalTokenFromEnv() string {
 
 	panic("For 1inch tests need set dev portal token to environment, expected key: 'DEV_PORTAL_TOKEN'")
 }
+
+func TestOneInchUpstreamErrors(t *testing.T) {
+	testCases := []struct {
+		name       string
+		status     int
+		body       string
+		failedOver bool
+		code       pb.ErrorCode
+		retryable  bool
+		details    map[string]string
+	}{
+		{
+			name:    "Rejected request",
+			status:  http.StatusBadRequest,
+			body:    `{"error":"Bad Request","description":"invalid wallet","statusCode":400}`,
+			code:    pb.ErrorCode_ERR_UPSTREAM_ERROR,
+			details: map[string]string{"upstream_status": "400"},
+		},
+		{
+			name:   "Rejected request without status in body",
+			status: http.StatusUnauthorized,
+			body:   "Invalid API key",
+			code:   pb.ErrorCode_ERR_UPSTREAM_ERROR,
+		},
+		{
+			name:       "Server error",
+			status:     http.StatusServiceUnavailable,
+			body:       `{"error":"Service Unavailable","statusCode":503}`,
+			failedOver: true,
+			code:       pb.ErrorCode_ERR_UPSTREAM_FAILED,
+			retryable:  true,
+			details:    map[string]string{"upstream_status": "503"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var calls atomic.Int32
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer upstream.Close()
+
+			handler, err := NewOneInchApiHandler(OneInchApiConfig{
+				Enabled:   true,
+				Upstreams: UpstreamList{{Url: upstream.URL}, {Url: upstream.URL}},
+			}, slog.Default())
+			require.NoError(t, err)
+
+			_, err = handler.Process(&types.JsonRequest{
+				Id:     types.StringId("upstream-error"),
+				Method: getWalletBalanceMethod,
+				Params: types.Params(ethereumChainId, ethereumAddressFromMainnet),
+			})
+			require.Error(t, err)
+
+			if testCase.failedOver {
+				assert.Equal(t, int32(2), calls.Load(), "request is failed over to next upstream")
+			} else {
+				assert.Equal(t, int32(1), calls.Load(), "rejected request isn't failed over")
+			}
+
+			handlerErr := handlerError(err)
+			assert.Equal(t, testCase.code, handlerErr.Code)
+			assert.Equal(t, testCase.retryable, handlerErr.Retryable)
+			assert.Equal(t, testCase.details, handlerErr.Details)
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package resolver
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultCacheMaxEntries   = 10000
+	defaultCacheMaxEntrySize = 64 * 1024
+	defaultCacheTtl          = 2 * time.Second
+)
+
+// blockNumberPattern matches block number in hex or decimal form
+var blockNumberPattern = regexp.MustCompile(`^(0x[0-9a-fA-F]+|[0-9]+)$`)
+
+// nullResult is JSON result of missing block, transaction or receipt
+var nullResult = []byte("null")
+
+// CacheMethodConfig describes caching of responses of one method
+type CacheMethodConfig struct {
+	// Ttl of response of request at latest block or without block param, cache ttl by default
+	Ttl time.Duration `yaml:"ttl"`
+	// Params are names of positional params, they are needed to find block param in positional params
+	Params []string `yaml:"params"`
+	// BlockParam is name of block param. Responses of requests at fixed block are cached until eviction,
+	// responses of requests at pending block and null responses at fixed block aren't cached
+	BlockParam string `yaml:"block_param"`
+}
+
+// defaultCachedMethods are cached when methods aren't configured
+var defaultCachedMethods = map[string]CacheMethodConfig{
+	namespaceInfura + namespaceSeparator + methodGetWalletBalance:  {Params: walletBalanceParamNames, BlockParam: "block"},
+	namespaceOneInch + namespaceSeparator + methodGetWalletBalance: {Ttl: 10 * time.Second},
+	namespaceEvm + namespaceSeparator + "eth_getBalance":           {Params: []string{"address", "block"}, BlockParam: "block"},
+	namespaceEvm + namespaceSeparator + "eth_getCode":              {Params: []string{"address", "block"}, BlockParam: "block"},
+	namespaceEvm + namespaceSeparator + "eth_call":                 {Params: []string{"tx", "block"}, BlockParam: "block"},
+	namespaceEvm + namespaceSeparator + "eth_getBlockByNumber":     {Params: []string{"block", "full"}, BlockParam: "block"},
+	namespaceEvm + namespaceSeparator + "eth_chainId":              {Ttl: time.Hour},
+}
+
+type cacheEntry struct {
+	key    string
+	result json.RawMessage
+	// expiresAt is zero for responses at fixed block
+	expiresAt time.Time
+}
+
+// responseCache keeps results of successful requests keyed by handler namespace, method, chain and params.
+// Request id, nonce and timestamp aren't part of key, so plain and encrypted requests share cached results.
+// Results are kept as JSON, so cached results aren't shared with handlers and can't be changed.
+type responseCache struct {
+	maxEntries   int
+	maxEntrySize int
+	ttl          time.Duration
+	// methods are keyed by method name with namespace
+	methods map[string]CacheMethodConfig
+	metrics *cacheMetrics
+	logger  *slog.Logger
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order holds entries from least to most recently used
+	order *list.List
+}
+
+func newResponseCache(cfg CacheConfig, logger *slog.Logger) (*responseCache, error) {
+	methods := cfg.Methods
+	if len(methods) == 0 {
+		methods = defaultCachedMethods
+	}
+	for name := range methods {
+		if _, _, err := splitMethodName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	cache := &responseCache{
+		maxEntries:   cfg.MaxEntries,
+		maxEntrySize: cfg.MaxEntrySize,
+		ttl:          cfg.Ttl,
+		methods:      methods,
+		metrics:      newCacheMetrics(),
+		logger:       logger.With("module", "response-cache"),
+		now:          time.Now,
+		entries:      make(map[string]*list.Element),
+		order:        list.New(),
+	}
+	if cache.maxEntries <= 0 {
+		cache.maxEntries = defaultCacheMaxEntries
+	}
+	if cache.maxEntrySize <= 0 {
+		cache.maxEntrySize = defaultCacheMaxEntrySize
+	}
+	if cache.ttl <= 0 {
+		cache.ttl = defaultCacheTtl
+	}
+
+	return cache, nil
+}
+
+// wrap returns handler which serves cached methods of namespace from cache, handler is returned as is if none of its methods is cached
+func (c *responseCache) wrap(namespace string, handler ApiHandler) ApiHandler {
+	methods := make(map[string]CacheMethodConfig)
+	for name, method := range c.methods {
+		if methodNamespace, methodName, _ := splitMethodName(name); methodNamespace == namespace {
+			methods[methodName] = method
+		}
+	}
+	if len(methods) == 0 {
+		return handler
+	}
+
+	return &cachedApiHandler{namespace: namespace, handler: handler, methods: methods, cache: c}
+}
+
+func (c *responseCache) get(key string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*cacheEntry)
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+
+	c.order.MoveToBack(element)
+	return entry.result, true
+}
+
+// set stores result, least recently used entries are evicted when cache is full
+func (c *responseCache) set(key string, result json.RawMessage, ttl time.Duration) {
+	if len(result) > c.maxEntrySize {
+		c.logger.Debug("result is too large for cache", slog.Int("size", len(result)))
+		return
+	}
+
+	entry := &cacheEntry{key: key, result: result}
+	if ttl > 0 {
+		entry.expiresAt = c.now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	for c.order.Len() >= c.maxEntries {
+		c.remove(c.order.Front())
+		c.metrics.evictions.Inc()
+	}
+
+	c.entries[key] = c.order.PushBack(entry)
+	c.metrics.entries.Set(float64(c.order.Len()))
+}
+
+func (c *responseCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*cacheEntry).key)
+	c.metrics.entries.Set(float64(c.order.Len()))
+}
+
+// cachedApiHandler serves cached methods of wrapped handler from response cache
+type cachedApiHandler struct {
+	namespace string
+	handler   ApiHandler
+	// methods are keyed by method name without namespace
+	methods map[string]CacheMethodConfig
+	cache   *responseCache
+}
+
+// Process returns cached result of the same request or result of wrapped handler, which is cached if request succeeds
+func (h *cachedApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
+	method, ok := h.methods[req.Method]
+	if !ok {
+		return h.handler.Process(req)
+	}
+
+	ttl, cacheable := h.ttl(req, method)
+	if !cacheable {
+		return h.handler.Process(req)
+	}
+
+	name := h.namespace + namespaceSeparator + req.Method
+	key, err := requestKey(h.namespace, req)
+	if err != nil {
+		// params are invalid, so handler returns error of request
+		return h.handler.Process(req)
+	}
+
+	if result, ok := h.cache.get(key); ok {
+		h.cache.metrics.hits.WithLabelValues(name).Inc()
+		return &types.JsonResponse{Id: req.Id, Result: result}, nil
+	}
+	h.cache.metrics.misses.WithLabelValues(name).Inc()
+
+	resp, err := h.handler.Process(req)
+	if err != nil {
+		return resp, err
+	}
+
+	result, err := json.Marshal(resp.Result)
+	if err != nil {
+		h.cache.logger.Warn("failed to marshal result for cache", slog.String("method", name), slog.Any("err", err))
+		return resp, nil
+	}
+	// null result at fixed block, e.g. block which isn't mined yet, changes later
+	if ttl == 0 && bytes.Equal(result, nullResult) {
+		return resp, nil
+	}
+	h.cache.set(key, result, ttl)
+
+	return resp, nil
+}
+
+// Capabilities returns capabilities of wrapped handler
+func (h *cachedApiHandler) Capabilities() HandlerCapabilities {
+	return h.handler.Capabilities()
+}
+
+// requestKey returns key of request which doesn't depend on request id, nonce and timestamp.
+// Params are normalized, so formatting and order of named params don't change key.
+// Numbers keep their literal form, so large integers which differ beyond float64 precision have different keys.
+func requestKey(namespace string, req *types.JsonRequest) (string, error) {
+	var params any
+	if len(req.Params) > 0 {
+		if !json.Valid(req.Params) {
+			return "", types.ErrInvalidParams
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(req.Params))
+		decoder.UseNumber()
+		if err := decoder.Decode(&params); err != nil {
+			return "", err
+		}
+	}
+
+	normalized, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+
+	return namespace + "\x00" + req.Method + "\x00" + strconv.FormatUint(req.ChainId, 10) + "\x00" + string(normalized), nil
+}
+
+// ttl returns ttl of request response, it's zero for requests at fixed block, which are cached until eviction
+func (h *cachedApiHandler) ttl(req *types.JsonRequest, method CacheMethodConfig) (time.Duration, bool) {
+	latestTtl := method.Ttl
+	if latestTtl <= 0 {
+		latestTtl = h.cache.ttl
+	}
+
+	if method.BlockParam == "" {
+		return latestTtl, true
+	}
+
+	block, ok := blockParam(req, method)
+	if !ok {
+		return latestTtl, true
+	}
+
+	var tag string
+	if err := json.Unmarshal(block, &tag); err != nil {
+		// EIP-1898 block param with blockHash or blockNumber
+		var blockRef struct {
+			BlockHash   string `json:"blockHash"`
+			BlockNumber string `json:"blockNumber"`
+		}
+		if err := json.Unmarshal(block, &blockRef); err == nil && (blockRef.BlockHash != "" || blockNumberPattern.MatchString(blockRef.BlockNumber)) {
+			return 0, true
+		}
+		return latestTtl, true
+	}
+
+	switch {
+	case tag == "pending":
+		return 0, false
+	case tag == "earliest", blockNumberPattern.MatchString(tag):
+		return 0, true
+	default:
+		// latest, safe and finalized blocks change
+		return latestTtl, true
+	}
+}
+
+// blockParam returns block param of request by name in named params or by position of name in positional params
+func blockParam(req *types.JsonRequest, method CacheMethodConfig) (json.RawMessage, bool) {
+	var named map[string]json.RawMessage
+	if err := json.Unmarshal(req.Params, &named); err == nil {
+		block, ok := named[method.BlockParam]
+		return block, ok
+	}
+
+	var positional []json.RawMessage
+	if err := json.Unmarshal(req.Params, &positional); err != nil {
+		return nil, false
+	}
+	for i, name := range method.Params {
+		if name == method.BlockParam && i < len(positional) {
+			return positional[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// cacheMetrics are metrics of response cache, method label is method name with namespace
+type cacheMetrics struct {
+	hits      *prometheus.CounterVec
+	misses    *prometheus.CounterVec
+	evictions prometheus.Counter
+	entries   prometheus.Gauge
+}
+
+func newCacheMetrics() *cacheMetrics {
+	return &cacheMetrics{
+		hits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "resolver_cache_hits_total",
+				Help: "Total number of requests served from response cache",
+			},
+			[]string{"method"},
+		),
+		misses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "resolver_cache_misses_total",
+				Help: "Total number of cacheable requests not found in response cache",
+			},
+			[]string{"method"},
+		),
+		evictions: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "resolver_cache_evictions_total",
+				Help: "Total number of responses evicted from full response cache",
+			},
+		),
+		entries: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "resolver_cache_entries",
+				Help: "Number of responses in response cache",
+			},
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *cacheMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.hits.Describe(ch)
+	m.misses.Describe(ch)
+	m.evictions.Describe(ch)
+	m.entries.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (m *cacheMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.hits.Collect(ch)
+	m.misses.Collect(ch)
+	m.evictions.Collect(ch)
+	m.entries.Collect(ch)
+}
@@ -0,0 +1,280 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/encryption"
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	"github.com/1inch/p2p-network/resolver/types"
+	ecies "github.com/ecies/go/v2"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCountingHandler = errors.New("counting handler error")
+
+// countingHandler returns count of processed requests as result, requests with method Fail fail
+// and requests with method GetMissingBlock return null
+type countingHandler struct {
+	count int
+}
+
+func (h *countingHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
+	h.count++
+	switch req.Method {
+	case "Fail":
+		return &types.JsonResponse{Id: req.Id, Result: 0}, errCountingHandler
+	case "GetMissingBlock":
+		return &types.JsonResponse{Id: req.Id, Result: nil}, nil
+	}
+	return &types.JsonResponse{Id: req.Id, Result: h.count}, nil
+}
+
+func (h *countingHandler) Capabilities() HandlerCapabilities {
+	return HandlerCapabilities{Methods: []string{"GetBalance", "GetBlock", "GetMissingBlock", "Fail"}}
+}
+
+func newTestResponseCache(t *testing.T, cfg CacheConfig) (*responseCache, *time.Time) {
+	if cfg.Methods == nil {
+		cfg.Methods = map[string]CacheMethodConfig{
+			"test.GetBalance":      {Ttl: time.Second, Params: []string{"address", "block"}, BlockParam: "block"},
+			"test.Fail":            {},
+			"test.GetMissingBlock": {Params: []string{"block"}, BlockParam: "block"},
+		}
+	}
+
+	cache, err := newResponseCache(cfg, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	require.NoError(t, err)
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestCachedApiHandler(t *testing.T) {
+	testCases := []struct {
+		name     string
+		requests []*types.JsonRequest
+		// advance is time between requests
+		advance time.Duration
+		// count is expected count of requests processed by wrapped handler
+		count int
+	}{
+		{
+			name: "Same params are cached",
+			requests: []*types.JsonRequest{
+				{Id: types.StringId("1"), Method: "GetBalance", Params: json.RawMessage(`["0x01", "latest"]`)},
+				{Id: types.NumberId(2), Method: "GetBalance", Params: json.RawMessage(`["0x01","latest"]`), Nonce: "other"},
+			},
+			count: 1,
+		},
+		{
+			name: "Order of named params doesn't change key",
+			requests: []*types.JsonRequest{
+				{Method: "GetBalance", Params: json.RawMessage(`{"address":"0x01","block":"latest"}`)},
+				{Method: "GetBalance", Params: json.RawMessage(`{"block":"latest","address":"0x01"}`)},
+			},
+			count: 1,
+		},
+		{
+			name: "Different params aren't cached",
+			requests: []*types.JsonRequest{
+				{Method: "GetBalance", Params: types.Params("0x01", "latest")},
+				{Method: "GetBalance", Params: types.Params("0x02", "latest")},
+			},
+			count: 2,
+		},
+		{
+			name: "Different chains aren't cached",
+			requests: []*types.JsonRequest{
+				{Method: "GetBalance", Params: types.Params("0x01", "latest"), ChainId: 1},
+				{Method: "GetBalance", Params: types.Params("0x01", "latest"), ChainId: 137},
+			},
+			count: 2,
+		},
+		{
+			name: "Latest block expires after ttl",
+			requests: []*types.JsonRequest{
+				{Method: "GetBalance", Params: types.Params("0x01", "latest")},
+				{Method: "GetBalance", Params: types.Params("0x01", "latest")},
+			},
+			advance: time.Second,
+			count:   2,
+		},
+		{
+			name: "Fixed block doesn't expire",
+			requests: []*types.JsonRequest{
+				{Method: "GetBalance", Params: types.Params("0x01", "0x10")},
+				{Method: "GetBalance", Params: types.Params("0x01", "0x10")},
+			},
+			advance: time.Hour,
+			count:   1,
+		},
+		{
+			name: "Block hash doesn't expire",
+			requests: []*types.JsonRequest{
+				{Method: "GetBalance", Params: json.RawMessage(`{"address":"0x01","block":{"blockHash":"0xabcd"}}`)},
+				{Method: "GetBalance", Params: json.RawMessage(`{"address":"0x01","block":{"blockHash":"0xabcd"}}`)},
+			},
+			advance: time.Hour,
+			count:   1,
+		},
+		{
+			name: "Large integers aren't rounded",
+			requests: []*types.JsonRequest{
+				{Method: "GetBalance", Params: json.RawMessage(`{"address":"0x01","block":"latest","amount":9007199254740992}`)},
+				{Method: "GetBalance", Params: json.RawMessage(`{"address":"0x01","block":"latest","amount":9007199254740993}`)},
+			},
+			count: 2,
+		},
+		{
+			name: "Null result at fixed block isn't cached",
+			requests: []*types.JsonRequest{
+				{Method: "GetMissingBlock", Params: types.Params("0x10")},
+				{Method: "GetMissingBlock", Params: types.Params("0x10")},
+			},
+			count: 2,
+		},
+		{
+			name: "Pending block isn't cached",
+			requests: []*types.JsonRequest{
+				{Method: "GetBalance", Params: types.Params("0x01", "pending")},
+				{Method: "GetBalance", Params: types.Params("0x01", "pending")},
+			},
+			count: 2,
+		},
+		{
+			name: "Errors aren't cached",
+			requests: []*types.JsonRequest{
+				{Method: "Fail"},
+				{Method: "Fail"},
+			},
+			count: 2,
+		},
+		{
+			name: "Method isn't cached",
+			requests: []*types.JsonRequest{
+				{Method: "GetBlock"},
+				{Method: "GetBlock"},
+			},
+			count: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cache, now := newTestResponseCache(t, CacheConfig{})
+			inner := &countingHandler{}
+			handler := cache.wrap("test", inner)
+
+			var results []string
+			for _, req := range testCase.requests {
+				resp, err := handler.Process(req)
+				*now = now.Add(testCase.advance)
+				if err != nil {
+					continue
+				}
+
+				assert.Equal(t, req.Id, resp.Id, "response must have id of request")
+				result, err := json.Marshal(resp.Result)
+				require.NoError(t, err)
+				results = append(results, string(result))
+			}
+
+			assert.Equal(t, testCase.count, inner.count)
+			if testCase.count == 1 {
+				assert.Equal(t, []string{"1", "1"}, results, "cached result must be returned")
+			}
+		})
+	}
+}
+
+func TestResponseCacheLimits(t *testing.T) {
+	cache, _ := newTestResponseCache(t, CacheConfig{MaxEntries: 2, MaxEntrySize: 4})
+	inner := &countingHandler{}
+	handler := cache.wrap("test", inner)
+
+	process := func(address string) {
+		_, err := handler.Process(&types.JsonRequest{Method: "GetBalance", Params: types.Params(address, "0x1")})
+		require.NoError(t, err)
+	}
+
+	process("0x01")
+	process("0x02")
+	process("0x01")
+	process("0x03")
+	assert.Equal(t, 3, inner.count)
+	assert.Equal(t, 2, cache.order.Len())
+	assert.Equal(t, 1., testutil.ToFloat64(cache.metrics.evictions))
+
+	process("0x01")
+	assert.Equal(t, 3, inner.count, "recently used response must be kept")
+	process("0x02")
+	assert.Equal(t, 4, inner.count, "least recently used response must be evicted")
+
+	assert.Equal(t, 2., testutil.ToFloat64(cache.metrics.hits.WithLabelValues("test.GetBalance")))
+	assert.Equal(t, 4., testutil.ToFloat64(cache.metrics.misses.WithLabelValues("test.GetBalance")))
+	assert.Equal(t, 2., testutil.ToFloat64(cache.metrics.entries))
+
+	inner.count = 99_999
+	process("0x04")
+	process("0x04")
+	assert.Equal(t, 100_001, inner.count, "result larger than max entry size isn't cached")
+}
+
+func TestNewResponseCache(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	_, err := newResponseCache(CacheConfig{Methods: map[string]CacheMethodConfig{"GetWalletBalance": {}}}, logger)
+	assert.ErrorIs(t, err, errNamespacedMethodName)
+
+	cache, err := newResponseCache(CacheConfig{}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, defaultCachedMethods, cache.methods)
+
+	handler := &countingHandler{}
+	assert.Same(t, handler, cache.wrap("test", handler), "handler without cached methods isn't wrapped")
+}
+
+func TestExecuteCached(t *testing.T) {
+	cfg := &Config{}
+	cfg.Apis.Default.Enabled = true
+	cfg.Cache = CacheConfig{Enabled: true, Methods: map[string]CacheMethodConfig{
+		"default.GetWalletBalance": {Params: walletBalanceParamNames, BlockParam: "block"},
+	}}
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+
+	resolverPublicKey, err := ecies.NewPublicKeyFromBytes(ethCrypto.FromECDSAPub(server.signer.PublicKey()))
+	require.NoError(t, err)
+	relayerKey, err := encryption.GenerateKeyPair()
+	require.NoError(t, err)
+
+	payload := []byte(`{"jsonrpc":"2.0","id":1,"method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]}`)
+	resp, err := server.Execute(context.Background(), &pb.ResolverRequest{Id: "1", Payload: payload})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":555}`, string(resp.GetPayload()))
+
+	payload = []byte(`{"jsonrpc":"2.0","id":"encrypted","method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]}`)
+	encryptedPayload, err := encryption.Encrypt(payload, resolverPublicKey)
+	require.NoError(t, err)
+
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "2", Payload: encryptedPayload, Encrypted: true, PublicKey: relayerKey.PublicKey.Bytes(true)})
+	require.NoError(t, err)
+	decryptedPayload, err := encryption.Decrypt(resp.GetPayload(), relayerKey)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":"encrypted","result":555}`, string(decryptedPayload))
+
+	metrics := server.handler.cache.metrics
+	assert.Equal(t, 1., testutil.ToFloat64(metrics.hits.WithLabelValues("default.GetWalletBalance")), "encrypted request must be served from cache")
+	assert.Equal(t, 1., testutil.ToFloat64(metrics.misses.WithLabelValues("default.GetWalletBalance")))
+}
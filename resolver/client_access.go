@@ -0,0 +1,339 @@
+package resolver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultClientMetricsMaxClients = 100
+	// anonymousClient is client of requests without public key
+	anonymousClient = "anonymous"
+	// otherClient is metrics label of clients above metrics max clients
+	otherClient = "other"
+	// apiTokenClientPrefix prefixes names of api token clients, so they don't collide with public keys
+	apiTokenClientPrefix = "token:"
+	// clientSweepInterval is interval of removal of idle clients
+	clientSweepInterval = time.Minute
+)
+
+var (
+	errClientDenied          = errors.New("client is denied")
+	errApiTokenRequired      = errors.New("api token is required")
+	errInvalidApiToken       = errors.New("invalid api token")
+	errRateLimitExceeded     = errors.New("requests per minute quota exceeded")
+	errDailyLimitExceeded    = errors.New("daily requests quota exceeded")
+	errInvalidClientKey      = errors.New("client key must be hex compressed public key")
+	errInvalidApiTokenConfig = errors.New("api token must have name and token")
+	errDuplicateApiToken     = errors.New("duplicate api token")
+)
+
+type apiToken struct {
+	name  string
+	quota ClientQuotaConfig
+}
+
+type clientState struct {
+	quota ClientQuotaConfig
+	// tokens is count of requests allowed by requests per minute quota, it's refilled continuously
+	tokens    float64
+	updatedAt time.Time
+	// day is start of UTC day of dailyCount
+	day        time.Time
+	dailyCount uint
+}
+
+// clientAccess rejects denied clients and clients without valid api token and limits requests of each client.
+// Client is api token of request, or allow-listed public key of encrypted request, or anonymous client.
+// Public key of request may be generated for each request, so other public keys don't have own quota.
+type clientAccess struct {
+	quota           ClientQuotaConfig
+	anonymousQuota  ClientQuotaConfig
+	allow           map[string]struct{}
+	deny            map[string]struct{}
+	requireApiToken bool
+	// apiTokens are keyed by hash of token, so lookup time doesn't depend on matching prefix of token
+	apiTokens         map[[sha256.Size]byte]apiToken
+	metricsMaxClients int
+	metrics           *clientMetrics
+	logger            *slog.Logger
+	now               func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*clientState
+	sweptAt time.Time
+	// labeled are clients with own label in metrics
+	labeled map[string]struct{}
+}
+
+func newClientAccess(cfg ClientAccessConfig, logger *slog.Logger) (*clientAccess, error) {
+	allow, err := clientKeySet(cfg.Allow)
+	if err != nil {
+		return nil, err
+	}
+	deny, err := clientKeySet(cfg.Deny)
+	if err != nil {
+		return nil, err
+	}
+
+	apiTokens := make(map[[sha256.Size]byte]apiToken, len(cfg.ApiTokens))
+	for _, token := range cfg.ApiTokens {
+		if token.Name == "" || token.Token == "" {
+			return nil, errInvalidApiTokenConfig
+		}
+
+		hash := sha256.Sum256([]byte(token.Token))
+		if _, ok := apiTokens[hash]; ok {
+			return nil, fmt.Errorf("%w: %s", errDuplicateApiToken, token.Name)
+		}
+
+		quota := cfg.Quota
+		if token.Quota != nil {
+			quota = *token.Quota
+		}
+		apiTokens[hash] = apiToken{name: token.Name, quota: quota}
+	}
+
+	anonymousQuota := cfg.Quota
+	if cfg.AnonymousQuota != nil {
+		anonymousQuota = *cfg.AnonymousQuota
+	}
+
+	access := &clientAccess{
+		quota:             cfg.Quota,
+		anonymousQuota:    anonymousQuota,
+		allow:             allow,
+		deny:              deny,
+		requireApiToken:   cfg.RequireApiToken,
+		apiTokens:         apiTokens,
+		metricsMaxClients: cfg.MetricsMaxClients,
+		metrics:           newClientMetrics(),
+		logger:            logger.With("module", "client-access"),
+		now:               time.Now,
+		clients:           make(map[string]*clientState),
+		labeled:           make(map[string]struct{}),
+	}
+	if access.metricsMaxClients <= 0 {
+		access.metricsMaxClients = defaultClientMetricsMaxClients
+	}
+
+	return access, nil
+}
+
+// clientKeySet returns set of lowercase hex compressed public keys
+func clientKeySet(keys []string) (map[string]struct{}, error) {
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+		if err != nil || len(decoded) != 33 {
+			return nil, fmt.Errorf("%w: %s", errInvalidClientKey, key)
+		}
+		set[hex.EncodeToString(decoded)] = struct{}{}
+	}
+	return set, nil
+}
+
+// authorize returns error if client of request is rejected, request is counted in quota later by consume
+func (a *clientAccess) authorize(publicKey []byte, token string) error {
+	client, _, err := a.identify(publicKey, token)
+	if err == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.metrics.requests.WithLabelValues(a.label(client), "denied").Inc()
+	return err
+}
+
+// consume returns error if client of request is rejected or exceeded its quota, otherwise request is counted in quota
+func (a *clientAccess) consume(publicKey []byte, token string) error {
+	client, quota, err := a.identify(publicKey, token)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err == nil {
+		err = a.count(client, quota)
+	}
+
+	result := "accepted"
+	switch {
+	case errors.Is(err, errRateLimitExceeded):
+		result = "rate_limited"
+	case errors.Is(err, errDailyLimitExceeded):
+		result = "daily_limited"
+	case err != nil:
+		result = "denied"
+	}
+	a.metrics.requests.WithLabelValues(a.label(client), result).Inc()
+
+	return err
+}
+
+// refund returns request of client which failed with retryable error to its quotas, nonce of the request
+// is released together with it, so the request can be sent again
+func (a *clientAccess) refund(publicKey []byte, token string) {
+	client, quota, err := a.identify(publicKey, token)
+	if err != nil || quota == (ClientQuotaConfig{}) {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.clients[client]
+	if !ok {
+		return
+	}
+
+	if quota.RequestsPerMinute > 0 {
+		state.tokens = min(float64(quota.RequestsPerMinute), state.tokens+1)
+	}
+	if state.dailyCount > 0 && state.day.Equal(a.now().UTC().Truncate(24*time.Hour)) {
+		state.dailyCount--
+	}
+}
+
+// identify returns client of request and its quota
+func (a *clientAccess) identify(publicKey []byte, token string) (string, ClientQuotaConfig, error) {
+	key := anonymousClient
+	if len(publicKey) > 0 {
+		key = hex.EncodeToString(publicKey)
+	}
+
+	if _, ok := a.deny[key]; ok {
+		return key, ClientQuotaConfig{}, errClientDenied
+	}
+
+	// valid api token is accepted from clients which aren't allowed by public key
+	if token != "" {
+		apiToken, ok := a.apiTokens[sha256.Sum256([]byte(token))]
+		if !ok {
+			return key, ClientQuotaConfig{}, errInvalidApiToken
+		}
+		return apiTokenClientPrefix + apiToken.name, apiToken.quota, nil
+	}
+
+	if a.requireApiToken {
+		return key, ClientQuotaConfig{}, errApiTokenRequired
+	}
+
+	if len(a.allow) > 0 {
+		if _, ok := a.allow[key]; !ok {
+			return key, ClientQuotaConfig{}, errClientDenied
+		}
+		return key, a.quota, nil
+	}
+
+	// client can send each request with new public key, so requests of keys which aren't allow-listed share quota
+	return anonymousClient, a.anonymousQuota, nil
+}
+
+// count counts request in requests per minute and daily quotas of client, rejected requests aren't counted
+func (a *clientAccess) count(client string, quota ClientQuotaConfig) error {
+	if quota == (ClientQuotaConfig{}) {
+		return nil
+	}
+
+	now := a.now()
+	a.sweep(now)
+
+	day := now.UTC().Truncate(24 * time.Hour)
+	state, ok := a.clients[client]
+	if !ok {
+		state = &clientState{quota: quota, tokens: float64(quota.RequestsPerMinute), updatedAt: now, day: day}
+		a.clients[client] = state
+	}
+
+	if quota.RequestsPerMinute > 0 {
+		perMinute := float64(quota.RequestsPerMinute)
+		state.tokens = min(perMinute, state.tokens+now.Sub(state.updatedAt).Minutes()*perMinute)
+		state.updatedAt = now
+		if state.tokens < 1 {
+			return errRateLimitExceeded
+		}
+	}
+
+	if !state.day.Equal(day) {
+		state.day = day
+		state.dailyCount = 0
+	}
+	if quota.DailyLimit > 0 && state.dailyCount >= quota.DailyLimit {
+		return errDailyLimitExceeded
+	}
+
+	if quota.RequestsPerMinute > 0 {
+		state.tokens--
+	}
+	state.dailyCount++
+	return nil
+}
+
+// sweep removes clients with full requests per minute quota whose daily count isn't needed anymore
+func (a *clientAccess) sweep(now time.Time) {
+	if now.Sub(a.sweptAt) < clientSweepInterval {
+		return
+	}
+	a.sweptAt = now
+
+	day := now.UTC().Truncate(24 * time.Hour)
+	for client, state := range a.clients {
+		if now.Sub(state.updatedAt) >= time.Minute && (state.quota.DailyLimit == 0 || !state.day.Equal(day)) {
+			delete(a.clients, client)
+		}
+	}
+}
+
+// label returns metrics label of client, api token clients always have own label, so count of labels is limited by config
+func (a *clientAccess) label(client string) string {
+	if strings.HasPrefix(client, apiTokenClientPrefix) {
+		return client
+	}
+
+	if _, ok := a.labeled[client]; ok {
+		return client
+	}
+	if len(a.labeled) >= a.metricsMaxClients {
+		return otherClient
+	}
+
+	a.labeled[client] = struct{}{}
+	return client
+}
+
+// clientMetrics are metrics of client access control, client label is public key, api token name or anonymous
+type clientMetrics struct {
+	requests *prometheus.CounterVec
+}
+
+func newClientMetrics() *clientMetrics {
+	return &clientMetrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "resolver_client_requests_total",
+				Help: "Total number of client requests by result of access control",
+			},
+			[]string{"client", "result"},
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *clientMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (m *clientMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+}
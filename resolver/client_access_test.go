@@ -0,0 +1,228 @@
+package resolver
+
+import (
+	"context"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/encryption"
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	ecies "github.com/ecies/go/v2"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClientKey(t *testing.T) []byte {
+	key, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+	return ethCrypto.CompressPubkey(&key.PublicKey)
+}
+
+func newTestClientAccess(t *testing.T, cfg ClientAccessConfig) (*clientAccess, *time.Time) {
+	access, err := newClientAccess(cfg, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	require.NoError(t, err)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	access.now = func() time.Time { return now }
+	return access, &now
+}
+
+func TestClientAccessCheck(t *testing.T) {
+	allowedKey := newTestClientKey(t)
+	deniedKey := newTestClientKey(t)
+	otherKey := newTestClientKey(t)
+
+	tokens := []ApiTokenConfig{{Name: "partner", Token: "secret"}}
+
+	testCases := []struct {
+		name      string
+		cfg       ClientAccessConfig
+		publicKey []byte
+		token     string
+		err       error
+	}{
+		{name: "Open access", cfg: ClientAccessConfig{}, publicKey: otherKey},
+		{name: "Anonymous client", cfg: ClientAccessConfig{}},
+		{name: "Allowed client", cfg: ClientAccessConfig{Allow: []string{hex.EncodeToString(allowedKey)}}, publicKey: allowedKey},
+		{name: "Client isn't allowed", cfg: ClientAccessConfig{Allow: []string{hex.EncodeToString(allowedKey)}}, publicKey: otherKey, err: errClientDenied},
+		{name: "Anonymous client isn't allowed", cfg: ClientAccessConfig{Allow: []string{hex.EncodeToString(allowedKey)}}, err: errClientDenied},
+		{name: "Denied client", cfg: ClientAccessConfig{Deny: []string{"0x" + hex.EncodeToString(deniedKey)}}, publicKey: deniedKey, err: errClientDenied},
+		{name: "Denied client with api token", cfg: ClientAccessConfig{Deny: []string{hex.EncodeToString(deniedKey)}, ApiTokens: tokens}, publicKey: deniedKey, token: "secret", err: errClientDenied},
+		{name: "Api token of client which isn't allowed", cfg: ClientAccessConfig{Allow: []string{hex.EncodeToString(allowedKey)}, ApiTokens: tokens}, publicKey: otherKey, token: "secret"},
+		{name: "Api token is required", cfg: ClientAccessConfig{RequireApiToken: true, ApiTokens: tokens}, publicKey: allowedKey, err: errApiTokenRequired},
+		{name: "Required api token", cfg: ClientAccessConfig{RequireApiToken: true, ApiTokens: tokens}, token: "secret"},
+		{name: "Invalid api token", cfg: ClientAccessConfig{ApiTokens: tokens}, publicKey: allowedKey, token: "secre", err: errInvalidApiToken},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			access, _ := newTestClientAccess(t, testCase.cfg)
+			assert.ErrorIs(t, access.consume(testCase.publicKey, testCase.token), testCase.err)
+		})
+	}
+}
+
+func TestClientAccessQuota(t *testing.T) {
+	client := newTestClientKey(t)
+	otherClient := newTestClientKey(t)
+	access, now := newTestClientAccess(t, ClientAccessConfig{
+		Quota:     ClientQuotaConfig{RequestsPerMinute: 2, DailyLimit: 5},
+		Allow:     []string{hex.EncodeToString(client), hex.EncodeToString(otherClient)},
+		ApiTokens: []ApiTokenConfig{{Name: "partner", Token: "secret", Quota: &ClientQuotaConfig{RequestsPerMinute: 100}}},
+	})
+
+	require.NoError(t, access.consume(client, ""))
+	require.NoError(t, access.consume(client, ""))
+	assert.ErrorIs(t, access.consume(client, ""), errRateLimitExceeded)
+	assert.NoError(t, access.consume(otherClient, ""), "clients have own quota")
+
+	*now = now.Add(30 * time.Second)
+	assert.NoError(t, access.consume(client, ""), "quota is refilled continuously")
+	assert.ErrorIs(t, access.consume(client, ""), errRateLimitExceeded)
+
+	*now = now.Add(time.Minute)
+	require.NoError(t, access.consume(client, ""))
+	require.NoError(t, access.consume(client, ""))
+	*now = now.Add(time.Minute)
+	assert.ErrorIs(t, access.consume(client, ""), errDailyLimitExceeded)
+
+	for range 10 {
+		require.NoError(t, access.consume(client, "secret"), "api token has own quota")
+	}
+
+	*now = now.Add(12 * time.Hour)
+	assert.NoError(t, access.consume(client, ""), "daily quota is reset at start of UTC day")
+
+	metrics := access.metrics.requests
+	label := hex.EncodeToString(client)
+	assert.Equal(t, 6., testutil.ToFloat64(metrics.WithLabelValues(label, "accepted")))
+	assert.Equal(t, 2., testutil.ToFloat64(metrics.WithLabelValues(label, "rate_limited")))
+	assert.Equal(t, 1., testutil.ToFloat64(metrics.WithLabelValues(label, "daily_limited")))
+	assert.Equal(t, 10., testutil.ToFloat64(metrics.WithLabelValues("token:partner", "accepted")))
+}
+
+func TestClientAccessAnonymousQuota(t *testing.T) {
+	access, _ := newTestClientAccess(t, ClientAccessConfig{
+		Quota:          ClientQuotaConfig{RequestsPerMinute: 100},
+		AnonymousQuota: &ClientQuotaConfig{RequestsPerMinute: 2},
+	})
+
+	require.NoError(t, access.consume(newTestClientKey(t), ""))
+	require.NoError(t, access.consume(nil, ""))
+	assert.ErrorIs(t, access.consume(newTestClientKey(t), ""), errRateLimitExceeded, "new public key doesn't get own quota")
+
+	assert.Equal(t, 2., testutil.ToFloat64(access.metrics.requests.WithLabelValues(anonymousClient, "accepted")))
+	assert.Equal(t, 1., testutil.ToFloat64(access.metrics.requests.WithLabelValues(anonymousClient, "rate_limited")))
+}
+
+func TestClientAccessRefund(t *testing.T) {
+	access, _ := newTestClientAccess(t, ClientAccessConfig{Quota: ClientQuotaConfig{RequestsPerMinute: 1, DailyLimit: 1}})
+	client := newTestClientKey(t)
+
+	require.NoError(t, access.consume(client, ""))
+	assert.ErrorIs(t, access.consume(client, ""), errRateLimitExceeded)
+
+	access.refund(client, "")
+	assert.NoError(t, access.consume(client, ""), "refunded request isn't counted in quota")
+}
+
+func TestClientAccessSweep(t *testing.T) {
+	client := newTestClientKey(t)
+	otherClient := newTestClientKey(t)
+	allow := []string{hex.EncodeToString(client), hex.EncodeToString(otherClient)}
+
+	access, now := newTestClientAccess(t, ClientAccessConfig{Quota: ClientQuotaConfig{RequestsPerMinute: 10, DailyLimit: 100}, Allow: allow})
+	perMinuteOnly, _ := newTestClientAccess(t, ClientAccessConfig{Quota: ClientQuotaConfig{RequestsPerMinute: 10}, Allow: allow})
+	perMinuteOnly.now = access.now
+
+	require.NoError(t, access.consume(client, ""))
+	require.NoError(t, perMinuteOnly.consume(client, ""))
+
+	*now = now.Add(2 * time.Minute)
+	require.NoError(t, access.consume(otherClient, ""))
+	require.NoError(t, perMinuteOnly.consume(otherClient, ""))
+	assert.Contains(t, access.clients, hex.EncodeToString(client), "daily count is kept until end of day")
+	assert.NotContains(t, perMinuteOnly.clients, hex.EncodeToString(client), "idle client is removed")
+
+	*now = now.Add(24 * time.Hour)
+	require.NoError(t, access.consume(otherClient, ""))
+	assert.NotContains(t, access.clients, hex.EncodeToString(client), "client is removed after end of day")
+}
+
+func TestClientAccessMetricsLabels(t *testing.T) {
+	first := newTestClientKey(t)
+	second := newTestClientKey(t)
+	access, _ := newTestClientAccess(t, ClientAccessConfig{MetricsMaxClients: 1, Allow: []string{hex.EncodeToString(first), hex.EncodeToString(second)}})
+
+	require.NoError(t, access.consume(first, ""))
+	require.NoError(t, access.consume(second, ""))
+	require.NoError(t, access.consume(first, ""))
+
+	metrics := access.metrics.requests
+	assert.Equal(t, 2., testutil.ToFloat64(metrics.WithLabelValues(hex.EncodeToString(first), "accepted")))
+	assert.Equal(t, 1., testutil.ToFloat64(metrics.WithLabelValues(otherClient, "accepted")))
+}
+
+func TestNewClientAccess(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	_, err := newClientAccess(ClientAccessConfig{Allow: []string{"0x1234"}}, logger)
+	assert.ErrorIs(t, err, errInvalidClientKey)
+
+	_, err = newClientAccess(ClientAccessConfig{ApiTokens: []ApiTokenConfig{{Name: "partner"}}}, logger)
+	assert.ErrorIs(t, err, errInvalidApiTokenConfig)
+
+	_, err = newClientAccess(ClientAccessConfig{ApiTokens: []ApiTokenConfig{{Name: "a", Token: "secret"}, {Name: "b", Token: "secret"}}}, logger)
+	assert.ErrorIs(t, err, errDuplicateApiToken)
+}
+
+func TestExecuteClientAccess(t *testing.T) {
+	relayerKey, err := encryption.GenerateKeyPair()
+	require.NoError(t, err)
+	clientKey := relayerKey.PublicKey.Bytes(true)
+
+	cfg := &Config{}
+	cfg.Apis.Default.Enabled = true
+	cfg.ClientAccess = ClientAccessConfig{
+		Enabled:   true,
+		Quota:     ClientQuotaConfig{RequestsPerMinute: 1},
+		Deny:      []string{hex.EncodeToString(clientKey)},
+		ApiTokens: []ApiTokenConfig{{Name: "partner", Token: "secret"}},
+	}
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+	resolverPublicKey, err := ecies.NewPublicKeyFromBytes(ethCrypto.FromECDSAPub(server.signer.PublicKey()))
+	require.NoError(t, err)
+
+	payload := []byte(`{"id":"1","method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]}`)
+	encryptedPayload, err := encryption.Encrypt(payload, resolverPublicKey)
+	require.NoError(t, err)
+
+	resp, err := server.Execute(context.Background(), &pb.ResolverRequest{Id: "1", Payload: encryptedPayload, Encrypted: true, PublicKey: clientKey})
+	require.NoError(t, err)
+	assert.Equal(t, pb.ErrorCode_ERR_ACCESS_DENIED, resp.GetError().GetCode(), "encrypted request of denied client must be rejected")
+
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "2", Payload: payload, PublicKey: clientKey})
+	require.NoError(t, err)
+	assert.Nil(t, resp.GetError(), "plain request is anonymous, its public key isn't used")
+
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "3", Payload: payload})
+	require.NoError(t, err)
+	assert.Equal(t, pb.ErrorCode_ERR_QUOTA_EXCEEDED, resp.GetError().GetCode())
+
+	payload = []byte(`{"jsonrpc":"2.0","id":4,"method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]}`)
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "4", Payload: payload})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":4,"error":{"code":-32005,"message":"requests per minute quota exceeded","data":{"retryable":true}}}`, string(resp.GetPayload()))
+
+	payload = []byte(`{"jsonrpc":"2.0","id":5,"method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"],"apiToken":"secret"}`)
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "5", Payload: payload})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":5,"result":555}`, string(resp.GetPayload()), "api token has own quota")
+}
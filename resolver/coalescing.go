@@ -0,0 +1,142 @@
+package resolver
+
+import (
+	"log/slog"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+// requestCoalescer makes one handler call for identical concurrent requests and returns its result to all of them.
+// Requests are identical if they have the same handler namespace, method, chain and params, request id,
+// nonce and timestamp aren't compared, so plain and encrypted requests are coalesced too.
+type requestCoalescer struct {
+	// methods are keyed by method name with namespace
+	methods map[string]struct{}
+	group   singleflight.Group
+	metrics *coalescingMetrics
+	logger  *slog.Logger
+}
+
+func newRequestCoalescer(cfg CoalescingConfig, logger *slog.Logger) (*requestCoalescer, error) {
+	names := cfg.Methods
+	if len(names) == 0 {
+		for name := range defaultCachedMethods {
+			names = append(names, name)
+		}
+	}
+
+	methods := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, _, err := splitMethodName(name); err != nil {
+			return nil, err
+		}
+		methods[name] = struct{}{}
+	}
+
+	return &requestCoalescer{
+		methods: methods,
+		metrics: newCoalescingMetrics(),
+		logger:  logger.With("module", "request-coalescer"),
+	}, nil
+}
+
+// wrap returns handler which coalesces requests of namespace methods, handler is returned as is if none of its methods is coalesced
+func (c *requestCoalescer) wrap(namespace string, handler ApiHandler) ApiHandler {
+	methods := make(map[string]struct{})
+	for name := range c.methods {
+		if methodNamespace, method, _ := splitMethodName(name); methodNamespace == namespace {
+			methods[method] = struct{}{}
+		}
+	}
+	if len(methods) == 0 {
+		return handler
+	}
+
+	return &coalescingApiHandler{namespace: namespace, handler: handler, methods: methods, coalescer: c}
+}
+
+// coalescingApiHandler coalesces identical concurrent requests of wrapped handler
+type coalescingApiHandler struct {
+	namespace string
+	handler   ApiHandler
+	// methods are keyed by method name without namespace
+	methods   map[string]struct{}
+	coalescer *requestCoalescer
+}
+
+// Process waits for result of identical request in progress or calls wrapped handler,
+// every request gets response with its own id
+func (h *coalescingApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
+	if _, ok := h.methods[req.Method]; !ok {
+		return h.handler.Process(req)
+	}
+
+	key, err := requestKey(h.namespace, req)
+	if err != nil {
+		// params are invalid, so handler returns error of request
+		return h.handler.Process(req)
+	}
+
+	name := h.namespace + namespaceSeparator + req.Method
+	// leader request is processed in the goroutine which called Do
+	executed := false
+	value, err, shared := h.coalescer.group.Do(key, func() (any, error) {
+		executed = true
+		h.coalescer.metrics.calls.WithLabelValues(name).Inc()
+		return h.handler.Process(req)
+	})
+	if shared && !executed {
+		h.coalescer.metrics.coalesced.WithLabelValues(name).Inc()
+		h.coalescer.logger.Debug("request coalesced", slog.String("method", name))
+	}
+
+	resp, _ := value.(*types.JsonResponse)
+	if resp == nil {
+		return nil, err
+	}
+	return &types.JsonResponse{Id: req.Id, Result: resp.Result}, err
+}
+
+// Capabilities returns capabilities of wrapped handler
+func (h *coalescingApiHandler) Capabilities() HandlerCapabilities {
+	return h.handler.Capabilities()
+}
+
+// coalescingMetrics are metrics of request coalescing, method label is method name with namespace
+type coalescingMetrics struct {
+	calls     *prometheus.CounterVec
+	coalesced *prometheus.CounterVec
+}
+
+func newCoalescingMetrics() *coalescingMetrics {
+	return &coalescingMetrics{
+		calls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "resolver_coalescing_calls_total",
+				Help: "Total number of handler calls made for coalesced methods",
+			},
+			[]string{"method"},
+		),
+		coalesced: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "resolver_coalesced_requests_total",
+				Help: "Total number of requests served by handler call of identical concurrent request",
+			},
+			[]string{"method"},
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *coalescingMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.calls.Describe(ch)
+	m.coalesced.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (m *coalescingMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.calls.Collect(ch)
+	m.coalesced.Collect(ch)
+}
@@ -0,0 +1,150 @@
+package resolver
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingHandler blocks requests until release is closed, requests with method Fail fail
+type blockingHandler struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (h *blockingHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
+	h.calls.Add(1)
+	<-h.release
+	if req.Method == "Fail" {
+		return &types.JsonResponse{Id: req.Id, Result: 0}, errCountingHandler
+	}
+	return &types.JsonResponse{Id: req.Id, Result: string(req.Params)}, nil
+}
+
+func (h *blockingHandler) Capabilities() HandlerCapabilities {
+	return HandlerCapabilities{Methods: []string{"GetBalance", "GetBlock", "Fail"}}
+}
+
+func TestCoalescingApiHandler(t *testing.T) {
+	const concurrency = 5
+
+	testCases := []struct {
+		name    string
+		request func(i int) *types.JsonRequest
+		// calls is expected count of requests processed by wrapped handler
+		calls int32
+		err   error
+	}{
+		{
+			name: "Identical requests are coalesced",
+			request: func(i int) *types.JsonRequest {
+				return &types.JsonRequest{Id: types.NumberId(int64(i)), Method: "GetBalance", Params: types.Params("0x01", "latest"), Nonce: string(rune('a' + i))}
+			},
+			calls: 1,
+		},
+		{
+			name: "Errors are returned to all requests",
+			request: func(i int) *types.JsonRequest {
+				return &types.JsonRequest{Id: types.NumberId(int64(i)), Method: "Fail"}
+			},
+			calls: 1,
+			err:   errCountingHandler,
+		},
+		{
+			name: "Requests with different params aren't coalesced",
+			request: func(i int) *types.JsonRequest {
+				return &types.JsonRequest{Id: types.NumberId(int64(i)), Method: "GetBalance", Params: types.Params("0x01", i)}
+			},
+			calls: concurrency,
+		},
+		{
+			name: "Method isn't coalesced",
+			request: func(i int) *types.JsonRequest {
+				return &types.JsonRequest{Id: types.NumberId(int64(i)), Method: "GetBlock"}
+			},
+			calls: concurrency,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			coalescer, err := newRequestCoalescer(CoalescingConfig{Methods: []string{"test.GetBalance", "test.Fail"}}, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+			require.NoError(t, err)
+			inner := &blockingHandler{release: make(chan struct{})}
+			handler := coalescer.wrap("test", inner)
+
+			var wg sync.WaitGroup
+			responses := make([]*types.JsonResponse, concurrency)
+			errs := make([]error, concurrency)
+			for i := range concurrency {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					responses[i], errs[i] = handler.Process(testCase.request(i))
+				}()
+			}
+
+			// requests must be in progress together
+			time.Sleep(50 * time.Millisecond)
+			close(inner.release)
+			wg.Wait()
+
+			assert.Equal(t, testCase.calls, inner.calls.Load())
+			for i := range concurrency {
+				assert.ErrorIs(t, errs[i], testCase.err)
+				require.NotNil(t, responses[i])
+				assert.Equal(t, types.NumberId(int64(i)), responses[i].Id, "response must have id of request")
+			}
+		})
+	}
+}
+
+func TestCoalescingMetrics(t *testing.T) {
+	coalescer, err := newRequestCoalescer(CoalescingConfig{Methods: []string{"test.GetBalance"}}, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	require.NoError(t, err)
+	inner := &blockingHandler{release: make(chan struct{})}
+	handler := coalescer.wrap("test", inner)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := handler.Process(&types.JsonRequest{Method: "GetBalance", Params: types.Params("0x01", "latest")})
+			assert.NoError(t, err)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	assert.Equal(t, 1., testutil.ToFloat64(coalescer.metrics.calls.WithLabelValues("test.GetBalance")))
+	assert.Equal(t, 2., testutil.ToFloat64(coalescer.metrics.coalesced.WithLabelValues("test.GetBalance")))
+
+	_, err = handler.Process(&types.JsonRequest{Method: "GetBalance", Params: types.Params("0x01", "latest")})
+	require.NoError(t, err)
+	assert.Equal(t, 2., testutil.ToFloat64(coalescer.metrics.calls.WithLabelValues("test.GetBalance")), "request after completed call isn't coalesced")
+}
+
+func TestNewRequestCoalescer(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	_, err := newRequestCoalescer(CoalescingConfig{Methods: []string{"GetWalletBalance"}}, logger)
+	assert.ErrorIs(t, err, errNamespacedMethodName)
+
+	coalescer, err := newRequestCoalescer(CoalescingConfig{}, logger)
+	require.NoError(t, err)
+	assert.Len(t, coalescer.methods, len(defaultCachedMethods), "built-in read methods are coalesced by default")
+
+	handler := &countingHandler{}
+	assert.Same(t, handler, coalescer.wrap("test", handler), "handler without coalesced methods isn't wrapped")
+}
@@ -1,6 +1,12 @@
 package resolver
 
-import "log/slog"
+import (
+	"log/slog"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+)
 
 // DefaultApiConfig provides configuration for default api handler
 type DefaultApiConfig struct {
@@ -11,12 +17,52 @@ type DefaultApiConfig struct {
 type InfuraApiConfig struct {
 	Key     string `yaml:"key"`
 	Enabled bool   `yaml:"enabled"`
+	// Upstreams are Ethereum mainnet JSON-RPC endpoints, Infura endpoint of Key by default
+	Upstreams UpstreamList   `yaml:"upstreams"`
+	Failover  FailoverConfig `yaml:"failover"`
 }
 
 // OneInchApiConfig providers configuration for 1inch api handler
 type OneInchApiConfig struct {
 	Key     string `yaml:"key"`
 	Enabled bool   `yaml:"enabled"`
+	// Upstreams are 1inch API base urls, https://api.1inch.dev by default
+	Upstreams UpstreamList   `yaml:"upstreams"`
+	Failover  FailoverConfig `yaml:"failover"`
+}
+
+// EvmApiConfig provides configuration for Ethereum JSON-RPC proxy api handler
+type EvmApiConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Upstreams are JSON-RPC endpoints keyed by chain ID
+	Upstreams map[uint64]UpstreamList `yaml:"upstreams"`
+	Failover  FailoverConfig          `yaml:"failover"`
+	// Methods are allow-listed eth_* methods, read-only methods are served if it's empty
+	Methods []string `yaml:"methods"`
+	// AllowStateChanges allows methods like eth_sendRawTransaction in Methods
+	AllowStateChanges bool `yaml:"allow_state_changes"`
+	// Timeout of upstream request if upstream has no timeout, 10s by default
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// TxRelayApiConfig provides configuration for transaction relay api handler
+type TxRelayApiConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Upstreams are JSON-RPC endpoints keyed by chain ID, transactions are sent to upstream of their chain
+	Upstreams map[uint64]UpstreamList `yaml:"upstreams"`
+	Failover  FailoverConfig          `yaml:"failover"`
+	// MaxGasLimit is max gas limit of relayed transaction, 30000000 by default
+	MaxGasLimit uint64 `yaml:"max_gas_limit"`
+	// MaxFeePerGas in wei caps fee of relayed transaction, empty means no cap
+	MaxFeePerGas string `yaml:"max_fee_per_gas"`
+	// MaxNonceGap is max difference between transaction nonce and sender pending nonce, 16 by default
+	MaxNonceGap uint64 `yaml:"max_nonce_gap"`
+	// Timeout of upstream requests of submission if upstream has no timeout, 10s by default
+	Timeout time.Duration `yaml:"timeout"`
+	// ReceiptTimeout is max time of waiting for receipt which holds the request, 30s by default
+	ReceiptTimeout time.Duration `yaml:"receipt_timeout"`
+	// ReceiptPollInterval is interval of receipt polling, 1s by default
+	ReceiptPollInterval time.Duration `yaml:"receipt_poll_interval"`
 }
 
 // ApiConfigs contains API-related configs
@@ -24,6 +70,10 @@ type ApiConfigs struct {
 	Default DefaultApiConfig `yaml:"default"`
 	Infura  InfuraApiConfig  `yaml:"infura"`
 	OneInch OneInchApiConfig `yaml:"1inch"`
+	Evm     EvmApiConfig     `yaml:"evm"`
+	TxRelay TxRelayApiConfig `yaml:"tx_relay"`
+	// Custom handlers are set by programs which embed resolver, they are keyed by namespace
+	Custom map[string]ApiHandler `yaml:"-"`
 }
 
 // MetricConfig contain params for configure metrics
@@ -32,6 +82,102 @@ type MetricConfig struct {
 	Port    uint `yaml:"port"`
 }
 
+// ReplayProtectionConfig contain params for configure replay protection of requests
+type ReplayProtectionConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Window is max allowed difference between request timestamp and resolver time
+	Window time.Duration `yaml:"window"`
+	// MaxFutureSkew is max allowed time of request timestamp ahead of resolver time, 5s by default, at most Window
+	MaxFutureSkew time.Duration `yaml:"max_future_skew"`
+	// MaxEntries is max count of nonces which are kept in replay cache, the oldest nonce is evicted when it's full
+	MaxEntries int `yaml:"max_entries"`
+}
+
+// CacheConfig contain params of cache of api handler responses
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxEntries is max count of cached responses, least recently used responses are evicted, 10000 by default
+	MaxEntries int `yaml:"max_entries"`
+	// MaxEntrySize is max size of cached result in bytes, larger results aren't cached, 64KiB by default
+	MaxEntrySize int `yaml:"max_entry_size"`
+	// Ttl of responses of methods without own ttl, 2s by default
+	Ttl time.Duration `yaml:"ttl"`
+	// Methods are cached methods keyed by method name with namespace, e.g. infura.GetWalletBalance, built-in methods are cached by default
+	Methods map[string]CacheMethodConfig `yaml:"methods"`
+}
+
+// CoalescingConfig contain params of coalescing of identical concurrent requests
+type CoalescingConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Methods are coalesced methods with namespace, e.g. infura.GetWalletBalance, built-in read methods are coalesced by default
+	Methods []string `yaml:"methods"`
+}
+
+// ClientQuotaConfig contain limits of requests of one client, zero means no limit
+type ClientQuotaConfig struct {
+	RequestsPerMinute uint `yaml:"requests_per_minute"`
+	// DailyLimit is max count of requests per UTC day
+	DailyLimit uint `yaml:"daily_limit"`
+}
+
+// ApiTokenConfig describes pre-shared api token of client
+type ApiTokenConfig struct {
+	// Name identifies client of the token in logs and metrics
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+	// Quota of the token, quota of client access config is used if it's not set
+	Quota *ClientQuotaConfig `yaml:"quota"`
+}
+
+// ClientAccessConfig contain params of access control and quotas of clients
+type ClientAccessConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Quota limits requests of each client
+	Quota ClientQuotaConfig `yaml:"quota"`
+	// AnonymousQuota limits requests of all clients without api token which aren't in Allow list together, Quota is used if it's not set
+	AnonymousQuota *ClientQuotaConfig `yaml:"anonymous_quota"`
+	// Allow lists hex compressed public keys of served clients, all clients are served if it's empty
+	Allow []string `yaml:"allow"`
+	// Deny lists hex compressed public keys of rejected clients, client can evade it with new key unless Allow is set
+	Deny []string `yaml:"deny"`
+	// RequireApiToken rejects requests without valid api token
+	RequireApiToken bool             `yaml:"require_api_token"`
+	ApiTokens       []ApiTokenConfig `yaml:"api_tokens"`
+	// MetricsMaxClients is max count of clients with own label in metrics, 100 by default
+	MetricsMaxClients int `yaml:"metrics_max_clients"`
+}
+
+// PaymentConfig contain params of payments for requests with vouchers of payment channel contract
+type PaymentConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// ChainId is chain ID of payment channel contract
+	ChainId uint64 `yaml:"chain_id"`
+	// Contract is address of payment channel contract
+	Contract string `yaml:"contract"`
+	// RpcUrl is JSON-RPC endpoint of the chain, deposits of payers are checked if it's set, it's required for settlement
+	RpcUrl string `yaml:"rpc_url"`
+	// Payee is address which is paid by vouchers, address of node key by default
+	Payee string `yaml:"payee"`
+	// Price is price of request in wei
+	Price string `yaml:"price"`
+	// MethodPrices are prices in wei keyed by method name with namespace, e.g. infura.GetWalletBalance, they override Price
+	MethodPrices map[string]string `yaml:"method_prices"`
+	// VouchersFile keeps latest voucher of every payer for settlement, vouchers are kept only in memory if it's empty
+	VouchersFile string `yaml:"vouchers_file"`
+	// DepositTtl is time during which fetched deposit of payer is used, 30s by default
+	DepositTtl time.Duration `yaml:"deposit_ttl"`
+}
+
+// KeyRotationConfig contain params of previous node key which is accepted during key rotation
+type KeyRotationConfig struct {
+	// PreviousPrivateKey is node key before rotation
+	PreviousPrivateKey string `yaml:"previous_private_key"`
+	// PreviousKeystore takes precedence over PreviousPrivateKey
+	PreviousKeystore signer.KeystoreConfig `yaml:"previous_keystore"`
+	// GracePeriod is time since resolver start during which previous key is accepted, zero means until it's removed from config
+	GracePeriod time.Duration `yaml:"grace_period"`
+}
+
 // Config represents resolver server config
 type Config struct {
 
@@ -41,13 +187,25 @@ type Config struct {
 	// Default resolver node key
 	PrivateKey string `yaml:"private_key"`
 
+	// Encrypted resolver node key, takes precedence over private key
+	Keystore signer.KeystoreConfig `yaml:"keystore"`
+
+	// Previous resolver node key, which is accepted after key rotation
+	KeyRotation KeyRotationConfig `yaml:"key_rotation"`
+
+	// Stake in wei which is sent on registration, required if registry has min resolver stake
+	Stake string `yaml:"stake"`
+
+	// Fee, gas and replacement policy of registry transactions
+	Transaction registry.TxConfig `yaml:"transaction"`
+
 	// Discovery contract address
 	ContractAddress string `yaml:"contract_address"`
 
 	// rpc url to blockchain node
 	RpcUrl string `yaml:"rpc_url"`
 
-	// Can be one or more of the following: default,infura
+	// Can be one or more of the following: default,infura,1inch
 	Apis ApiConfigs `yaml:"apis"`
 
 	// Default loglevel
@@ -55,4 +213,19 @@ type Config struct {
 
 	// Configuration metric
 	Metric MetricConfig `yaml:"metric"`
+
+	// Configuration replay protection
+	ReplayProtection ReplayProtectionConfig `yaml:"replay_protection"`
+
+	// Configuration of response cache
+	Cache CacheConfig `yaml:"cache"`
+
+	// Configuration of coalescing of identical concurrent requests
+	Coalescing CoalescingConfig `yaml:"coalescing"`
+
+	// Configuration of access control and quotas of clients
+	ClientAccess ClientAccessConfig `yaml:"client_access"`
+
+	// Configuration of payments for requests
+	Payment PaymentConfig `yaml:"payment"`
 }
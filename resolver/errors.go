@@ -0,0 +1,162 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	"github.com/1inch/p2p-network/resolver/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// errorClass is resolver error code and retryable flag of sentinel errors
+type errorClass struct {
+	code      pb.ErrorCode
+	retryable bool
+	errs      []error
+}
+
+var errorClasses = []errorClass{
+	{
+		code: pb.ErrorCode_ERR_INVALID_MESSAGE_FORMAT,
+		errs: []error{errEmptyRequest, errEmptyRequestId, errEmptyPayload, errEmptyPublicKey, errEmptyNonce, errEmptyTimestamp, errChainIdRequired},
+	},
+	{
+		code: pb.ErrorCode_ERR_METHOD_NOT_FOUND,
+		errs: []error{errUnrecognizedMethod, errStateChangingMethod},
+	},
+	{
+		code: pb.ErrorCode_ERR_INVALID_PARAMS,
+		errs: []error{
+			types.ErrInvalidParams, errWrongParamCount, errEmptyAddress, errEmptyBlock, errEmptyChainId, errInvalidFormatAddress,
+			errChainIdMustBeNumeric, errInvalidTransaction, errUnprotectedTransaction, errChainIdMismatch,
+			errNonceTooLow, errNonceTooHigh, errGasLimitTooHigh, errFeeCapTooHigh,
+		},
+	},
+	{
+		code: pb.ErrorCode_ERR_UNSUPPORTED_CHAIN,
+		errs: []error{errUnsupportedChainId, errChainIdNotSupported},
+	},
+	{
+		code: pb.ErrorCode_ERR_REPLAYED_REQUEST,
+		errs: []error{errStaleRequest, errReplayedRequest},
+	},
+	{
+		code: pb.ErrorCode_ERR_ACCESS_DENIED,
+		errs: []error{errClientDenied, errApiTokenRequired, errInvalidApiToken},
+	},
+	{
+		code:      pb.ErrorCode_ERR_QUOTA_EXCEEDED,
+		retryable: true,
+		errs:      []error{errRateLimitExceeded},
+	},
+	{
+		code: pb.ErrorCode_ERR_QUOTA_EXCEEDED,
+		errs: []error{errDailyLimitExceeded},
+	},
+	{
+		code: pb.ErrorCode_ERR_PAYMENT_REQUIRED,
+		errs: []error{errPaymentRequired, registry.ErrInvalidVoucher, errInsufficientPayment, errDepositExceeded, errPaymentChannelClosing},
+	},
+}
+
+// jsonRpcCodes are JSON-RPC 2.0 error codes of resolver error codes, other codes are internal errors
+var jsonRpcCodes = map[pb.ErrorCode]int{
+	pb.ErrorCode_ERR_INVALID_MESSAGE_FORMAT: types.CodeInvalidRequest,
+	pb.ErrorCode_ERR_REPLAYED_REQUEST:       types.CodeInvalidRequest,
+	pb.ErrorCode_ERR_UNSUPPORTED_CHAIN:      types.CodeInvalidRequest,
+	pb.ErrorCode_ERR_METHOD_NOT_FOUND:       types.CodeMethodNotFound,
+	pb.ErrorCode_ERR_INVALID_PARAMS:         types.CodeInvalidParams,
+	pb.ErrorCode_ERR_ACCESS_DENIED:          types.CodeAccessDenied,
+	pb.ErrorCode_ERR_QUOTA_EXCEEDED:         types.CodeLimitExceeded,
+	pb.ErrorCode_ERR_UPSTREAM_RATE_LIMITED:  types.CodeLimitExceeded,
+	pb.ErrorCode_ERR_PAYMENT_REQUIRED:       types.CodePaymentRequired,
+}
+
+// clientMessages are messages sent to client instead of messages of errors which may contain upstream urls
+// with api keys or other internal details, such errors are only logged by resolver
+var clientMessages = map[pb.ErrorCode]string{
+	pb.ErrorCode_ERR_INTERNAL_EXCEPTION:    "internal error",
+	pb.ErrorCode_ERR_UPSTREAM_FAILED:       "upstream request failed",
+	pb.ErrorCode_ERR_UPSTREAM_RATE_LIMITED: "upstream rate limit exceeded",
+	pb.ErrorCode_ERR_TIMEOUT:               "upstream request timed out",
+}
+
+// clientMessage returns message of handler error which is sent to client
+func clientMessage(handlerErr *types.HandlerError) string {
+	if message, ok := clientMessages[handlerErr.Code]; ok {
+		return message
+	}
+	return handlerErr.Error()
+}
+
+// handlerError returns handler error of err. Errors which aren't *types.HandlerError are classified
+// by errorClasses, errors of upstream response and timeouts, other errors are internal exceptions.
+// Requests rejected by upstream with 4xx status are upstream errors which aren't retryable.
+func handlerError(err error) *types.HandlerError {
+	var handlerErr *types.HandlerError
+	if errors.As(err, &handlerErr) {
+		return handlerErr
+	}
+
+	for _, class := range errorClasses {
+		for _, classErr := range class.errs {
+			if errors.Is(err, classErr) {
+				return types.NewHandlerError(class.code, class.retryable, err)
+			}
+		}
+	}
+
+	var rpcErr gethrpc.Error
+	var jsonErr *types.JsonError
+	switch {
+	case isTimeout(err):
+		return types.NewHandlerError(pb.ErrorCode_ERR_TIMEOUT, true, err)
+	case errors.As(err, &rpcErr):
+		return types.NewHandlerError(pb.ErrorCode_ERR_UPSTREAM_ERROR, false, err).WithDetail("upstream_code", strconv.Itoa(rpcErr.ErrorCode()))
+	case errors.As(err, &jsonErr):
+		return types.NewHandlerError(pb.ErrorCode_ERR_UPSTREAM_ERROR, false, err).WithDetail("upstream_code", strconv.Itoa(jsonErr.Code))
+	}
+
+	if status, ok := upstreamStatus(err); ok {
+		if !isRejectedStatus(status) {
+			return upstreamFailure(err)
+		}
+		handlerErr := types.NewHandlerError(pb.ErrorCode_ERR_UPSTREAM_ERROR, false, err)
+		if status != 0 {
+			handlerErr.WithDetail("upstream_status", strconv.Itoa(status))
+		}
+		return handlerErr
+	}
+
+	return types.NewHandlerError(pb.ErrorCode_ERR_INTERNAL_EXCEPTION, false, err)
+}
+
+// upstreamFailure returns retryable handler error of failed upstream request, it's rate limited error
+// if upstream responded with 429 status and timeout error if upstream didn't respond in time
+func upstreamFailure(err error) *types.HandlerError {
+	handlerErr := types.NewHandlerError(pb.ErrorCode_ERR_UPSTREAM_FAILED, true, err)
+
+	status, _ := upstreamStatus(err)
+	if status != 0 {
+		handlerErr.WithDetail("upstream_status", strconv.Itoa(status))
+	}
+
+	switch {
+	case status == http.StatusTooManyRequests:
+		handlerErr.Code = pb.ErrorCode_ERR_UPSTREAM_RATE_LIMITED
+	case isTimeout(err):
+		handlerErr.Code = pb.ErrorCode_ERR_TIMEOUT
+	}
+
+	return handlerErr
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
@@ -0,0 +1,123 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	"github.com/1inch/p2p-network/resolver/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerError(t *testing.T) {
+	typedErr := types.NewHandlerError(pb.ErrorCode_ERR_UPSTREAM_FAILED, true, errors.New("upstream failed")).WithDetail("upstream_status", "502")
+
+	testCases := []struct {
+		name      string
+		err       error
+		code      pb.ErrorCode
+		retryable bool
+		details   map[string]string
+	}{
+		{name: "Typed error", err: typedErr, code: pb.ErrorCode_ERR_UPSTREAM_FAILED, retryable: true, details: map[string]string{"upstream_status": "502"}},
+		{name: "Empty payload", err: errEmptyPayload, code: pb.ErrorCode_ERR_INVALID_MESSAGE_FORMAT},
+		{name: "Unrecognized method", err: errUnrecognizedMethod, code: pb.ErrorCode_ERR_METHOD_NOT_FOUND},
+		{name: "Invalid params", err: fmt.Errorf("%w: address", types.ErrInvalidParams), code: pb.ErrorCode_ERR_INVALID_PARAMS},
+		{name: "Unsupported chain", err: fmt.Errorf("%w: %d", errUnsupportedChainId, 10), code: pb.ErrorCode_ERR_UNSUPPORTED_CHAIN},
+		{name: "Replayed request", err: errReplayedRequest, code: pb.ErrorCode_ERR_REPLAYED_REQUEST},
+		{name: "Rate limit", err: errRateLimitExceeded, code: pb.ErrorCode_ERR_QUOTA_EXCEEDED, retryable: true},
+		{name: "Daily limit", err: errDailyLimitExceeded, code: pb.ErrorCode_ERR_QUOTA_EXCEEDED},
+		{name: "Payment required", err: errPaymentRequired, code: pb.ErrorCode_ERR_PAYMENT_REQUIRED},
+		{name: "Timeout", err: context.DeadlineExceeded, code: pb.ErrorCode_ERR_TIMEOUT, retryable: true},
+		{name: "Upstream response error", err: &types.JsonError{Code: 3, Message: "execution reverted"}, code: pb.ErrorCode_ERR_UPSTREAM_ERROR, details: map[string]string{"upstream_code": "3"}},
+		{name: "Rejected request", err: &upstreamStatusError{StatusCode: http.StatusUnauthorized, Err: errors.New("unauthorized")}, code: pb.ErrorCode_ERR_UPSTREAM_ERROR, details: map[string]string{"upstream_status": "401"}},
+		{name: "Rejected request without status", err: &upstreamStatusError{Err: errors.New("rejected")}, code: pb.ErrorCode_ERR_UPSTREAM_ERROR},
+		{name: "Unknown error", err: errors.New("unknown"), code: pb.ErrorCode_ERR_INTERNAL_EXCEPTION},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handlerErr := handlerError(testCase.err)
+			assert.Equal(t, testCase.code, handlerErr.Code)
+			assert.Equal(t, testCase.retryable, handlerErr.Retryable)
+			assert.Equal(t, testCase.details, handlerErr.Details)
+			assert.ErrorIs(t, handlerErr, testCase.err)
+		})
+	}
+
+	assert.Same(t, typedErr, handlerError(fmt.Errorf("wrapped: %w", typedErr)), "wrapped typed error is returned as is")
+
+	rejected := handlerError(gethrpc.HTTPError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"})
+	assert.Equal(t, pb.ErrorCode_ERR_UPSTREAM_ERROR, rejected.Code)
+	assert.False(t, rejected.Retryable, "request rejected by upstream isn't retryable")
+	assert.Equal(t, map[string]string{"upstream_status": "400"}, rejected.Details)
+}
+
+func TestUpstreamFailure(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		code    pb.ErrorCode
+		details map[string]string
+	}{
+		{name: "Connection error", err: errors.New("connection refused"), code: pb.ErrorCode_ERR_UPSTREAM_FAILED},
+		{name: "Server error", err: gethrpc.HTTPError{StatusCode: http.StatusBadGateway}, code: pb.ErrorCode_ERR_UPSTREAM_FAILED, details: map[string]string{"upstream_status": "502"}},
+		{name: "Rate limited", err: gethrpc.HTTPError{StatusCode: http.StatusTooManyRequests}, code: pb.ErrorCode_ERR_UPSTREAM_RATE_LIMITED, details: map[string]string{"upstream_status": "429"}},
+		{name: "Timeout", err: context.DeadlineExceeded, code: pb.ErrorCode_ERR_TIMEOUT},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			handlerErr := upstreamFailure(fmt.Errorf("%w: %w", errAllUpstreamsFailed, testCase.err))
+			assert.Equal(t, testCase.code, handlerErr.Code)
+			assert.True(t, handlerErr.Retryable)
+			assert.Equal(t, testCase.details, handlerErr.Details)
+			assert.ErrorIs(t, handlerErr, errAllUpstreamsFailed)
+		})
+	}
+}
+
+func TestJsonRpcError(t *testing.T) {
+	upstreamErr := &types.JsonError{Code: 3, Message: "execution reverted", Data: "0x08c379a0"}
+	assert.Equal(t, upstreamErr, jsonRpcError(upstreamErr), "upstream error is returned as is")
+
+	assert.Equal(t, &types.JsonError{Code: types.CodeMethodNotFound, Message: errUnrecognizedMethod.Error()}, jsonRpcError(errUnrecognizedMethod))
+
+	failure := upstreamFailure(gethrpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"})
+	assert.Equal(t, &types.JsonError{
+		Code:    types.CodeLimitExceeded,
+		Message: "upstream rate limit exceeded",
+		Data:    types.ErrorData{Retryable: true, Details: map[string]string{"upstream_status": "429"}},
+	}, jsonRpcError(failure))
+}
+
+func TestUpstreamUrlIsNotSent(t *testing.T) {
+	// upstream isn't listening, so error of request contains its url
+	upstreamUrl := "http://127.0.0.1:1/v3/secret-api-key"
+
+	cfg := &Config{}
+	cfg.Apis.Evm = EvmApiConfig{Enabled: true, Upstreams: map[uint64]UpstreamList{1: {{Url: upstreamUrl}}}}
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+
+	jsonRpcPayload, err := json.Marshal(&types.JsonRequest{JsonRpc: types.Version, Id: types.NumberId(1), Method: "eth_blockNumber"})
+	require.NoError(t, err)
+	resp, err := server.Execute(context.Background(), &pb.ResolverRequest{Id: "1", Payload: jsonRpcPayload})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"upstream request failed","data":{"retryable":true}}}`, string(resp.GetPayload()))
+
+	legacyPayload, err := json.Marshal(&types.JsonRequest{Id: types.NumberId(2), Method: "eth_blockNumber"})
+	require.NoError(t, err)
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "2", Payload: legacyPayload})
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetError())
+	assert.Equal(t, pb.ErrorCode_ERR_UPSTREAM_FAILED, resp.GetError().GetCode())
+	assert.Equal(t, "upstream request failed", resp.GetError().GetMessage())
+	assert.NotContains(t, resp.String(), "secret-api-key")
+}
@@ -0,0 +1,191 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// defaultEvmTimeout limits upstream request when timeout isn't configured
+const defaultEvmTimeout = 10 * time.Second
+
+var (
+	errNoEvmUpstream        = errors.New("evm api handler has no upstream")
+	errNotEthMethod         = errors.New("evm api handler serves only eth_* methods")
+	errStateChangingMethod  = errors.New("state changing method is not allowed")
+	errChainIdRequired      = errors.New("chainId is required when several chains are served")
+	errUnsupportedChainId   = errors.New("chain is not served")
+	errNamedParamsForbidden = errors.New("ethereum methods accept only positional params")
+)
+
+// defaultEvmMethods are read-only methods served by evm api handler when allow-list isn't configured
+var defaultEvmMethods = []string{
+	"eth_blockNumber",
+	"eth_call",
+	"eth_chainId",
+	"eth_estimateGas",
+	"eth_feeHistory",
+	"eth_gasPrice",
+	"eth_getBalance",
+	"eth_getBlockByHash",
+	"eth_getBlockByNumber",
+	"eth_getBlockReceipts",
+	"eth_getBlockTransactionCountByHash",
+	"eth_getBlockTransactionCountByNumber",
+	"eth_getCode",
+	"eth_getLogs",
+	"eth_getProof",
+	"eth_getStorageAt",
+	"eth_getTransactionByBlockHashAndIndex",
+	"eth_getTransactionByBlockNumberAndIndex",
+	"eth_getTransactionByHash",
+	"eth_getTransactionCount",
+	"eth_getTransactionReceipt",
+	"eth_maxPriorityFeePerGas",
+	"eth_syncing",
+}
+
+// stateChangingEvmMethods change chain or node state, they are served only if AllowStateChanges is set
+var stateChangingEvmMethods = []string{
+	"eth_sendRawTransaction",
+	"eth_sendTransaction",
+	"eth_sign",
+	"eth_signTransaction",
+	"eth_signTypedData",
+	"eth_signTypedData_v4",
+}
+
+// evmApiHandler forwards allow-listed Ethereum JSON-RPC requests unchanged to upstream node of request chain
+type evmApiHandler struct {
+	upstreams map[uint64]*upstreamPool[*gethrpc.Client]
+	methods   []string
+	chainIds  []uint64
+	logger    *slog.Logger
+}
+
+// NewEvmApiHandler creates an Ethereum JSON-RPC proxy handler instance
+func NewEvmApiHandler(cfg EvmApiConfig, logger *slog.Logger) (ApiHandler, error) {
+	if len(cfg.Upstreams) == 0 {
+		return nil, errNoEvmUpstream
+	}
+
+	methods := cfg.Methods
+	if len(methods) == 0 {
+		methods = defaultEvmMethods
+	}
+	for _, method := range methods {
+		if !strings.HasPrefix(method, "eth_") {
+			return nil, fmt.Errorf("%w: %s", errNotEthMethod, method)
+		}
+		if !cfg.AllowStateChanges && slices.Contains(stateChangingEvmMethods, method) {
+			return nil, fmt.Errorf("%w: %s, enable allow_state_changes to serve it", errStateChangingMethod, method)
+		}
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultEvmTimeout
+	}
+
+	handler := &evmApiHandler{
+		upstreams: make(map[uint64]*upstreamPool[*gethrpc.Client], len(cfg.Upstreams)),
+		methods:   slices.Clone(methods),
+		logger:    logger.With("module", "api-evm"),
+	}
+	for chainId, upstreams := range cfg.Upstreams {
+		pool, err := newUpstreamPool(upstreams, timeout, cfg.Failover, dialRpc, handler.logger.With(slog.Uint64("chain_id", chainId)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create upstreams of chain %d: %w", chainId, err)
+		}
+
+		handler.upstreams[chainId] = pool
+		handler.chainIds = append(handler.chainIds, chainId)
+	}
+	slices.Sort(handler.chainIds)
+
+	return handler, nil
+}
+
+// Process forwards request to upstream of request chain, upstream errors are returned as JSON-RPC errors with upstream code
+func (h *evmApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
+	if !slices.Contains(h.methods, req.Method) {
+		if slices.Contains(stateChangingEvmMethods, req.Method) {
+			return &types.JsonResponse{Id: req.Id, Result: 0}, errStateChangingMethod
+		}
+		return &types.JsonResponse{Id: req.Id, Result: 0}, errUnrecognizedMethod
+	}
+
+	upstreams, err := h.upstream(req.ChainId)
+	if err != nil {
+		return &types.JsonResponse{Id: req.Id, Result: 0}, err
+	}
+
+	var params []json.RawMessage
+	if len(req.Params) > 0 && string(req.Params) != "null" {
+		if err := req.UnmarshalParams(&params); err != nil {
+			return &types.JsonResponse{Id: req.Id, Result: 0}, fmt.Errorf("%w: %w", types.ErrInvalidParams, errNamedParamsForbidden)
+		}
+	}
+
+	args := make([]any, len(params))
+	for i, param := range params {
+		args[i] = param
+	}
+
+	var result json.RawMessage
+	err = upstreams.call(func(ctx context.Context, client *gethrpc.Client) error {
+		return client.CallContext(ctx, &result, req.Method, args...)
+	})
+	if err != nil {
+		h.logger.Error("failed invoking upstream JSON-RPC request", slog.String("method", req.Method), slog.Any("err", err))
+		return &types.JsonResponse{Id: req.Id, Result: 0}, upstreamError(err)
+	}
+
+	return &types.JsonResponse{Id: req.Id, Result: result}, nil
+}
+
+// Capabilities returns allow-listed methods and chain IDs of upstreams
+func (h *evmApiHandler) Capabilities() HandlerCapabilities {
+	return HandlerCapabilities{Methods: slices.Clone(h.methods), ChainIds: slices.Clone(h.chainIds)}
+}
+
+// upstream returns upstreams of the chain, chain ID may be omitted if the handler serves one chain
+func (h *evmApiHandler) upstream(chainId uint64) (*upstreamPool[*gethrpc.Client], error) {
+	if chainId == 0 {
+		if len(h.chainIds) != 1 {
+			return nil, errChainIdRequired
+		}
+		chainId = h.chainIds[0]
+	}
+
+	upstreams, ok := h.upstreams[chainId]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedChainId, chainId)
+	}
+
+	return upstreams, nil
+}
+
+// upstreamError keeps code, message and data of upstream JSON-RPC error, so they are forwarded unchanged
+func upstreamError(err error) error {
+	var rpcErr gethrpc.Error
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+
+	jsonErr := &types.JsonError{Code: rpcErr.ErrorCode(), Message: rpcErr.Error()}
+	var dataErr gethrpc.DataError
+	if errors.As(err, &dataErr) {
+		jsonErr.Data = dataErr.ErrorData()
+	}
+
+	return jsonErr
+}
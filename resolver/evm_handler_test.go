@@ -0,0 +1,162 @@
+package resolver
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// revertError is JSON-RPC error with code and data like node returns for reverted eth_call
+type revertError struct{}
+
+func (revertError) Error() string          { return "execution reverted" }
+func (revertError) ErrorCode() int         { return 3 }
+func (revertError) ErrorData() interface{} { return "0x08c379a0" }
+
+// ethService is upstream node of one chain
+type ethService struct {
+	blockNumber string
+}
+
+func (s *ethService) BlockNumber() string {
+	return s.blockNumber
+}
+
+func (s *ethService) GetBalance(address, block string) string {
+	return "0x" + address[len(address)-2:]
+}
+
+func (s *ethService) Call(tx map[string]any, block string) (string, error) {
+	return "", revertError{}
+}
+
+func (s *ethService) SendRawTransaction(tx string) string {
+	return "0xhash"
+}
+
+func newEthUpstream(t *testing.T, blockNumber string) string {
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", &ethService{blockNumber: blockNumber}))
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func TestEvmApiHandler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	handler, err := NewEvmApiHandler(EvmApiConfig{
+		Enabled: true,
+		Upstreams: map[uint64]UpstreamList{
+			1:     {{Url: newEthUpstream(t, "0x1")}},
+			31337: {{Url: newEthUpstream(t, "0x7a69")}},
+		},
+	}, logger)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		request *types.JsonRequest
+		result  string
+		err     error
+		jsonErr *types.JsonError
+	}{
+		{
+			name:    "Method is forwarded to upstream of chain",
+			request: &types.JsonRequest{Method: "eth_blockNumber", ChainId: 31337},
+			result:  `"0x7a69"`,
+		},
+		{
+			name:    "Params are forwarded unchanged",
+			request: &types.JsonRequest{Method: "eth_getBalance", Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"), ChainId: 1},
+			result:  `"0x24"`,
+		},
+		{
+			name:    "Upstream error keeps code and data",
+			request: &types.JsonRequest{Method: "eth_call", Params: json.RawMessage(`[{"to":"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324"},"latest"]`), ChainId: 1},
+			jsonErr: &types.JsonError{Code: 3, Message: "execution reverted", Data: "0x08c379a0"},
+		},
+		{
+			name:    "Chain is required",
+			request: &types.JsonRequest{Method: "eth_blockNumber"},
+			err:     errChainIdRequired,
+		},
+		{
+			name:    "Chain is not served",
+			request: &types.JsonRequest{Method: "eth_blockNumber", ChainId: 137},
+			err:     errUnsupportedChainId,
+		},
+		{
+			name:    "Named params",
+			request: &types.JsonRequest{Method: "eth_getBalance", Params: json.RawMessage(`{"address":"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324"}`), ChainId: 1},
+			err:     types.ErrInvalidParams,
+		},
+		{
+			name:    "State changing method",
+			request: &types.JsonRequest{Method: "eth_sendRawTransaction", Params: types.Params("0x02"), ChainId: 1},
+			err:     errStateChangingMethod,
+		},
+		{
+			name:    "Method is not allow-listed",
+			request: &types.JsonRequest{Method: "eth_newFilter", ChainId: 1},
+			err:     errUnrecognizedMethod,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp, err := handler.Process(testCase.request)
+			if testCase.jsonErr != nil {
+				assert.Equal(t, testCase.jsonErr, err)
+				return
+			}
+			if testCase.err != nil {
+				assert.ErrorIs(t, err, testCase.err)
+				return
+			}
+
+			require.NoError(t, err)
+			result, err := json.Marshal(resp.Result)
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.result, string(result))
+		})
+	}
+
+	assert.Equal(t, []uint64{1, 31337}, handler.Capabilities().ChainIds)
+	assert.Equal(t, defaultEvmMethods, handler.Capabilities().Methods)
+}
+
+func TestEvmApiHandlerStateChanges(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	upstream := newEthUpstream(t, "0x1")
+
+	_, err := NewEvmApiHandler(EvmApiConfig{Upstreams: map[uint64]UpstreamList{1: {{Url: upstream}}}, Methods: []string{"eth_sendRawTransaction"}}, logger)
+	assert.ErrorIs(t, err, errStateChangingMethod, "state changing method must be enabled explicitly")
+
+	_, err = NewEvmApiHandler(EvmApiConfig{Upstreams: map[uint64]UpstreamList{1: {{Url: upstream}}}, Methods: []string{"debug_traceCall"}}, logger)
+	assert.ErrorIs(t, err, errNotEthMethod)
+
+	_, err = NewEvmApiHandler(EvmApiConfig{Methods: []string{"eth_blockNumber"}}, logger)
+	assert.ErrorIs(t, err, errNoEvmUpstream)
+
+	handler, err := NewEvmApiHandler(EvmApiConfig{
+		Upstreams:         map[uint64]UpstreamList{1: {{Url: upstream}}},
+		Methods:           []string{"eth_blockNumber", "eth_sendRawTransaction"},
+		AllowStateChanges: true,
+	}, logger)
+	require.NoError(t, err)
+
+	resp, err := handler.Process(&types.JsonRequest{Method: "eth_sendRawTransaction", Params: types.Params("0x02")})
+	require.NoError(t, err, "chain may be omitted when handler serves one chain")
+	assert.JSONEq(t, `"0xhash"`, string(resp.Result.(json.RawMessage)))
+}
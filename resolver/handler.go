@@ -9,15 +9,29 @@ import (
 
 var (
 	errUnrecognizedMethod = errors.New("unrecognized method")
-	errWrongParamCount    = errors.New("wrong number of params")
+	errWrongParamCount    = types.ErrWrongParamCount
 	errEmptyAddress       = errors.New("empty address")
 	errEmptyBlock         = errors.New("empty block")
 	errEmptyChainId       = errors.New("empty chainId")
 )
 
+const methodGetWalletBalance = "GetWalletBalance"
+
+// walletBalanceParamNames are names of GetWalletBalance params in named params, positional params have the same order
+var walletBalanceParamNames = []string{"address", "block"}
+
 // ApiHandler provides Process() method for handling JSON payloads
 type ApiHandler interface {
 	Process(*types.JsonRequest) (*types.JsonResponse, error)
+	// Capabilities returns requests served by the handler, they are published in node registry
+	Capabilities() HandlerCapabilities
+}
+
+// HandlerCapabilities describes requests served by ApiHandler
+type HandlerCapabilities struct {
+	Methods []string
+	// ChainIds are empty if requests are not chain specific
+	ChainIds []uint64
 }
 
 type defaultApiHandler struct {
@@ -32,8 +46,8 @@ func NewDefaultApiHandler(cfg DefaultApiConfig, logger *slog.Logger) ApiHandler
 // Process acts as an API wrapper for JSON payloads coming through gRPC
 func (h *defaultApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
 	switch req.Method {
-	case "GetWalletBalance":
-		balance, err := h.getWalletBalance(req.Params)
+	case methodGetWalletBalance:
+		balance, err := h.getWalletBalance(req)
 		if err != nil {
 			return &types.JsonResponse{Id: req.Id, Result: 0}, err
 		}
@@ -43,10 +57,16 @@ func (h *defaultApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse
 	}
 }
 
-func (h *defaultApiHandler) getWalletBalance(params []string) (int, error) {
-	if len(params) != 2 {
-		h.logger.Error("GetWalletBalance: wrong number of params", "cnt", len(params))
-		return 0, errWrongParamCount
+// Capabilities returns requests served by the handler
+func (h *defaultApiHandler) Capabilities() HandlerCapabilities {
+	return HandlerCapabilities{Methods: []string{methodGetWalletBalance}}
+}
+
+func (h *defaultApiHandler) getWalletBalance(req *types.JsonRequest) (int, error) {
+	var address, block string
+	if err := req.BindParams(walletBalanceParamNames, &address, &block); err != nil {
+		h.logger.Error("GetWalletBalance: failed to decode params", slog.Any("err", err))
+		return 0, err
 	}
 	h.logger.Info("GetWalletBalance() processed")
 	return 555, nil
@@ -1,33 +1,46 @@
 package resolver
 
 import (
+	"context"
 	"log/slog"
+	"time"
 
 	"github.com/1inch/p2p-network/resolver/types"
 	"github.com/ethereum/go-ethereum/common"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
+const (
+	infuraUrl            = "https://mainnet.infura.io/v3/"
+	defaultInfuraTimeout = 10 * time.Second
+)
+
 type infuraApiHandler struct {
-	client *gethrpc.Client
-	logger *slog.Logger
+	upstreams *upstreamPool[*gethrpc.Client]
+	logger    *slog.Logger
 }
 
-// NewInfuraApiHandler creates an Infura API handler instance
-func NewInfuraApiHandler(cfg InfuraApiConfig, logger *slog.Logger) ApiHandler {
-	client, err := gethrpc.DialHTTP("https://mainnet.infura.io/v3/" + cfg.Key)
+// NewInfuraApiHandler creates an Infura API handler instance, Infura endpoint of the key is used if upstreams aren't configured
+func NewInfuraApiHandler(cfg InfuraApiConfig, logger *slog.Logger) (ApiHandler, error) {
+	logger = logger.With("module", "api-infura")
+	upstreams, err := newUpstreamPool(upstreamsOf(cfg.Upstreams, infuraUrl+cfg.Key), defaultInfuraTimeout, cfg.Failover, dialRpc, logger)
 	if err != nil {
-		slog.Error("error creating Infura API client", "err", err)
-		return nil
+		return nil, err
 	}
-	return &infuraApiHandler{client, logger.With("module", "api-infura")}
+
+	return &infuraApiHandler{upstreams: upstreams, logger: logger}, nil
+}
+
+// dialRpc creates JSON-RPC client of HTTP or WebSocket endpoint
+func dialRpc(url string) (*gethrpc.Client, error) {
+	return gethrpc.DialOptions(context.Background(), url)
 }
 
 // Process acts as an API wrapper for JSON payloads coming through gRPC
 func (h *infuraApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
 	switch req.Method {
-	case "GetWalletBalance":
-		balance, err := h.getWalletBalance(req.Params)
+	case methodGetWalletBalance:
+		balance, err := h.getWalletBalance(req)
 		if err != nil {
 			return &types.JsonResponse{Id: req.Id, Result: 0}, err
 		}
@@ -37,22 +50,29 @@ func (h *infuraApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse,
 	}
 }
 
-func (h *infuraApiHandler) getWalletBalance(params []string) (string, error) {
-	if len(params) != 2 {
-		h.logger.Error("GetWalletBalance: wrong number of params", "cnt", len(params))
-		return "", errWrongParamCount
+// Capabilities returns requests served by the handler, Infura endpoint serves Ethereum mainnet
+func (h *infuraApiHandler) Capabilities() HandlerCapabilities {
+	return HandlerCapabilities{Methods: []string{methodGetWalletBalance}, ChainIds: []uint64{1}}
+}
+
+func (h *infuraApiHandler) getWalletBalance(req *types.JsonRequest) (string, error) {
+	var address, block string
+	err := req.BindParams(walletBalanceParamNames, &address, &block)
+	if err != nil {
+		h.logger.Error("GetWalletBalance: failed to decode params", slog.Any("err", err))
+		return "", err
 	}
-	address := params[0]
-	block := params[1]
 
-	err := h.validateRequest(address, block)
+	err = h.validateRequest(address, block)
 	if err != nil {
 		h.logger.Error("failed validate request for GetWalletBalance")
 		return "", err
 	}
 
 	var result string
-	err = h.client.Call(&result, "eth_getBalance", address, block)
+	err = h.upstreams.call(func(ctx context.Context, client *gethrpc.Client) error {
+		return client.CallContext(ctx, &result, "eth_getBalance", address, block)
+	})
 	if err != nil {
 		h.logger.Error("failed invoking JSON-RPC request", "err", err)
 		return "", err
@@ -0,0 +1,145 @@
+package resolver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"github.com/1inch/p2p-network/resolver/types"
+)
+
+var (
+	errInvalidVersion = errors.New(`jsonrpc must be "` + types.Version + `"`)
+	errEmptyMethod    = errors.New("empty method")
+	errEmptyBatch     = errors.New("empty batch")
+)
+
+// isBatch returns true if payload is JSON array
+func isBatch(payload []byte) bool {
+	payload = bytes.TrimSpace(payload)
+	return len(payload) > 0 && payload[0] == '['
+}
+
+// processBatch processes each request of batch, response has responses of all requests except notifications.
+// Empty response is returned if batch has only notifications.
+func (s *Server) processBatch(payload []byte, clientKey []byte) ([]byte, error) {
+	var batch []json.RawMessage
+	if err := json.Unmarshal(payload, &batch); err != nil {
+		s.logger.Error("failed unmarshal batch payload", slog.Any("err", err))
+		return s.marshalJsonRpcResponse(newJsonRpcErrorResponse(nil, &types.JsonError{Code: types.CodeParseError, Message: err.Error()}))
+	}
+
+	if len(batch) == 0 {
+		return s.marshalJsonRpcResponse(newJsonRpcErrorResponse(nil, &types.JsonError{Code: types.CodeInvalidRequest, Message: errEmptyBatch.Error()}))
+	}
+
+	responses := make([]*types.JsonResponse, 0, len(batch))
+	for _, raw := range batch {
+		var jsonReq types.JsonRequest
+		if err := json.Unmarshal(raw, &jsonReq); err != nil {
+			responses = append(responses, newJsonRpcErrorResponse(nil, &types.JsonError{Code: types.CodeInvalidRequest, Message: err.Error()}))
+			continue
+		}
+
+		if resp := s.processJsonRpcRequest(&jsonReq, clientKey); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+
+	if len(responses) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(responses)
+}
+
+// processJsonRpcRequest processes JSON-RPC 2.0 request, it returns nil for notification
+func (s *Server) processJsonRpcRequest(jsonReq *types.JsonRequest, clientKey []byte) *types.JsonResponse {
+	resp := s.processJsonRpcCall(jsonReq, clientKey)
+	if jsonReq.IsNotification() {
+		if resp.Error != nil {
+			s.logger.Debug("notification failed", slog.String("method", jsonReq.Method), slog.Any("err", resp.Error))
+		}
+		return nil
+	}
+
+	return resp
+}
+
+func (s *Server) processJsonRpcCall(jsonReq *types.JsonRequest, clientKey []byte) *types.JsonResponse {
+	if jsonReq.JsonRpc != types.Version {
+		return newJsonRpcErrorResponse(jsonReq.Id, &types.JsonError{Code: types.CodeInvalidRequest, Message: errInvalidVersion.Error()})
+	}
+
+	if jsonReq.Method == "" {
+		return newJsonRpcErrorResponse(jsonReq.Id, &types.JsonError{Code: types.CodeInvalidRequest, Message: errEmptyMethod.Error()})
+	}
+
+	if err := s.checkReplay(jsonReq); err != nil {
+		return newJsonRpcErrorResponse(jsonReq.Id, jsonRpcError(err))
+	}
+
+	if err := s.checkClient(jsonReq, clientKey); err != nil {
+		return newJsonRpcErrorResponse(jsonReq.Id, jsonRpcError(err))
+	}
+
+	if err := s.checkPayment(jsonReq); err != nil {
+		return newJsonRpcErrorResponse(jsonReq.Id, jsonRpcError(err))
+	}
+
+	if err := s.checkQuota(jsonReq, clientKey); err != nil {
+		return newJsonRpcErrorResponse(jsonReq.Id, jsonRpcError(err))
+	}
+
+	resp, err := s.handler.Process(jsonReq)
+	if err != nil {
+		s.logger.Error("failed process request in handler", slog.String("method", jsonReq.Method), slog.Any("err", err))
+		s.release(jsonReq, clientKey, err)
+		return newJsonRpcErrorResponse(jsonReq.Id, jsonRpcError(err))
+	}
+
+	return &types.JsonResponse{
+		JsonRpc: types.Version,
+		Id:      jsonReq.Id,
+		Result:  resp.Result,
+	}
+}
+
+func (s *Server) marshalJsonRpcResponse(resp *types.JsonResponse) ([]byte, error) {
+	if resp == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(resp)
+}
+
+func newJsonRpcErrorResponse(id types.Id, err *types.JsonError) *types.JsonResponse {
+	return &types.JsonResponse{
+		JsonRpc: types.Version,
+		Id:      id,
+		Error:   err,
+	}
+}
+
+// jsonRpcError returns JSON-RPC 2.0 error object of handler error, errors of upstream JSON-RPC response are returned as is.
+// Messages of upstream failures and internal errors are replaced by fixed messages, see clientMessage.
+// Data of error object has retryable flag and details of retryable handler error or handler error with details.
+func jsonRpcError(err error) *types.JsonError {
+	var jsonErr *types.JsonError
+	if errors.As(err, &jsonErr) {
+		return jsonErr
+	}
+
+	handlerErr := handlerError(err)
+	code, ok := jsonRpcCodes[handlerErr.Code]
+	if !ok {
+		code = types.CodeInternalError
+	}
+
+	jsonErr = &types.JsonError{Code: code, Message: clientMessage(handlerErr)}
+	if handlerErr.Retryable || len(handlerErr.Details) > 0 {
+		jsonErr.Data = types.ErrorData{Retryable: handlerErr.Retryable, Details: handlerErr.Details}
+	}
+	return jsonErr
+}
@@ -0,0 +1,108 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteJsonRpc(t *testing.T) {
+	cfg := &Config{}
+	cfg.Apis.Default.Enabled = true
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		payload  string
+		expected string
+	}{
+		{
+			name:     "Positional params",
+			payload:  `{"jsonrpc":"2.0","id":1,"method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]}`,
+			expected: `{"jsonrpc":"2.0","id":1,"result":555}`,
+		},
+		{
+			name:     "Named params",
+			payload:  `{"jsonrpc":"2.0","id":"a","method":"default.GetWalletBalance","params":{"address":"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","block":"latest"}}`,
+			expected: `{"jsonrpc":"2.0","id":"a","result":555}`,
+		},
+		{
+			name:     "Null id",
+			payload:  `{"jsonrpc":"2.0","id":null,"method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]}`,
+			expected: `{"jsonrpc":"2.0","id":null,"result":555}`,
+		},
+		{
+			name:     "Unknown method",
+			payload:  `{"jsonrpc":"2.0","id":2,"method":"GetBlock","params":[]}`,
+			expected: `{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"unrecognized method"}}`,
+		},
+		{
+			name:     "Wrong param count",
+			payload:  `{"jsonrpc":"2.0","id":3,"method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324"]}`,
+			expected: `{"jsonrpc":"2.0","id":3,"error":{"code":-32602,"message":"wrong number of params"}}`,
+		},
+		{
+			name:     "Param of wrong type",
+			payload:  `{"jsonrpc":"2.0","id":4,"method":"GetWalletBalance","params":[1,"latest"]}`,
+			expected: `{"jsonrpc":"2.0","id":4,"error":{"code":-32602,"message":"invalid params: address: json: cannot unmarshal number into Go value of type string"}}`,
+		},
+		{
+			name:     "Wrong version",
+			payload:  `{"jsonrpc":"1.0","id":5,"method":"GetWalletBalance","params":[]}`,
+			expected: `{"jsonrpc":"2.0","id":5,"error":{"code":-32600,"message":"jsonrpc must be \"2.0\""}}`,
+		},
+		{
+			name:     "Notification",
+			payload:  `{"jsonrpc":"2.0","method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]}`,
+			expected: ``,
+		},
+		{
+			name: "Batch",
+			payload: `[
+				{"jsonrpc":"2.0","id":1,"method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]},
+				{"jsonrpc":"2.0","method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]},
+				{"jsonrpc":"2.0","id":2,"method":"GetBlock"},
+				1
+			]`,
+			expected: `[
+				{"jsonrpc":"2.0","id":1,"result":555},
+				{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"unrecognized method"}},
+				{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"json: cannot unmarshal number into Go value of type types.JsonRequest"}}
+			]`,
+		},
+		{
+			name:     "Batch of notifications",
+			payload:  `[{"jsonrpc":"2.0","method":"GetWalletBalance","params":[]}]`,
+			expected: ``,
+		},
+		{
+			name:     "Empty batch",
+			payload:  `[]`,
+			expected: `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"empty batch"}}`,
+		},
+		{
+			name:     "Invalid batch",
+			payload:  `[{"jsonrpc":"2.0","id":1,"method":"GetWalletBalance"`,
+			expected: `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"unexpected end of JSON input"}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp, err := server.Execute(context.Background(), &pb.ResolverRequest{Id: "1", Payload: []byte(testCase.payload)})
+			require.NoError(t, err)
+			require.Nil(t, resp.GetError(), "JSON-RPC 2.0 errors must be returned in payload")
+
+			if testCase.expected == "" {
+				assert.Empty(t, resp.GetPayload())
+				return
+			}
+			assert.JSONEq(t, testCase.expected, string(resp.GetPayload()))
+		})
+	}
+}
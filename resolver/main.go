@@ -55,6 +55,18 @@ func New(cfg Config, logger *slog.Logger) (*Resolver, error) {
 	if cfg.Metric.Enabled {
 		serverMetrics := grpcprom.NewServerMetrics()
 		registry.MustRegister(serverMetrics)
+		if server != nil && server.handler.cache != nil {
+			registry.MustRegister(server.handler.cache.metrics)
+		}
+		if server != nil && server.handler.coalescer != nil {
+			registry.MustRegister(server.handler.coalescer.metrics)
+		}
+		if server != nil && server.clientAccess != nil {
+			registry.MustRegister(server.clientAccess.metrics)
+		}
+		if server != nil && server.payments != nil {
+			registry.MustRegister(server.payments.metrics)
+		}
 
 		if err != nil {
 			logger.Error("failed to start prometheus exporter", slog.Any("err", err.Error()))
@@ -91,6 +103,7 @@ func newGrpcServer(logger *slog.Logger, server *Server, opts ...grpc.ServerOptio
 	healthServer := health.NewServer()
 
 	pb.RegisterExecuteServer(grpcServer, server)
+	pb.RegisterLivenessServer(grpcServer, server)
 	grpchealth.RegisterHealthServer(grpcServer, healthServer)
 
 	// TODO maybe need make this turn on/off by configuration?
@@ -0,0 +1,45 @@
+package resolver
+
+import (
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+)
+
+// NodeListEntry returns resolver entry for node list file which is used instead of node registry contract.
+func NodeListEntry(logger *slog.Logger, cfg *Config) (registry.ResolverEntry, error) {
+	if err := validateEndpoint(cfg.GrpcEndpoint); err != nil {
+		return registry.ResolverEntry{}, err
+	}
+
+	if cfg.Stake != "" {
+		if _, err := ParseWei(cfg.Stake); err != nil {
+			return registry.ResolverEntry{}, err
+		}
+	}
+
+	nodeSigner, err := signer.New(cfg.PrivateKey, cfg.Keystore)
+	if err != nil {
+		return registry.ResolverEntry{}, err
+	}
+
+	capabilities := handlerCapabilities(cfg, logger)
+	chainIds := make([]uint64, len(capabilities.ChainIds))
+	for i, chainId := range capabilities.ChainIds {
+		chainIds[i] = chainId.Uint64()
+	}
+
+	return registry.ResolverEntry{
+		IP:        cfg.GrpcEndpoint,
+		PublicKey: hex.EncodeToString(signer.CompressedPublicKey(nodeSigner)),
+		Stake:     cfg.Stake,
+		Capabilities: registry.CapabilitiesEntry{
+			Methods:         capabilities.Methods,
+			ChainIds:        chainIds,
+			ProtocolVersion: capabilities.ProtocolVersion,
+			Encrypted:       capabilities.Encrypted,
+		},
+	}, nil
+}
@@ -0,0 +1,491 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultDepositTtl = 30 * time.Second
+	// channelFetchTimeout limits fetching of payer channel from payment channel contract
+	channelFetchTimeout = 10 * time.Second
+)
+
+var (
+	errPaymentRequired       = errors.New("payment voucher is required")
+	errInsufficientPayment   = errors.New("insufficient payment")
+	errDepositExceeded       = errors.New("voucher amount exceeds deposit of payer")
+	errPaymentChannelClosing = errors.New("withdrawal of payer deposit is requested")
+	errInvalidPaymentConfig  = errors.New("invalid payment config")
+	errVouchersFileMismatch  = errors.New("vouchers file belongs to other payment channel")
+)
+
+// paymentChannelReader fetches channels of payment channel contract, it's implemented by registry.PaymentChannel
+type paymentChannelReader interface {
+	GetChannel(ctx context.Context, payer, payee common.Address) (registry.PaymentChannelState, error)
+}
+
+type fetchedChannel struct {
+	state     registry.PaymentChannelState
+	fetchedAt time.Time
+}
+
+// payments charges requests by vouchers of payment channel contract. Voucher is cumulative amount signed by payer,
+// so every request must increase amount of the latest accepted voucher of its payer at least by price of the method.
+// Latest vouchers are kept in vouchers file until they are settled by payee.
+type payments struct {
+	domain registry.PaymentDomain
+	payee  common.Address
+	price  *big.Int
+	// methodPrices are keyed by method name with namespace
+	methodPrices map[string]*big.Int
+	// channel is nil when deposits of payers aren't checked
+	channel      paymentChannelReader
+	depositTtl   time.Duration
+	vouchersFile string
+	metrics      *paymentMetrics
+	logger       *slog.Logger
+	now          func() time.Time
+
+	mu sync.Mutex
+	// vouchers are latest accepted vouchers keyed by payer
+	vouchers map[common.Address]registry.Voucher
+	// channels are fetched channels keyed by payer
+	channels map[common.Address]fetchedChannel
+	// replaced are vouchers replaced by the latest vouchers keyed by payer, they are restored by refund
+	replaced map[common.Address]replacedVoucher
+}
+
+// replacedVoucher is voucher replaced by the latest voucher of payer and amount received by the latest voucher
+type replacedVoucher struct {
+	// voucher is nil if payer had no voucher
+	voucher  *registry.Voucher
+	received *big.Int
+}
+
+func newPayments(cfg PaymentConfig, nodeSigner signer.Signer, logger *slog.Logger) (*payments, error) {
+	domain, payee, err := paymentDomain(cfg, nodeSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	price := new(big.Int)
+	if cfg.Price != "" {
+		if price, err = parsePrice(cfg.Price); err != nil {
+			return nil, err
+		}
+	}
+
+	methodPrices := make(map[string]*big.Int, len(cfg.MethodPrices))
+	for name, methodPrice := range cfg.MethodPrices {
+		if _, _, err := splitMethodName(name); err != nil {
+			return nil, err
+		}
+		if methodPrices[name], err = parsePrice(methodPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	vouchers, err := loadVouchers(cfg.VouchersFile, domain, payee)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &payments{
+		domain:       domain,
+		payee:        payee,
+		price:        price,
+		methodPrices: methodPrices,
+		depositTtl:   cfg.DepositTtl,
+		vouchersFile: cfg.VouchersFile,
+		metrics:      newPaymentMetrics(),
+		logger:       logger.With("module", "payments"),
+		now:          time.Now,
+		vouchers:     vouchers,
+		channels:     make(map[common.Address]fetchedChannel),
+		replaced:     make(map[common.Address]replacedVoucher),
+	}
+	if p.depositTtl <= 0 {
+		p.depositTtl = defaultDepositTtl
+	}
+
+	if cfg.RpcUrl != "" {
+		client, err := registry.Dial(context.Background(), &registry.Config{DialURI: cfg.RpcUrl})
+		if err != nil {
+			return nil, err
+		}
+		if p.channel, err = client.PaymentChannel(domain.Contract); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// paymentDomain returns domain of vouchers and payee of config, payee is address of node key by default
+func paymentDomain(cfg PaymentConfig, nodeSigner signer.Signer) (registry.PaymentDomain, common.Address, error) {
+	if cfg.ChainId == 0 {
+		return registry.PaymentDomain{}, common.Address{}, fmt.Errorf("%w: chain id is required", errInvalidPaymentConfig)
+	}
+	if !common.IsHexAddress(cfg.Contract) {
+		return registry.PaymentDomain{}, common.Address{}, fmt.Errorf("%w: invalid contract address %q", errInvalidPaymentConfig, cfg.Contract)
+	}
+
+	payee := nodeSigner.Address()
+	if cfg.Payee != "" {
+		if !common.IsHexAddress(cfg.Payee) {
+			return registry.PaymentDomain{}, common.Address{}, fmt.Errorf("%w: invalid payee address %q", errInvalidPaymentConfig, cfg.Payee)
+		}
+		payee = common.HexToAddress(cfg.Payee)
+	}
+
+	domain := registry.PaymentDomain{ChainId: new(big.Int).SetUint64(cfg.ChainId), Contract: common.HexToAddress(cfg.Contract)}
+	return domain, payee, nil
+}
+
+func parsePrice(price string) (*big.Int, error) {
+	wei, ok := new(big.Int).SetString(price, 10)
+	if !ok || wei.Sign() < 0 {
+		return nil, fmt.Errorf("%w: invalid price %q", errInvalidPaymentConfig, price)
+	}
+	return wei, nil
+}
+
+// priceOf returns price of method with namespace
+func (p *payments) priceOf(method string) *big.Int {
+	if price, ok := p.methodPrices[method]; ok {
+		return price
+	}
+	return p.price
+}
+
+// check verifies voucher of request to method with namespace and records it as the latest voucher of payer.
+// Voucher isn't required for free methods.
+func (p *payments) check(method string, voucher *types.Voucher) error {
+	price := p.priceOf(method)
+	if price.Sign() == 0 {
+		return nil
+	}
+
+	err := p.accept(price, voucher)
+
+	result := "paid"
+	switch {
+	case errors.Is(err, errPaymentRequired):
+		result = "required"
+	case errors.Is(err, registry.ErrInvalidVoucher):
+		result = "invalid"
+	case errors.Is(err, errInsufficientPayment):
+		result = "insufficient"
+	case errors.Is(err, errDepositExceeded):
+		result = "deposit_exceeded"
+	case errors.Is(err, errPaymentChannelClosing):
+		result = "channel_closing"
+	case err != nil:
+		result = "failed"
+	}
+	p.metrics.requests.WithLabelValues(result).Inc()
+
+	return err
+}
+
+func (p *payments) accept(price *big.Int, voucher *types.Voucher) error {
+	if voucher == nil {
+		return fmt.Errorf("%w: price is %s wei", errPaymentRequired, price)
+	}
+
+	parsed, err := p.parse(voucher)
+	if err != nil {
+		return err
+	}
+	if err := p.domain.VerifyVoucher(parsed); err != nil {
+		return err
+	}
+
+	settled := new(big.Int)
+	if p.channel != nil {
+		state, err := p.channelState(parsed.Payer, parsed.Amount)
+		if err != nil {
+			return err
+		}
+		if !state.WithdrawableAt.IsZero() {
+			return errPaymentChannelClosing
+		}
+		if parsed.Amount.Cmp(state.Deposit) > 0 {
+			return fmt.Errorf("%w: deposit is %s wei", errDepositExceeded, state.Deposit)
+		}
+		settled = state.Settled
+	}
+
+	return p.record(parsed, price, settled)
+}
+
+// parse decodes voucher of request, payee of the voucher is payee of the resolver
+func (p *payments) parse(voucher *types.Voucher) (registry.Voucher, error) {
+	if !common.IsHexAddress(voucher.Payer) {
+		return registry.Voucher{}, fmt.Errorf("%w: invalid payer address", registry.ErrInvalidVoucher)
+	}
+	amount, ok := new(big.Int).SetString(voucher.Amount, 10)
+	if !ok {
+		return registry.Voucher{}, fmt.Errorf("%w: amount must be decimal number of wei", registry.ErrInvalidVoucher)
+	}
+	signature, err := hexutil.Decode(voucher.Signature)
+	if err != nil {
+		return registry.Voucher{}, fmt.Errorf("%w: signature: %w", registry.ErrInvalidVoucher, err)
+	}
+
+	return registry.Voucher{
+		Payer:     common.HexToAddress(voucher.Payer),
+		Payee:     p.payee,
+		Amount:    amount,
+		Signature: signature,
+	}, nil
+}
+
+// channelState returns channel of payer, it's fetched again after deposit ttl or if amount exceeds known deposit
+func (p *payments) channelState(payer common.Address, amount *big.Int) (registry.PaymentChannelState, error) {
+	p.mu.Lock()
+	fetched, ok := p.channels[payer]
+	p.mu.Unlock()
+
+	if ok && p.now().Sub(fetched.fetchedAt) < p.depositTtl && amount.Cmp(fetched.state.Deposit) <= 0 {
+		return fetched.state, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), channelFetchTimeout)
+	defer cancel()
+
+	state, err := p.channel.GetChannel(ctx, payer, p.payee)
+	if err != nil {
+		p.logger.Error("failed to fetch payment channel", slog.String("payer", payer.Hex()), slog.Any("err", err))
+		return registry.PaymentChannelState{}, err
+	}
+
+	p.mu.Lock()
+	p.channels[payer] = fetchedChannel{state: state, fetchedAt: p.now()}
+	p.mu.Unlock()
+
+	return state, nil
+}
+
+// record accepts voucher which increases amount of the latest voucher of payer at least by price
+func (p *payments) record(voucher registry.Voucher, price, settled *big.Int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	latest := settled
+	previous, ok := p.vouchers[voucher.Payer]
+	if ok && previous.Amount.Cmp(latest) > 0 {
+		latest = previous.Amount
+	}
+
+	required := new(big.Int).Add(latest, price)
+	if voucher.Amount.Cmp(required) < 0 {
+		return fmt.Errorf("%w: cumulative amount must be at least %s wei", errInsufficientPayment, required)
+	}
+
+	p.vouchers[voucher.Payer] = voucher
+	if err := p.save(); err != nil {
+		p.restore(voucher.Payer, previous, ok)
+		p.logger.Error("failed to save vouchers", slog.Any("err", err))
+		return err
+	}
+
+	received := new(big.Int).Sub(voucher.Amount, latest)
+	replaced := replacedVoucher{received: received}
+	if ok {
+		replaced.voucher = &previous
+	}
+	p.replaced[voucher.Payer] = replaced
+
+	receivedWei, _ := received.Float64()
+	p.metrics.received.Add(receivedWei)
+	return nil
+}
+
+// refund restores voucher replaced by accepted voucher of request which failed with retryable error
+// or exceeded client quota, so the voucher can be sent again. Nonce of request is released by caller
+// for retryable errors only, so other errors need a new request with the same voucher. Voucher which isn't the latest voucher of payer
+// anymore isn't refunded, because later vouchers include its amount.
+func (p *payments) refund(method string, voucher *types.Voucher) {
+	if voucher == nil || p.priceOf(method).Sign() == 0 {
+		return
+	}
+
+	parsed, err := p.parse(voucher)
+	if err != nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	latest, ok := p.vouchers[parsed.Payer]
+	replaced, replacedOk := p.replaced[parsed.Payer]
+	if !ok || !replacedOk || latest.Amount.Cmp(parsed.Amount) != 0 || !bytes.Equal(latest.Signature, parsed.Signature) {
+		return
+	}
+
+	if replaced.voucher != nil {
+		p.restore(parsed.Payer, *replaced.voucher, true)
+	} else {
+		p.restore(parsed.Payer, registry.Voucher{}, false)
+	}
+	delete(p.replaced, parsed.Payer)
+	if err := p.save(); err != nil {
+		p.logger.Error("failed to save vouchers", slog.Any("err", err))
+	}
+
+	refunded, _ := replaced.received.Float64()
+	p.metrics.refunded.Add(refunded)
+	p.metrics.requests.WithLabelValues("refunded").Inc()
+}
+
+// restore sets voucher of payer to previous voucher, payer without previous voucher is removed
+func (p *payments) restore(payer common.Address, previous registry.Voucher, ok bool) {
+	if ok {
+		p.vouchers[payer] = previous
+	} else {
+		delete(p.vouchers, payer)
+	}
+}
+
+// save writes vouchers to vouchers file, file is replaced at once, so it's never partially written
+func (p *payments) save() error {
+	if p.vouchersFile == "" {
+		return nil
+	}
+
+	file := vouchersFile{
+		ChainId:  p.domain.ChainId.Uint64(),
+		Contract: p.domain.Contract.Hex(),
+		Payee:    p.payee.Hex(),
+	}
+	for _, voucher := range p.vouchers {
+		file.Vouchers = append(file.Vouchers, types.Voucher{
+			Payer:     voucher.Payer.Hex(),
+			Amount:    voucher.Amount.String(),
+			Signature: hexutil.Encode(voucher.Signature),
+		})
+	}
+	slices.SortFunc(file.Vouchers, func(a, b types.Voucher) int {
+		return strings.Compare(a.Payer, b.Payer)
+	})
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := p.vouchersFile + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, p.vouchersFile)
+}
+
+// vouchersFile is content of vouchers file, vouchers are sorted by payer
+type vouchersFile struct {
+	ChainId  uint64          `json:"chainId"`
+	Contract string          `json:"contract"`
+	Payee    string          `json:"payee"`
+	Vouchers []types.Voucher `json:"vouchers"`
+}
+
+// loadVouchers reads vouchers of the payee from vouchers file, missing file has no vouchers
+func loadVouchers(path string, domain registry.PaymentDomain, payee common.Address) (map[common.Address]registry.Voucher, error) {
+	vouchers := make(map[common.Address]registry.Voucher)
+	if path == "" {
+		return vouchers, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return vouchers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file vouchersFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if file.ChainId != domain.ChainId.Uint64() || common.HexToAddress(file.Contract) != domain.Contract || common.HexToAddress(file.Payee) != payee {
+		return nil, fmt.Errorf("%w: %s", errVouchersFileMismatch, path)
+	}
+
+	for _, voucher := range file.Vouchers {
+		amount, ok := new(big.Int).SetString(voucher.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("%w: amount %q", registry.ErrInvalidVoucher, voucher.Amount)
+		}
+		signature, err := hexutil.Decode(voucher.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", registry.ErrInvalidVoucher, err)
+		}
+
+		payer := common.HexToAddress(voucher.Payer)
+		vouchers[payer] = registry.Voucher{Payer: payer, Payee: payee, Amount: amount, Signature: signature}
+	}
+
+	return vouchers, nil
+}
+
+// paymentMetrics are metrics of paid requests
+type paymentMetrics struct {
+	requests *prometheus.CounterVec
+	received prometheus.Counter
+	refunded prometheus.Counter
+}
+
+func newPaymentMetrics() *paymentMetrics {
+	return &paymentMetrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "resolver_payment_requests_total",
+				Help: "Total number of requests to paid methods by result of voucher check",
+			},
+			[]string{"result"},
+		),
+		received: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "resolver_payment_received_wei_total",
+			Help: "Total amount in wei of accepted vouchers, it's paid after settlement",
+		}),
+		refunded: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "resolver_payment_refunded_wei_total",
+			Help: "Total amount in wei of vouchers refunded after retryable errors of requests",
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (m *paymentMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.received.Describe(ch)
+	m.refunded.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (m *paymentMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.received.Collect(ch)
+	m.refunded.Collect(ch)
+}
@@ -0,0 +1,370 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPaymentContract = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	testPaymentChainId  = 31337
+)
+
+// fakePaymentChannel returns channels of payers to any payee
+type fakePaymentChannel struct {
+	channels map[common.Address]registry.PaymentChannelState
+	fetches  int
+}
+
+func (c *fakePaymentChannel) GetChannel(_ context.Context, payer, _ common.Address) (registry.PaymentChannelState, error) {
+	c.fetches++
+	state, ok := c.channels[payer]
+	if !ok {
+		return registry.PaymentChannelState{Deposit: new(big.Int), Settled: new(big.Int)}, nil
+	}
+	return state, nil
+}
+
+func newTestPayments(t *testing.T, cfg PaymentConfig) (*payments, signer.Signer, *time.Time) {
+	nodeSigner, err := signer.Generate()
+	require.NoError(t, err)
+
+	cfg.ChainId = testPaymentChainId
+	cfg.Contract = testPaymentContract
+	p, err := newPayments(cfg, nodeSigner, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	require.NoError(t, err)
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+	return p, nodeSigner, &now
+}
+
+// signTestVoucher returns voucher of request signed by payer for payee of payments
+func signTestVoucher(t *testing.T, p *payments, payer signer.Signer, amount int64) *types.Voucher {
+	voucher, err := p.domain.SignVoucher(payer, p.payee, big.NewInt(amount))
+	require.NoError(t, err)
+
+	return &types.Voucher{
+		Payer:     voucher.Payer.Hex(),
+		Amount:    voucher.Amount.String(),
+		Signature: hexutil.Encode(voucher.Signature),
+	}
+}
+
+func TestPaymentsCheck(t *testing.T) {
+	payer, err := signer.Generate()
+	require.NoError(t, err)
+	other, err := signer.Generate()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		method  string
+		voucher func(p *payments) *types.Voucher
+		channel registry.PaymentChannelState
+		err     error
+		result  string
+	}{
+		{
+			name:    "Paid request",
+			method:  "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher { return signTestVoucher(t, p, payer, 100) },
+			result:  "paid",
+		},
+		{
+			name:    "Free method",
+			method:  "test.GetBlock",
+			voucher: func(p *payments) *types.Voucher { return nil },
+		},
+		{
+			name:    "Voucher is missing",
+			method:  "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher { return nil },
+			err:     errPaymentRequired,
+			result:  "required",
+		},
+		{
+			name:   "Voucher of other payer",
+			method: "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher {
+				voucher := signTestVoucher(t, p, other, 100)
+				voucher.Payer = payer.Address().Hex()
+				return voucher
+			},
+			err:    registry.ErrInvalidVoucher,
+			result: "invalid",
+		},
+		{
+			name:   "Voucher for other payee",
+			method: "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher {
+				voucher, err := p.domain.SignVoucher(payer, other.Address(), big.NewInt(100))
+				require.NoError(t, err)
+				return &types.Voucher{Payer: payer.Address().Hex(), Amount: "100", Signature: hexutil.Encode(voucher.Signature)}
+			},
+			err:    registry.ErrInvalidVoucher,
+			result: "invalid",
+		},
+		{
+			name:   "Malformed amount",
+			method: "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher {
+				voucher := signTestVoucher(t, p, payer, 100)
+				voucher.Amount = "0x64"
+				return voucher
+			},
+			err:    registry.ErrInvalidVoucher,
+			result: "invalid",
+		},
+		{
+			name:    "Amount is less than price",
+			method:  "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher { return signTestVoucher(t, p, payer, 99) },
+			err:     errInsufficientPayment,
+			result:  "insufficient",
+		},
+		{
+			name:    "Settled amount isn't paid again",
+			method:  "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher { return signTestVoucher(t, p, payer, 150) },
+			channel: registry.PaymentChannelState{Deposit: big.NewInt(1000), Settled: big.NewInt(100)},
+			err:     errInsufficientPayment,
+			result:  "insufficient",
+		},
+		{
+			name:    "Amount exceeds deposit",
+			method:  "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher { return signTestVoucher(t, p, payer, 1001) },
+			err:     errDepositExceeded,
+			result:  "deposit_exceeded",
+		},
+		{
+			name:    "Withdrawal is requested",
+			method:  "test.GetBalance",
+			voucher: func(p *payments) *types.Voucher { return signTestVoucher(t, p, payer, 100) },
+			channel: registry.PaymentChannelState{Deposit: big.NewInt(1000), Settled: new(big.Int), WithdrawableAt: time.Now()},
+			err:     errPaymentChannelClosing,
+			result:  "channel_closing",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			p, _, _ := newTestPayments(t, PaymentConfig{MethodPrices: map[string]string{"test.GetBalance": "100", "test.GetBlock": "0"}, Price: "1"})
+			channel := testCase.channel
+			if channel.Deposit == nil {
+				channel = registry.PaymentChannelState{Deposit: big.NewInt(1000), Settled: new(big.Int)}
+			}
+			p.channel = &fakePaymentChannel{channels: map[common.Address]registry.PaymentChannelState{payer.Address(): channel}}
+
+			err := p.check(testCase.method, testCase.voucher(p))
+			assert.ErrorIs(t, err, testCase.err)
+			if testCase.result != "" {
+				assert.Equal(t, 1., testutil.ToFloat64(p.metrics.requests.WithLabelValues(testCase.result)))
+			}
+		})
+	}
+}
+
+func TestPaymentsCumulativeAmount(t *testing.T) {
+	p, _, now := newTestPayments(t, PaymentConfig{Price: "10", DepositTtl: time.Minute})
+	channel := &fakePaymentChannel{channels: make(map[common.Address]registry.PaymentChannelState)}
+	p.channel = channel
+
+	payer, err := signer.Generate()
+	require.NoError(t, err)
+	channel.channels[payer.Address()] = registry.PaymentChannelState{Deposit: big.NewInt(100), Settled: new(big.Int)}
+
+	require.NoError(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 10)))
+	require.NoError(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 25)), "payer can pay more than price")
+	assert.ErrorIs(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 25)), errInsufficientPayment, "voucher can't be reused")
+	assert.ErrorIs(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 34)), errInsufficientPayment)
+	require.NoError(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 35)))
+	assert.Equal(t, 35., testutil.ToFloat64(p.metrics.received))
+	assert.Equal(t, 1, channel.fetches, "deposit is fetched once during ttl")
+
+	// topped up deposit is fetched when voucher exceeds known deposit
+	channel.channels[payer.Address()] = registry.PaymentChannelState{Deposit: big.NewInt(200), Settled: new(big.Int)}
+	require.NoError(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 150)))
+	assert.Equal(t, 2, channel.fetches)
+
+	*now = now.Add(time.Minute)
+	require.NoError(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 160)))
+	assert.Equal(t, 3, channel.fetches, "deposit is fetched after ttl")
+
+	assert.Equal(t, big.NewInt(160), p.vouchers[payer.Address()].Amount)
+}
+
+func TestPaymentsRefund(t *testing.T) {
+	p, _, _ := newTestPayments(t, PaymentConfig{Price: "10"})
+
+	payer, err := signer.Generate()
+	require.NoError(t, err)
+
+	first := signTestVoucher(t, p, payer, 10)
+	second := signTestVoucher(t, p, payer, 20)
+	require.NoError(t, p.check("test.GetBalance", first))
+	require.NoError(t, p.check("test.GetBalance", second))
+
+	p.refund("test.GetBalance", first)
+	assert.Equal(t, big.NewInt(20), p.vouchers[payer.Address()].Amount, "voucher which isn't the latest isn't refunded")
+
+	p.refund("test.GetBalance", second)
+	assert.Equal(t, big.NewInt(10), p.vouchers[payer.Address()].Amount, "replaced voucher is restored")
+	assert.Equal(t, 10., testutil.ToFloat64(p.metrics.refunded))
+	require.NoError(t, p.check("test.GetBalance", second), "refunded voucher is accepted again")
+}
+
+func TestPaymentsVouchersFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vouchers.json")
+	p, nodeSigner, _ := newTestPayments(t, PaymentConfig{Price: "10", VouchersFile: path})
+
+	payer, err := signer.Generate()
+	require.NoError(t, err)
+	require.NoError(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 10)))
+	require.NoError(t, p.check("test.GetBalance", signTestVoucher(t, p, payer, 20)))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var file vouchersFile
+	require.NoError(t, json.Unmarshal(data, &file))
+	require.Len(t, file.Vouchers, 1)
+	assert.Equal(t, "20", file.Vouchers[0].Amount)
+
+	// restarted resolver doesn't accept the same amount again
+	cfg := PaymentConfig{ChainId: testPaymentChainId, Contract: testPaymentContract, Price: "10", VouchersFile: path}
+	restarted, err := newPayments(cfg, nodeSigner, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	require.NoError(t, err)
+	assert.ErrorIs(t, restarted.check("test.GetBalance", signTestVoucher(t, restarted, payer, 20)), errInsufficientPayment)
+	require.NoError(t, restarted.check("test.GetBalance", signTestVoucher(t, restarted, payer, 30)))
+
+	otherSigner, err := signer.Generate()
+	require.NoError(t, err)
+	_, err = newPayments(cfg, otherSigner, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	assert.ErrorIs(t, err, errVouchersFileMismatch, "vouchers of other payee must not be loaded")
+}
+
+func TestNewPayments(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	nodeSigner, err := signer.Generate()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		cfg  PaymentConfig
+		err  error
+	}{
+		{name: "Valid config", cfg: PaymentConfig{ChainId: 1, Contract: testPaymentContract, Price: "10"}},
+		{name: "Chain id is missing", cfg: PaymentConfig{Contract: testPaymentContract}, err: errInvalidPaymentConfig},
+		{name: "Invalid contract", cfg: PaymentConfig{ChainId: 1, Contract: "0x1234"}, err: errInvalidPaymentConfig},
+		{name: "Invalid payee", cfg: PaymentConfig{ChainId: 1, Contract: testPaymentContract, Payee: "payee"}, err: errInvalidPaymentConfig},
+		{name: "Invalid price", cfg: PaymentConfig{ChainId: 1, Contract: testPaymentContract, Price: "-1"}, err: errInvalidPaymentConfig},
+		{name: "Method without namespace", cfg: PaymentConfig{ChainId: 1, Contract: testPaymentContract, MethodPrices: map[string]string{"GetWalletBalance": "1"}}, err: errNamespacedMethodName},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := newPayments(testCase.cfg, nodeSigner, logger)
+			assert.ErrorIs(t, err, testCase.err)
+		})
+	}
+}
+
+func TestExecutePayment(t *testing.T) {
+	cfg := &Config{}
+	cfg.Apis.Default.Enabled = true
+	cfg.Payment = PaymentConfig{Enabled: true, ChainId: testPaymentChainId, Contract: testPaymentContract, Price: "100"}
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+	payer, err := signer.Generate()
+	require.NoError(t, err)
+
+	payload := []byte(`{"id":"1","method":"GetWalletBalance","params":["0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324","latest"]}`)
+	resp, err := server.Execute(context.Background(), &pb.ResolverRequest{Id: "1", Payload: payload})
+	require.NoError(t, err)
+	assert.Equal(t, pb.ErrorCode_ERR_PAYMENT_REQUIRED, resp.GetError().GetCode())
+	assert.Equal(t, "payment voucher is required: price is 100 wei", resp.GetError().GetMessage())
+
+	request := types.JsonRequest{
+		JsonRpc: types.Version,
+		Id:      types.NumberId(2),
+		Method:  "default.GetWalletBalance",
+		Params:  types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
+		Voucher: signTestVoucher(t, server.payments, payer, 100),
+	}
+	payload, err = json.Marshal(request)
+	require.NoError(t, err)
+
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "2", Payload: payload})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"result":555}`, string(resp.GetPayload()))
+
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "3", Payload: payload})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"error":{"code":-32010,"message":"insufficient payment: cumulative amount must be at least 200 wei"}}`, string(resp.GetPayload()))
+
+	payload = []byte(`{"jsonrpc":"2.0","id":4,"method":"Unknown"}`)
+	resp, err = server.Execute(context.Background(), &pb.ResolverRequest{Id: "4", Payload: payload})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":4,"error":{"code":-32601,"message":"unrecognized method"}}`, string(resp.GetPayload()), "unknown method isn't charged")
+}
+
+func TestExecutePaymentQuota(t *testing.T) {
+	cfg := &Config{}
+	cfg.Apis.Default.Enabled = true
+	cfg.Payment = PaymentConfig{Enabled: true, ChainId: testPaymentChainId, Contract: testPaymentContract, Price: "100"}
+	cfg.ClientAccess = ClientAccessConfig{Enabled: true, Quota: ClientQuotaConfig{RequestsPerMinute: 1}}
+	cfg.ReplayProtection = ReplayProtectionConfig{Enabled: true, Window: time.Minute}
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+	timestamp := time.Now().UnixMilli()
+	now := time.Now()
+	server.clientAccess.now = func() time.Time { return now }
+	payer, err := signer.Generate()
+	require.NoError(t, err)
+
+	execute := func(nonce string, voucher *types.Voucher) string {
+		payload, err := json.Marshal(types.JsonRequest{
+			JsonRpc:   types.Version,
+			Id:        types.NumberId(1),
+			Method:    "default.GetWalletBalance",
+			Params:    types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
+			Timestamp: timestamp,
+			Nonce:     nonce,
+			Voucher:   voucher,
+		})
+		require.NoError(t, err)
+
+		resp, err := server.Execute(context.Background(), &pb.ResolverRequest{Id: "1", Payload: payload})
+		require.NoError(t, err)
+		return string(resp.GetPayload())
+	}
+
+	assert.Contains(t, execute("0b5e2d7a", nil), `"code":-32010`)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":555}`, execute("9c1f4a3e", signTestVoucher(t, server.payments, payer, 100)), "unpaid request doesn't spend quota")
+
+	voucher := signTestVoucher(t, server.payments, payer, 200)
+	assert.Contains(t, execute("6d8b0e21", voucher), `"code":-32005`)
+
+	now = now.Add(time.Minute)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"result":555}`, execute("6d8b0e21", voucher), "request above quota is sent again with the same nonce and voucher")
+	assert.Contains(t, execute("6d8b0e21", voucher), `"code":-32600`, "processed request can't be replayed")
+}
@@ -5,22 +5,31 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math/big"
 	"net"
 
+	"github.com/1inch/p2p-network/contracts"
 	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// ProtocolVersion is version of resolver request protocol published in node registry
+const ProtocolVersion = 1
+
 var (
 	errInvalidFormatAddress  = errors.New("invalid format for address")
 	errInvalidFormatEndpoint = errors.New("invalid format for endpoint")
+	errInvalidFormatStake    = errors.New("invalid format for stake")
 )
 
 // RegistrationResolver describe registration new resolver on blockchain registry
 type RegistrationResolver struct {
 	logger         *slog.Logger
 	cfg            Config
+	signer         signer.Signer
 	registryClient *registry.Client
 }
 
@@ -38,11 +47,24 @@ func NewRegistrationResolver(logger *slog.Logger, cfg *Config) (*RegistrationRes
 		return nil, err
 	}
 
+	if cfg.Stake != "" {
+		if _, err = ParseWei(cfg.Stake); err != nil {
+			return nil, err
+		}
+	}
+
+	nodeSigner, err := signer.New(cfg.PrivateKey, cfg.Keystore)
+	if err != nil {
+		logger.Error("failed to load node key", slog.Any("err", err.Error()))
+		return nil, err
+	}
+
 	rawUrl := fmt.Sprintf("http://%s", cfg.RpcUrl)
 	registryCfg := &registry.Config{
 		DialURI:         rawUrl,
-		PrivateKey:      cfg.PrivateKey,
+		Signer:          nodeSigner,
 		ContractAddress: cfg.ContractAddress,
+		Tx:              cfg.Transaction,
 	}
 	registryCli, err := registry.Dial(context.Background(), registryCfg)
 	if err != nil {
@@ -53,31 +75,133 @@ func NewRegistrationResolver(logger *slog.Logger, cfg *Config) (*RegistrationRes
 	return &RegistrationResolver{
 		logger:         logger,
 		cfg:            *cfg,
+		signer:         nodeSigner,
 		registryClient: registryCli,
 	}, nil
 }
 
 // Register workflow for registration resolver to blockchain registry
-// TODO Register maybe need added configuration limit gas price and gas limit,
 func (r *RegistrationResolver) Register(ctx context.Context) (*common.Hash, error) {
-	privateKey, err := crypto.HexToECDSA(r.cfg.PrivateKey)
-	if err != nil {
-		r.logger.Error("failed map hex to private key")
-		return nil, err
+	publicKey := signer.CompressedPublicKey(r.signer)
+
+	var stake *big.Int
+	if r.cfg.Stake != "" {
+		// validated in NewRegistrationResolver
+		stake, _ = ParseWei(r.cfg.Stake)
 	}
-	publicKey := crypto.CompressPubkey(&privateKey.PublicKey)
 
-	tx, err := r.registryClient.Registry.RegisterResolver(r.registryClient.Auth, r.cfg.GrpcEndpoint, publicKey)
+	capabilities := r.capabilities()
+	return r.transact(ctx, "RegisterResolver", stake, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.registryClient.Registry.RegisterResolver(opts, r.cfg.GrpcEndpoint, publicKey, capabilities)
+	})
+}
+
+// Update workflow for change resolver endpoint in blockchain registry to endpoint from config
+func (r *RegistrationResolver) Update(ctx context.Context) (*common.Hash, error) {
+	publicKey := signer.CompressedPublicKey(r.signer)
+
+	return r.transact(ctx, "UpdateResolver", nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.registryClient.Registry.UpdateResolver(opts, publicKey, r.cfg.GrpcEndpoint)
+	})
+}
+
+// UpdateCapabilities workflow for change resolver capabilities in blockchain registry to capabilities of api handler from config
+func (r *RegistrationResolver) UpdateCapabilities(ctx context.Context) (*common.Hash, error) {
+	publicKey := signer.CompressedPublicKey(r.signer)
+
+	return r.transact(ctx, "SetResolverCapabilities", nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.registryClient.Registry.SetResolverCapabilities(opts, publicKey, r.capabilities())
+	})
+}
+
+// Deregister workflow for remove resolver from blockchain registry
+func (r *RegistrationResolver) Deregister(ctx context.Context) (*common.Hash, error) {
+	publicKey := signer.CompressedPublicKey(r.signer)
+
+	return r.transact(ctx, "DeregisterResolver", nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.registryClient.Registry.DeregisterResolver(opts, publicKey)
+	})
+}
+
+// RotateKey replaces resolver public key in blockchain registry, node key from config is used as old key
+func (r *RegistrationResolver) RotateKey(ctx context.Context, newPublicKey []byte) (*common.Hash, error) {
+	oldPublicKey := signer.CompressedPublicKey(r.signer)
+
+	return r.transact(ctx, "RotateResolverKey", nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.registryClient.Registry.RotateResolverKey(opts, oldPublicKey, newPublicKey)
+	})
+}
+
+// Stake adds amount in wei to resolver stake in blockchain registry
+func (r *RegistrationResolver) Stake(ctx context.Context, amount *big.Int) (*common.Hash, error) {
+	publicKey := signer.CompressedPublicKey(r.signer)
+
+	return r.transact(ctx, "StakeResolver", amount, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.registryClient.Registry.StakeResolver(opts, publicKey)
+	})
+}
+
+// Unstake starts unbonding of amount in wei of resolver stake in blockchain registry
+func (r *RegistrationResolver) Unstake(ctx context.Context, amount *big.Int) (*common.Hash, error) {
+	publicKey := signer.CompressedPublicKey(r.signer)
+
+	return r.transact(ctx, "UnstakeResolver", nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.registryClient.Registry.UnstakeResolver(opts, publicKey, amount)
+	})
+}
+
+// WithdrawStake withdraws unbonded resolver stake from blockchain registry after unbonding period
+func (r *RegistrationResolver) WithdrawStake(ctx context.Context) (*common.Hash, error) {
+	publicKey := signer.CompressedPublicKey(r.signer)
+
+	return r.transact(ctx, "WithdrawResolverStake", nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.registryClient.Registry.WithdrawResolverStake(opts, publicKey)
+	})
+}
+
+// ParseWei parses non-negative decimal amount in wei
+func ParseWei(amount string) (*big.Int, error) {
+	wei, ok := new(big.Int).SetString(amount, 10)
+	if !ok || wei.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %s", errInvalidFormatStake, amount)
+	}
+	return wei, nil
+}
+
+// capabilities returns capabilities of api handler enabled in config, they are not published if no handler is enabled
+func (r *RegistrationResolver) capabilities() contracts.NodeRegistryCapabilities {
+	return handlerCapabilities(&r.cfg, r.logger)
+}
+
+func handlerCapabilities(cfg *Config, logger *slog.Logger) contracts.NodeRegistryCapabilities {
+	handler, err := newApiHandler(cfg, logger)
 	if err != nil {
-		r.logger.Error("failed call contract method 'RegisterResolver'", slog.Any("err", err.Error()))
-		return nil, err
+		logger.Warn("api handler is not configured, capabilities are not published")
+		return contracts.NodeRegistryCapabilities{}
 	}
 
-	txHash := tx.Hash()
+	handlerCapabilities := handler.Capabilities()
+	chainIds := make([]*big.Int, len(handlerCapabilities.ChainIds))
+	for i, chainId := range handlerCapabilities.ChainIds {
+		chainIds[i] = new(big.Int).SetUint64(chainId)
+	}
 
-	err = r.registryClient.WaitForTx(ctx, txHash)
+	return contracts.NodeRegistryCapabilities{
+		Methods:         handlerCapabilities.Methods,
+		ChainIds:        chainIds,
+		ProtocolVersion: ProtocolVersion,
+		Encrypted:       true,
+	}
+}
+
+// transact sends registry transaction with transaction policy from config, hash of the mined transaction is returned
+func (r *RegistrationResolver) transact(ctx context.Context, method string, value *big.Int, send registry.SendFunc) (*common.Hash, error) {
+	txHash, err := r.registryClient.Transact(ctx, value, send)
+	if errors.Is(err, registry.ErrDryRun) {
+		return nil, err
+	}
 	if err != nil {
-		r.logger.Error("failed process transaction for register resolver", slog.Any("err", err.Error()))
+		r.logger.Error("failed call contract method '"+method+"'", slog.Any("err", err.Error()))
 		return nil, err
 	}
 
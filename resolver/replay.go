@@ -0,0 +1,182 @@
+package resolver
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	defaultReplayWindow     = 30 * time.Second
+	defaultReplayMaxEntries = 100000
+	// defaultReplayMaxFutureSkew limits timestamps ahead of resolver time, so nonces with far future timestamps
+	// can't stay in cache longer than nonces of other requests
+	defaultReplayMaxFutureSkew = 5 * time.Second
+)
+
+var (
+	errEmptyNonce      = errors.New("empty nonce")
+	errEmptyTimestamp  = errors.New("empty timestamp")
+	errStaleRequest    = errors.New("request timestamp is outside of replay window")
+	errReplayedRequest = errors.New("request already processed")
+)
+
+type replayEntry struct {
+	nonce     string
+	timestamp time.Time
+	// index is position of entry in replayEntries heap
+	index int
+}
+
+// replayEntries is min-heap of entries by request timestamp
+type replayEntries []*replayEntry
+
+func (e replayEntries) Len() int           { return len(e) }
+func (e replayEntries) Less(i, j int) bool { return e[i].timestamp.Before(e[j].timestamp) }
+
+func (e replayEntries) Swap(i, j int) {
+	e[i], e[j] = e[j], e[i]
+	e[i].index = i
+	e[j].index = j
+}
+
+func (e *replayEntries) Push(x any) {
+	entry := x.(*replayEntry)
+	entry.index = len(*e)
+	*e = append(*e, entry)
+}
+
+func (e *replayEntries) Pop() any {
+	old := *e
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*e = old[:len(old)-1]
+	return entry
+}
+
+// replayCache keeps nonces of processed requests for a limited time window. When cache is full the entry with
+// the oldest request timestamp is evicted and requests with timestamp not after it are rejected as stale, so evicted
+// nonce can't be replayed and flood of unique nonces narrows the window instead of rejecting all requests.
+// Eviction bound stays maxFutureSkew behind resolver time, so flood of requests with future timestamps doesn't reject
+// requests delivered within maxFutureSkew. Nonce evicted before its request is maxFutureSkew old isn't protected
+// by the bound, it's evicted only if cache is flooded by requests with later timestamps.
+type replayCache struct {
+	window        time.Duration
+	maxFutureSkew time.Duration
+	maxEntries    int
+	now           func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*replayEntry
+	// order holds entries by request timestamp, oldest at the top
+	order replayEntries
+	// evictedUntil is the latest timestamp of requests which nonces were evicted before expiration
+	evictedUntil time.Time
+}
+
+func newReplayCache(cfg ReplayProtectionConfig) *replayCache {
+	window := cfg.Window
+	if window <= 0 {
+		window = defaultReplayWindow
+	}
+
+	maxFutureSkew := cfg.MaxFutureSkew
+	if maxFutureSkew <= 0 {
+		maxFutureSkew = defaultReplayMaxFutureSkew
+	}
+
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultReplayMaxEntries
+	}
+
+	return &replayCache{
+		window:        window,
+		maxFutureSkew: min(maxFutureSkew, window),
+		maxEntries:    maxEntries,
+		now:           time.Now,
+		entries:       make(map[string]*replayEntry),
+	}
+}
+
+// check validates timestamp and nonce of request and remembers nonce if request is fresh
+func (c *replayCache) check(timestampMs int64, nonce string) error {
+	if nonce == "" {
+		return errEmptyNonce
+	}
+
+	if timestampMs == 0 {
+		return errEmptyTimestamp
+	}
+
+	now := c.now()
+	timestamp := time.UnixMilli(timestampMs)
+	diff := now.Sub(timestamp)
+	if diff > c.window || diff < -c.maxFutureSkew {
+		return errStaleRequest
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evictExpired(now)
+
+	if _, exists := c.entries[nonce]; exists {
+		return errReplayedRequest
+	}
+
+	// nonce of request with this timestamp may be evicted, so request can't be told from replay
+	if !timestamp.After(c.evictedUntil) {
+		return errStaleRequest
+	}
+
+	if len(c.order) >= c.maxEntries {
+		c.evictOldest(now)
+	}
+
+	// nonce must be kept while request with this timestamp can pass window check
+	entry := &replayEntry{nonce: nonce, timestamp: timestamp}
+	heap.Push(&c.order, entry)
+	c.entries[nonce] = entry
+
+	return nil
+}
+
+// forget removes nonce of request which wasn't processed, so the request can be sent again
+func (c *replayCache) forget(nonce string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[nonce]; ok {
+		heap.Remove(&c.order, entry.index)
+		delete(c.entries, nonce)
+	}
+}
+
+// evictOldest removes entry with the oldest timestamp before expiration, requests with its timestamp and older
+// are rejected after it, but bound isn't moved closer than maxFutureSkew to now
+func (c *replayCache) evictOldest(now time.Time) {
+	if len(c.order) == 0 {
+		return
+	}
+
+	entry := heap.Pop(&c.order).(*replayEntry)
+	delete(c.entries, entry.nonce)
+
+	evictedUntil := entry.timestamp
+	if latest := now.Add(-c.maxFutureSkew); evictedUntil.After(latest) {
+		evictedUntil = latest
+	}
+	if evictedUntil.After(c.evictedUntil) {
+		c.evictedUntil = evictedUntil
+	}
+}
+
+// evictExpired removes entries with timestamps which don't pass window check anymore
+func (c *replayCache) evictExpired(now time.Time) {
+	for len(c.order) > 0 && now.Sub(c.order[0].timestamp) > c.window {
+		entry := heap.Pop(&c.order).(*replayEntry)
+		delete(c.entries, entry.nonce)
+	}
+}
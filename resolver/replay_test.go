@@ -0,0 +1,244 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/internal/signer"
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayCache(t *testing.T) {
+	now := time.Now()
+	cache := newReplayCache(ReplayProtectionConfig{Enabled: true, Window: time.Minute, MaxEntries: 2})
+	cache.now = func() time.Time { return now }
+
+	testCases := []struct {
+		name        string
+		timestamp   int64
+		nonce       string
+		expectedErr error
+	}{
+		{name: "Fresh request", timestamp: now.UnixMilli(), nonce: "nonce-1"},
+		{name: "Same nonce", timestamp: now.UnixMilli(), nonce: "nonce-1", expectedErr: errReplayedRequest},
+		{name: "Empty nonce", timestamp: now.UnixMilli(), nonce: "", expectedErr: errEmptyNonce},
+		{name: "Empty timestamp", timestamp: 0, nonce: "nonce-2", expectedErr: errEmptyTimestamp},
+		{name: "Old request", timestamp: now.Add(-2 * time.Minute).UnixMilli(), nonce: "nonce-2", expectedErr: errStaleRequest},
+		{name: "Request from future", timestamp: now.Add(2 * time.Minute).UnixMilli(), nonce: "nonce-2", expectedErr: errStaleRequest},
+		{name: "Request beyond max future skew", timestamp: now.Add(10 * time.Second).UnixMilli(), nonce: "nonce-2", expectedErr: errStaleRequest},
+		{name: "Request with clock skew", timestamp: now.Add(-30 * time.Second).UnixMilli(), nonce: "nonce-2"},
+		{name: "Cache is full", timestamp: now.UnixMilli(), nonce: "nonce-3"},
+		{name: "Evicted nonce", timestamp: now.Add(-30 * time.Second).UnixMilli(), nonce: "nonce-2", expectedErr: errStaleRequest},
+		{name: "Request older than evicted request", timestamp: now.Add(-31 * time.Second).UnixMilli(), nonce: "nonce-4", expectedErr: errStaleRequest},
+		{name: "Request after evicted request", timestamp: now.Add(-29 * time.Second).UnixMilli(), nonce: "nonce-4"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := cache.check(testCase.timestamp, testCase.nonce)
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestReplayCacheEvictsExpiredNonces(t *testing.T) {
+	now := time.Now()
+	cache := newReplayCache(ReplayProtectionConfig{Enabled: true, Window: time.Minute, MaxEntries: 1})
+	cache.now = func() time.Time { return now }
+
+	timestamp := now.UnixMilli()
+	require.NoError(t, cache.check(timestamp, "nonce-1"))
+
+	// request with old timestamp is rejected as stale even after its nonce was evicted
+	now = now.Add(3 * time.Minute)
+	assert.ErrorIs(t, cache.check(timestamp, "nonce-1"), errStaleRequest)
+	assert.NoError(t, cache.check(now.UnixMilli(), "nonce-2"))
+	assert.Len(t, cache.order, 1)
+}
+
+func TestReplayCacheFlood(t *testing.T) {
+	now := time.Now()
+	cache := newReplayCache(ReplayProtectionConfig{Enabled: true, Window: time.Minute, MaxEntries: 10})
+	cache.now = func() time.Time { return now }
+
+	for i := range 100 {
+		timestamp := now.Add(time.Duration(i) * time.Millisecond).UnixMilli()
+		require.NoError(t, cache.check(timestamp, fmt.Sprintf("flood-%d", i)), "cache doesn't reject requests when it's full")
+	}
+	assert.Len(t, cache.order, 10)
+
+	now = now.Add(time.Second)
+	assert.NoError(t, cache.check(now.UnixMilli(), "client"), "request of other client is accepted during flood")
+	assert.ErrorIs(t, cache.check(now.UnixMilli(), "client"), errReplayedRequest)
+}
+
+func TestReplayCacheFutureFlood(t *testing.T) {
+	now := time.Now()
+	cache := newReplayCache(ReplayProtectionConfig{Enabled: true, Window: time.Minute, MaxEntries: 10})
+	cache.now = func() time.Time { return now }
+
+	for i := range 100 {
+		now = now.Add(10 * time.Millisecond)
+		require.NoError(t, cache.check(now.Add(defaultReplayMaxFutureSkew).UnixMilli(), fmt.Sprintf("flood-%d", i)))
+
+		// request of other client is delivered a second after it's sent
+		nonce := fmt.Sprintf("client-%d", i)
+		require.NoError(t, cache.check(now.Add(-time.Second).UnixMilli(), nonce), "flood with future timestamps doesn't reject current requests")
+		require.ErrorIs(t, cache.check(now.Add(-time.Second).UnixMilli(), nonce), errReplayedRequest)
+	}
+	assert.False(t, cache.evictedUntil.After(now.Add(-defaultReplayMaxFutureSkew)), "eviction bound stays behind resolver time")
+}
+
+func TestExecuteWithReplayProtection(t *testing.T) {
+	cfg := &Config{}
+	cfg.Apis.Default.Enabled = true
+	cfg.ReplayProtection = ReplayProtectionConfig{Enabled: true, Window: time.Minute}
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(&types.JsonRequest{
+		Id:        types.StringId("1"),
+		Method:    "GetWalletBalance",
+		Params:    types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
+		Timestamp: time.Now().UnixMilli(),
+		Nonce:     "2f1a6c0e9b3d4f5a",
+	})
+	require.NoError(t, err)
+	req := &pb.ResolverRequest{Id: "1", Payload: payload}
+
+	resp, err := server.Execute(context.Background(), req)
+	require.NoError(t, err)
+	require.Nil(t, resp.GetError(), "first request must be processed")
+	require.NotEmpty(t, resp.GetPayload())
+
+	resp, err = server.Execute(context.Background(), req)
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetError(), "replayed request must be rejected")
+	assert.Equal(t, pb.ErrorCode_ERR_REPLAYED_REQUEST, resp.GetError().Code)
+	assert.Equal(t, errReplayedRequest.Error(), resp.GetError().Message)
+}
+
+func TestExecuteRetryableError(t *testing.T) {
+	upstreamUrl, err := url.Parse(newEthUpstream(t, "0x7a69"))
+	require.NoError(t, err)
+	proxy := httputil.NewSingleHostReverseProxy(upstreamUrl)
+
+	// the first request to upstream fails, so the request fails with retryable error
+	var upstreamRequests atomic.Int32
+	flakyUpstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if upstreamRequests.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	t.Cleanup(flakyUpstream.Close)
+
+	cfg := &Config{}
+	cfg.Apis.Evm = EvmApiConfig{Enabled: true, Upstreams: map[uint64]UpstreamList{31337: {{Url: flakyUpstream.URL}}}}
+	cfg.ReplayProtection = ReplayProtectionConfig{Enabled: true, Window: time.Minute}
+	cfg.ClientAccess = ClientAccessConfig{Enabled: true, Quota: ClientQuotaConfig{RequestsPerMinute: 1, DailyLimit: 1}}
+	cfg.Payment = PaymentConfig{Enabled: true, ChainId: testPaymentChainId, Contract: testPaymentContract, Price: "10"}
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+
+	payer, err := signer.Generate()
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(&types.JsonRequest{
+		JsonRpc:   types.Version,
+		Id:        types.NumberId(1),
+		Method:    "eth_blockNumber",
+		Timestamp: time.Now().UnixMilli(),
+		Nonce:     "7c0b3f9e2a5d4e61",
+		Voucher:   signTestVoucher(t, server.payments, payer, 10),
+	})
+	require.NoError(t, err)
+	req := &pb.ResolverRequest{Id: "1", Payload: payload}
+
+	execute := func() *types.JsonResponse {
+		resp, err := server.Execute(context.Background(), req)
+		require.NoError(t, err)
+
+		var jsonResp types.JsonResponse
+		require.NoError(t, json.Unmarshal(resp.GetPayload(), &jsonResp))
+		return &jsonResp
+	}
+
+	failed := execute()
+	require.NotNil(t, failed.Error)
+	assert.Equal(t, types.ErrorData{Retryable: true, Details: map[string]string{"upstream_status": "502"}}, decodeErrorData(t, failed.Error.Data))
+
+	retried := execute()
+	require.Nil(t, retried.Error, "nonce, quota and voucher of failed request are released")
+	assert.Equal(t, "0x7a69", retried.Result)
+
+	replayed := execute()
+	require.NotNil(t, replayed.Error)
+	assert.Equal(t, types.CodeInvalidRequest, replayed.Error.Code, "processed request can't be replayed")
+}
+
+func TestExecuteQuotaExceededRetry(t *testing.T) {
+	cfg := &Config{}
+	cfg.Apis.Default.Enabled = true
+	cfg.ReplayProtection = ReplayProtectionConfig{Enabled: true, Window: time.Minute}
+	cfg.ClientAccess = ClientAccessConfig{Enabled: true, Quota: ClientQuotaConfig{RequestsPerMinute: 1}}
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+	timestamp := time.Now().UnixMilli()
+	now := time.Now()
+	server.clientAccess.now = func() time.Time { return now }
+
+	execute := func(nonce string) *types.JsonResponse {
+		payload, err := json.Marshal(&types.JsonRequest{
+			JsonRpc:   types.Version,
+			Id:        types.NumberId(1),
+			Method:    "GetWalletBalance",
+			Params:    types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
+			Timestamp: timestamp,
+			Nonce:     nonce,
+		})
+		require.NoError(t, err)
+
+		resp, err := server.Execute(context.Background(), &pb.ResolverRequest{Id: "1", Payload: payload})
+		require.NoError(t, err)
+
+		var jsonResp types.JsonResponse
+		require.NoError(t, json.Unmarshal(resp.GetPayload(), &jsonResp))
+		return &jsonResp
+	}
+
+	require.Nil(t, execute("5b1e0c7d9a2f4e38").Error)
+
+	limited := execute("c4d2a9e1f0b37a65")
+	require.NotNil(t, limited.Error)
+	assert.Equal(t, types.ErrorData{Retryable: true}, decodeErrorData(t, limited.Error.Data))
+
+	now = now.Add(time.Minute)
+	retried := execute("c4d2a9e1f0b37a65")
+	assert.Nil(t, retried.Error, "request above quota is retried with the same nonce after quota is refilled")
+}
+
+// decodeErrorData decodes data of error object unmarshaled from JSON
+func decodeErrorData(t *testing.T, data any) types.ErrorData {
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	var errorData types.ErrorData
+	require.NoError(t, json.Unmarshal(raw, &errorData))
+	return errorData
+}
@@ -0,0 +1,276 @@
+package resolver
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/1inch/p2p-network/resolver/types"
+)
+
+// namespaceSeparator separates handler namespace and method name, e.g. infura.GetWalletBalance
+const namespaceSeparator = "."
+
+const (
+	namespaceDefault = "default"
+	namespaceInfura  = "infura"
+	namespaceOneInch = "1inch"
+	namespaceEvm     = "evm"
+	namespaceTxRelay = "tx"
+)
+
+var (
+	errInvalidNamespace     = errors.New("handler namespace must be non-empty and must not contain " + namespaceSeparator)
+	errDuplicateNamespace   = errors.New("duplicate handler namespace")
+	errNamespacedMethodName = errors.New("method must have handler namespace, e.g. infura.GetWalletBalance")
+	errInfuraHandler        = errors.New("failed to create infura api handler")
+	errOneInchHandler       = errors.New("failed to create 1inch api handler")
+	errEvmHandler           = errors.New("failed to create evm api handler")
+	errTxRelayHandler       = errors.New("failed to create tx relay api handler")
+)
+
+// MethodInfo describes method served by one of enabled api handlers
+type MethodInfo struct {
+	// Name is method name with handler namespace
+	Name string
+	// Handler is namespace of the handler
+	Handler string
+	// Method is method name without namespace
+	Method string
+	// Default is true if requests with method name without namespace are routed to the handler
+	Default bool
+	// ChainIds are chain IDs served by the handler
+	ChainIds []uint64
+}
+
+type namespacedHandler struct {
+	namespace    string
+	handler      ApiHandler
+	capabilities HandlerCapabilities
+}
+
+// handlerRouter is ApiHandler which routes requests to enabled api handlers by method name.
+// Method with namespace is routed to the handler of namespace, method without namespace is routed
+// to the first handler which serves it in order of registration.
+type handlerRouter struct {
+	handlers []namespacedHandler
+	// routes maps method name with and without namespace to handler
+	routes map[string]*namespacedHandler
+	// cache wraps registered handlers, it's nil when caching is disabled
+	cache *responseCache
+	// coalescer wraps registered handlers inside cache, it's nil when coalescing is disabled
+	coalescer *requestCoalescer
+}
+
+func newHandlerRouter() *handlerRouter {
+	return &handlerRouter{routes: make(map[string]*namespacedHandler)}
+}
+
+// register adds handler with methods it declares in capabilities
+func (r *handlerRouter) register(namespace string, handler ApiHandler) error {
+	if namespace == "" || strings.Contains(namespace, namespaceSeparator) {
+		return fmt.Errorf("%w: %q", errInvalidNamespace, namespace)
+	}
+	if slices.ContainsFunc(r.handlers, func(h namespacedHandler) bool { return h.namespace == namespace }) {
+		return fmt.Errorf("%w: %q", errDuplicateNamespace, namespace)
+	}
+
+	// cached results are returned without waiting for identical requests, so cache wraps coalescer
+	if r.coalescer != nil {
+		handler = r.coalescer.wrap(namespace, handler)
+	}
+	if r.cache != nil {
+		handler = r.cache.wrap(namespace, handler)
+	}
+
+	r.handlers = append(r.handlers, namespacedHandler{
+		namespace:    namespace,
+		handler:      handler,
+		capabilities: handler.Capabilities(),
+	})
+
+	// routes are rebuilt because appending can move handlers
+	r.routes = make(map[string]*namespacedHandler)
+	for i := range r.handlers {
+		h := &r.handlers[i]
+		for _, method := range h.capabilities.Methods {
+			r.routes[h.namespace+namespaceSeparator+method] = h
+			if _, ok := r.routes[method]; !ok {
+				r.routes[method] = h
+			}
+		}
+	}
+
+	return nil
+}
+
+// Process routes request to the handler of the method, handler gets method name without namespace
+func (r *handlerRouter) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
+	h, ok := r.routes[req.Method]
+	if !ok {
+		return &types.JsonResponse{Id: req.Id, Result: 0}, errUnrecognizedMethod
+	}
+
+	routed := *req
+	routed.Method = strings.TrimPrefix(req.Method, h.namespace+namespaceSeparator)
+	return h.handler.Process(&routed)
+}
+
+// methodName returns method name with namespace of the handler which serves method, false if method isn't served
+func (r *handlerRouter) methodName(method string) (string, bool) {
+	h, ok := r.routes[method]
+	if !ok {
+		return "", false
+	}
+	return h.namespace + namespaceSeparator + strings.TrimPrefix(method, h.namespace+namespaceSeparator), true
+}
+
+// Capabilities returns methods of all handlers with and without namespace and union of their chain IDs
+func (r *handlerRouter) Capabilities() HandlerCapabilities {
+	var capabilities HandlerCapabilities
+	for _, method := range r.Methods() {
+		capabilities.Methods = append(capabilities.Methods, method.Name)
+		if method.Default {
+			capabilities.Methods = append(capabilities.Methods, method.Method)
+		}
+	}
+
+	for _, h := range r.handlers {
+		for _, chainId := range h.capabilities.ChainIds {
+			if !slices.Contains(capabilities.ChainIds, chainId) {
+				capabilities.ChainIds = append(capabilities.ChainIds, chainId)
+			}
+		}
+	}
+	slices.Sort(capabilities.ChainIds)
+
+	return capabilities
+}
+
+// Methods returns methods of all handlers sorted by name
+func (r *handlerRouter) Methods() []MethodInfo {
+	var methods []MethodInfo
+	for i := range r.handlers {
+		h := &r.handlers[i]
+		for _, method := range h.capabilities.Methods {
+			methods = append(methods, MethodInfo{
+				Name:     h.namespace + namespaceSeparator + method,
+				Handler:  h.namespace,
+				Method:   method,
+				Default:  r.routes[method] == h,
+				ChainIds: h.capabilities.ChainIds,
+			})
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+	return methods
+}
+
+// splitMethodName returns namespace and method of method name with namespace
+func splitMethodName(name string) (string, string, error) {
+	namespace, method, ok := strings.Cut(name, namespaceSeparator)
+	if !ok || namespace == "" || method == "" {
+		return "", "", fmt.Errorf("%w: %q", errNamespacedMethodName, name)
+	}
+	return namespace, method, nil
+}
+
+// newApiHandler creates router of all api handlers enabled in config. Built-in handlers are registered in order
+// default, infura, 1inch, tx, evm, so they serve methods without namespace before custom handlers, which are ordered by namespace.
+func newApiHandler(cfg *Config, logger *slog.Logger) (*handlerRouter, error) {
+	router := newHandlerRouter()
+
+	if cfg.Cache.Enabled {
+		logger.Debug("response cache enabled")
+		cache, err := newResponseCache(cfg.Cache, logger)
+		if err != nil {
+			return nil, err
+		}
+		router.cache = cache
+	}
+
+	if cfg.Coalescing.Enabled {
+		logger.Debug("request coalescing enabled")
+		coalescer, err := newRequestCoalescer(cfg.Coalescing, logger)
+		if err != nil {
+			return nil, err
+		}
+		router.coalescer = coalescer
+	}
+
+	if cfg.Apis.Default.Enabled {
+		logger.Debug("set default api handler")
+		if err := router.register(namespaceDefault, NewDefaultApiHandler(cfg.Apis.Default, logger)); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Apis.Infura.Enabled {
+		logger.Debug("set infura api handler")
+		handler, err := NewInfuraApiHandler(cfg.Apis.Infura, logger)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInfuraHandler, err)
+		}
+		if err := router.register(namespaceInfura, handler); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Apis.OneInch.Enabled {
+		logger.Debug("set 1inch api handler")
+		handler, err := NewOneInchApiHandler(cfg.Apis.OneInch, logger)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errOneInchHandler, err)
+		}
+		if err := router.register(namespaceOneInch, handler); err != nil {
+			return nil, err
+		}
+	}
+
+	// tx relay handler is registered before evm handler, so eth_sendRawTransaction without namespace is validated
+	if cfg.Apis.TxRelay.Enabled {
+		logger.Debug("set tx relay api handler")
+		handler, err := NewTxRelayApiHandler(cfg.Apis.TxRelay, logger)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errTxRelayHandler, err)
+		}
+		if err := router.register(namespaceTxRelay, handler); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Apis.Evm.Enabled {
+		logger.Debug("set evm api handler")
+		handler, err := NewEvmApiHandler(cfg.Apis.Evm, logger)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errEvmHandler, err)
+		}
+		if err := router.register(namespaceEvm, handler); err != nil {
+			return nil, err
+		}
+	}
+
+	namespaces := make([]string, 0, len(cfg.Apis.Custom))
+	for namespace := range cfg.Apis.Custom {
+		namespaces = append(namespaces, namespace)
+	}
+	slices.Sort(namespaces)
+	for _, namespace := range namespaces {
+		logger.Debug("set custom api handler", slog.String("namespace", namespace))
+		if err := router.register(namespace, cfg.Apis.Custom[namespace]); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(router.handlers) == 0 {
+		return nil, errNoHandlerApiInConfig
+	}
+
+	return router, nil
+}
@@ -0,0 +1,96 @@
+package resolver
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoHandler returns its name and requested method, so routing is visible in result
+type echoHandler struct {
+	name     string
+	methods  []string
+	chainIds []uint64
+}
+
+func (h *echoHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
+	return &types.JsonResponse{Id: req.Id, Result: h.name + ":" + req.Method}, nil
+}
+
+func (h *echoHandler) Capabilities() HandlerCapabilities {
+	return HandlerCapabilities{Methods: h.methods, ChainIds: h.chainIds}
+}
+
+func TestHandlerRouter(t *testing.T) {
+	cfg := &Config{}
+	cfg.Apis.Default.Enabled = true
+	cfg.Apis.Custom = map[string]ApiHandler{
+		"zeta":  &echoHandler{name: "zeta", methods: []string{"GetWalletBalance", "GetGasPrice"}, chainIds: []uint64{137, 1}},
+		"alpha": &echoHandler{name: "alpha", methods: []string{"GetGasPrice"}, chainIds: []uint64{1}},
+	}
+
+	router, err := newApiHandler(cfg, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		method string
+		result any
+		err    error
+	}{
+		{name: "Method without namespace is routed to built-in handler first", method: "GetWalletBalance", result: 555},
+		{name: "Method with namespace", method: "zeta.GetWalletBalance", result: "zeta:GetWalletBalance"},
+		{name: "Custom handlers are ordered by namespace", method: "GetGasPrice", result: "alpha:GetGasPrice"},
+		{name: "Method of other handler", method: "zeta.GetGasPrice", result: "zeta:GetGasPrice"},
+		{name: "Method is not served by namespace", method: "alpha.GetWalletBalance", err: errUnrecognizedMethod},
+		{name: "Unknown namespace", method: "infura.GetWalletBalance", err: errUnrecognizedMethod},
+		{name: "Unknown method", method: "GetBlock", err: errUnrecognizedMethod},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp, err := router.Process(&types.JsonRequest{Id: types.StringId("1"), Method: testCase.method, Params: types.Params("a", "b")})
+			if testCase.err != nil {
+				assert.ErrorIs(t, err, testCase.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.result, resp.Result)
+		})
+	}
+
+	capabilities := router.Capabilities()
+	assert.ElementsMatch(t, []string{
+		"default.GetWalletBalance", "GetWalletBalance", "zeta.GetWalletBalance",
+		"alpha.GetGasPrice", "GetGasPrice", "zeta.GetGasPrice",
+	}, capabilities.Methods)
+	assert.Equal(t, []uint64{1, 137}, capabilities.ChainIds)
+
+	methods := router.Methods()
+	require.Len(t, methods, 4)
+	assert.Equal(t, MethodInfo{Name: "alpha.GetGasPrice", Handler: "alpha", Method: "GetGasPrice", Default: true, ChainIds: []uint64{1}}, methods[0])
+	assert.False(t, methods[3].Default, "zeta.GetWalletBalance is not default")
+}
+
+func TestHandlerRouterInvalidConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	_, err := newApiHandler(&Config{}, logger)
+	assert.True(t, errors.Is(err, errNoHandlerApiInConfig))
+
+	cfg := &Config{}
+	cfg.Apis.Custom = map[string]ApiHandler{"my.api": &echoHandler{}}
+	_, err = newApiHandler(cfg, logger)
+	assert.ErrorIs(t, err, errInvalidNamespace)
+
+	cfg.Apis.Default.Enabled = true
+	cfg.Apis.Custom = map[string]ApiHandler{namespaceDefault: &echoHandler{}}
+	_, err = newApiHandler(cfg, logger)
+	assert.ErrorIs(t, err, errDuplicateNamespace)
+}
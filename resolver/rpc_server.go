@@ -8,30 +8,55 @@ import (
 	"errors"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/1inch/p2p-network/internal/encryption"
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
 	pb "github.com/1inch/p2p-network/proto/resolver"
 	"github.com/1inch/p2p-network/resolver/types"
 	ecies "github.com/ecies/go/v2"
 	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
-	errEmptyRequest   = errors.New("empty request")
-	errEmptyRequestId = errors.New("empty request id")
-	errEmptyPayload   = errors.New("empty payload")
-	errEmptyPublicKey = errors.New("empty public key")
+	errEmptyRequest     = errors.New("empty request")
+	errEmptyRequestId   = errors.New("empty request id")
+	errEmptyPayload     = errors.New("empty payload")
+	errEmptyPublicKey   = errors.New("empty public key")
+	errInvalidChallenge = errors.New("heartbeat challenge must be 1-64 bytes")
 )
 
+// maxChallengeLength limits heartbeat challenge, so resolver doesn't hash arbitrary large payloads
+const maxChallengeLength = 64
+
 // Server represents gRPC server.
 type Server struct {
 	pb.UnimplementedExecuteServer
+	pb.UnimplementedLivenessServer
+
+	signer signer.Signer
 
-	privateKey *ecies.PrivateKey
+	// previousSigner is nil when key rotation is not in progress
+	previousSigner signer.Signer
+	// previousKeyValidUntil is zero when previous key is accepted without time limit
+	previousKeyValidUntil time.Time
 
 	logger *slog.Logger
 
-	handler ApiHandler
+	// handler routes requests to enabled api handlers
+	handler *handlerRouter
+
+	// replayCache is nil when replay protection is disabled
+	replayCache *replayCache
+
+	// clientAccess is nil when client access control is disabled
+	clientAccess *clientAccess
+
+	// payments is nil when requests are free
+	payments *payments
 }
 
 // newServer creates new RpcServer.
@@ -40,46 +65,77 @@ func newServer(cfg *Config) (*Server, error) {
 		Level: cfg.LogLevel,
 	})
 	logger := slog.New(loggerHandler)
-	var handler ApiHandler
 
-	switch {
-	case cfg.Apis.Default.Enabled:
-		{
-			logger.Debug("set default api handler")
-			handler = NewDefaultApiHandler(cfg.Apis.Default, logger)
-		}
-	case cfg.Apis.Infura.Enabled:
-		{
-			logger.Debug("set infura api handler")
-			handler = NewInfuraApiHandler(cfg.Apis.Infura, logger)
+	handler, err := newApiHandler(cfg, logger)
+	if err != nil {
+		logger.Error("expect someone handler api in config")
+		return nil, err
+	}
+
+	nodeSigner, err := signer.New(cfg.PrivateKey, cfg.Keystore)
+	if errors.Is(err, signer.ErrNoKeyConfigured) {
+		logger.Warn("node key is not configured, generate new one")
+		nodeSigner, err = signer.Generate()
+	}
+	if err != nil {
+		logger.Error("failed to load node key", slog.Any("err", err))
+		return nil, err
+	}
+	logger.Info("node public key", slog.String("key", hex.EncodeToString(signer.CompressedPublicKey(nodeSigner))))
+
+	var previousSigner signer.Signer
+	var previousKeyValidUntil time.Time
+	rotation := cfg.KeyRotation
+	if rotation.PreviousPrivateKey != "" || rotation.PreviousKeystore.Path != "" {
+		previousSigner, err = signer.New(rotation.PreviousPrivateKey, rotation.PreviousKeystore)
+		if err != nil {
+			logger.Error("failed to load previous node key", slog.Any("err", err))
+			return nil, err
 		}
-	case cfg.Apis.OneInch.Enabled:
-		{
-			logger.Debug("set 1inch api handler")
-			handler = NewOneInchApiHandler(cfg.Apis.OneInch, logger)
+
+		if rotation.GracePeriod > 0 {
+			previousKeyValidUntil = time.Now().Add(rotation.GracePeriod)
 		}
-	default:
-		logger.Error("expect someone handler api in config")
-		return nil, errNoHandlerApiInConfig
+		logger.Info("previous node key accepted", slog.String("key", hex.EncodeToString(signer.CompressedPublicKey(previousSigner))), slog.Any("grace_period", rotation.GracePeriod))
 	}
 
-	var privKey *ecies.PrivateKey
-	if len(cfg.PrivateKey) > 0 {
-		privKeyBytes, err := hex.DecodeString(cfg.PrivateKey)
+	var replay *replayCache
+	if cfg.ReplayProtection.Enabled {
+		logger.Debug("replay protection enabled")
+		replay = newReplayCache(cfg.ReplayProtection)
+	}
+
+	var access *clientAccess
+	if cfg.ClientAccess.Enabled {
+		logger.Debug("client access control enabled")
+		access, err = newClientAccess(cfg.ClientAccess, logger)
 		if err != nil {
-			logger.Error("error decoding private key hex", slog.Any("err", err))
+			logger.Error("failed to create client access control", slog.Any("err", err))
 			return nil, err
 		}
-		privKey = ecies.NewPrivateKeyFromBytes(privKeyBytes)
-	} else {
-		privKeyGenerated, err := encryption.GenerateKeyPair()
+	}
 
+	var paid *payments
+	if cfg.Payment.Enabled {
+		logger.Debug("payments enabled")
+		paid, err = newPayments(cfg.Payment, nodeSigner, logger)
 		if err != nil {
+			logger.Error("failed to create payments", slog.Any("err", err))
 			return nil, err
 		}
-		privKey = privKeyGenerated
+		logger.Info("payments accepted", slog.String("payee", paid.payee.Hex()), slog.String("contract", paid.domain.Contract.Hex()))
 	}
-	return &Server{privateKey: privKey, logger: logger.With("module", "rpc-server"), handler: handler}, nil
+
+	return &Server{
+		signer:                nodeSigner,
+		previousSigner:        previousSigner,
+		previousKeyValidUntil: previousKeyValidUntil,
+		logger:                logger.With("module", "rpc-server"),
+		handler:               handler,
+		replayCache:           replay,
+		clientAccess:          access,
+		payments:              paid,
+	}, nil
 }
 
 // Execute executes ResolverRequest.
@@ -90,17 +146,30 @@ func (s *Server) Execute(ctx context.Context, req *pb.ResolverRequest) (*pb.Reso
 		return s.buildResolverResponseWithErr(req, err), nil
 	}
 
-	jsonReq, err := s.getJsonRequest(req)
+	payload, err := s.getPayload(req)
 	if err != nil {
 		return s.buildResolverResponseWithErr(req, err), nil
 	}
 
-	resp, err := s.processRequest(jsonReq)
+	// public key of plain request isn't used for encryption of response, so plain requests are anonymous
+	var clientKey []byte
+	if req.Encrypted {
+		clientKey = req.PublicKey
+	}
 
+	resp, err := s.processPayload(payload, clientKey)
 	if err != nil {
 		return s.buildResolverResponseWithErr(req, err), nil
 	}
 
+	if len(resp) == 0 {
+		// JSON-RPC notifications don't get response, so payload is empty
+		return &pb.ResolverResponse{
+			Id:     req.Id,
+			Result: &pb.ResolverResponse_Payload{},
+		}, nil
+	}
+
 	if req.Encrypted {
 		pubKeyDecompressed, err := ethCrypto.DecompressPubkey(req.PublicKey)
 		if err != nil {
@@ -127,6 +196,44 @@ func (s *Server) Execute(ctx context.Context, req *pb.ResolverRequest) (*pb.Reso
 	}, nil
 }
 
+// ListMethods returns methods served by enabled api handlers.
+func (s *Server) ListMethods(ctx context.Context, req *pb.ListMethodsRequest) (*pb.ListMethodsResponse, error) {
+	methods := s.handler.Methods()
+	resp := &pb.ListMethodsResponse{Methods: make([]*pb.MethodInfo, len(methods))}
+	for i, method := range methods {
+		resp.Methods[i] = &pb.MethodInfo{
+			Name:     method.Name,
+			Handler:  method.Handler,
+			Method:   method.Method,
+			Default:  method.Default,
+			ChainIds: method.ChainIds,
+		}
+	}
+
+	return resp, nil
+}
+
+// Heartbeat returns liveness attestation signed by node key.
+func (s *Server) Heartbeat(ctx context.Context, req *pb.HeartbeatRequest) (*pb.HeartbeatResponse, error) {
+	if len(req.GetChallenge()) == 0 || len(req.GetChallenge()) > maxChallengeLength {
+		return nil, status.Error(codes.InvalidArgument, errInvalidChallenge.Error())
+	}
+
+	publicKey := signer.CompressedPublicKey(s.signer)
+	timestamp := time.Now().Unix()
+	signature, err := s.signer.SignHash(registry.HeartbeatHash(req.GetChallenge(), timestamp, publicKey))
+	if err != nil {
+		s.logger.Error("failed to sign heartbeat", slog.Any("err", err))
+		return nil, status.Error(codes.Internal, "failed to sign heartbeat")
+	}
+
+	return &pb.HeartbeatResponse{
+		PublicKey: publicKey,
+		Timestamp: timestamp,
+		Signature: signature,
+	}, nil
+}
+
 func (s *Server) validateResolverRequest(req *pb.ResolverRequest) error {
 	// return after this check, because maybe nil pointer exception in next checks
 	// maybe this check is useless. let it stay for reinsuranceClick to apply
@@ -151,33 +258,201 @@ func (s *Server) validateResolverRequest(req *pb.ResolverRequest) error {
 	return nil
 }
 
-func (s *Server) getJsonRequest(req *pb.ResolverRequest) (*types.JsonRequest, error) {
-	var jsonReq types.JsonRequest
-	var payload []byte
-	if req.Encrypted {
-		decrypted, err := encryption.Decrypt(req.Payload, s.privateKey)
-		if err != nil {
-			return nil, err
-		}
-		payload = decrypted
-	} else {
-		payload = req.Payload
+// getPayload returns plain payload of request, encrypted payload is decrypted with node key
+func (s *Server) getPayload(req *pb.ResolverRequest) ([]byte, error) {
+	if !req.Encrypted {
+		return req.Payload, nil
 	}
+
+	return s.decrypt(req.Payload)
+}
+
+// processPayload processes legacy request, JSON-RPC 2.0 request or batch of JSON-RPC 2.0 requests.
+// Legacy request errors are returned as error, JSON-RPC 2.0 errors are returned as error objects in response payload.
+func (s *Server) processPayload(payload []byte, clientKey []byte) ([]byte, error) {
+	if isBatch(payload) {
+		return s.processBatch(payload, clientKey)
+	}
+
+	var jsonReq types.JsonRequest
 	err := json.Unmarshal(payload, &jsonReq)
 	if err != nil {
 		s.logger.Error("failed unmarshal request payload")
 		return nil, err
 	}
-	return &jsonReq, nil
+
+	if jsonReq.JsonRpc != "" {
+		return s.marshalJsonRpcResponse(s.processJsonRpcRequest(&jsonReq, clientKey))
+	}
+
+	err = s.checkReplay(&jsonReq)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkClient(&jsonReq, clientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkPayment(&jsonReq)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkQuota(&jsonReq, clientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.processRequest(&jsonReq)
+	if err != nil {
+		s.release(&jsonReq, clientKey, err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// decrypt decrypts payload with node key, during key rotation payload encrypted with previous key is accepted too
+func (s *Server) decrypt(payload []byte) ([]byte, error) {
+	decrypted, err := s.signer.Decrypt(payload)
+	if err == nil || s.previousSigner == nil {
+		return decrypted, err
+	}
+
+	if !s.previousKeyValidUntil.IsZero() && time.Now().After(s.previousKeyValidUntil) {
+		return nil, err
+	}
+
+	decrypted, previousErr := s.previousSigner.Decrypt(payload)
+	if previousErr != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("request decrypted with previous node key")
+	return decrypted, nil
+}
+
+func (s *Server) checkReplay(jsonReq *types.JsonRequest) error {
+	if s.replayCache == nil {
+		return nil
+	}
+
+	err := s.replayCache.check(jsonReq.Timestamp, jsonReq.Nonce)
+	if err != nil {
+		s.logger.Warn("request rejected by replay protection", slog.Any("id", jsonReq.Id), slog.Any("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// checkClient rejects denied clients, request is counted in client quota by checkQuota after payment check
+func (s *Server) checkClient(jsonReq *types.JsonRequest, clientKey []byte) error {
+	if s.clientAccess == nil {
+		return nil
+	}
+
+	err := s.clientAccess.authorize(clientKey, jsonReq.ApiToken)
+	if err != nil {
+		s.logger.Warn("request rejected by client access control", slog.Any("id", jsonReq.Id), slog.Any("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// checkQuota counts request in client quota, so requests rejected by payment check don't spend quota.
+// Voucher of request which exceeded quota is refunded, nonce of request which exceeded requests per minute quota
+// is released too, so the same request can be sent again after quota is refilled.
+func (s *Server) checkQuota(jsonReq *types.JsonRequest, clientKey []byte) error {
+	if s.clientAccess == nil {
+		return nil
+	}
+
+	err := s.clientAccess.consume(clientKey, jsonReq.ApiToken)
+	if err != nil {
+		s.logger.Warn("request rejected by client quota", slog.Any("id", jsonReq.Id), slog.Any("err", err.Error()))
+		if handlerError(err).Retryable {
+			s.releaseRequest(jsonReq)
+		} else {
+			s.refundPayment(jsonReq)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkPayment checks voucher of request to paid method, requests to unknown methods aren't charged
+func (s *Server) checkPayment(jsonReq *types.JsonRequest) error {
+	if s.payments == nil {
+		return nil
+	}
+
+	method, ok := s.handler.methodName(jsonReq.Method)
+	if !ok {
+		return nil
+	}
+
+	err := s.payments.check(method, jsonReq.Voucher)
+	if err != nil {
+		s.logger.Warn("request rejected by payment check", slog.Any("id", jsonReq.Id), slog.Any("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// release undoes replay protection, quota and payment checks of request which failed with retryable error,
+// so the same request with the same nonce and voucher can be retried
+func (s *Server) release(jsonReq *types.JsonRequest, clientKey []byte, err error) {
+	if !handlerError(err).Retryable {
+		return
+	}
+
+	if s.clientAccess != nil {
+		s.clientAccess.refund(clientKey, jsonReq.ApiToken)
+	}
+	s.releaseRequest(jsonReq)
+
+	s.logger.Debug("request released after retryable error", slog.Any("id", jsonReq.Id))
+}
+
+// releaseRequest forgets nonce and refunds voucher of request, so the same request can be sent again
+func (s *Server) releaseRequest(jsonReq *types.JsonRequest) {
+	if s.replayCache != nil {
+		s.replayCache.forget(jsonReq.Nonce)
+	}
+	s.refundPayment(jsonReq)
+}
+
+// refundPayment undoes payment check of request, so its voucher can be sent again
+func (s *Server) refundPayment(jsonReq *types.JsonRequest) {
+	if s.payments == nil {
+		return
+	}
+
+	if method, ok := s.handler.methodName(jsonReq.Method); ok {
+		s.payments.refund(method, jsonReq.Voucher)
+	}
 }
 
 func (s *Server) buildResolverResponseWithErr(req *pb.ResolverRequest, err error) *pb.ResolverResponse {
+	handlerErr := handlerError(err)
+	if _, ok := clientMessages[handlerErr.Code]; ok {
+		s.logger.Error("request failed", slog.String("id", req.GetId()), slog.String("code", handlerErr.Code.String()), slog.Any("err", err))
+	}
+
 	return &pb.ResolverResponse{
 		Id: req.Id,
 		Result: &pb.ResolverResponse_Error{
 			Error: &pb.Error{
-				Code:    s.getErrorCodeByErr(err),
-				Message: err.Error(),
+				Code:      handlerErr.Code,
+				Message:   clientMessage(handlerErr),
+				Retryable: handlerErr.Retryable,
+				Details:   handlerErr.Details,
 			},
 		},
 	}
@@ -186,7 +461,7 @@ func (s *Server) buildResolverResponseWithErr(req *pb.ResolverRequest, err error
 func (s *Server) processRequest(jsonReq *types.JsonRequest) ([]byte, error) {
 	jsonResponses, err := s.handler.Process(jsonReq)
 	if err != nil {
-		s.logger.Error("failed process request in handler")
+		s.logger.Error("failed process request in handler", slog.String("method", jsonReq.Method), slog.Any("err", err))
 		return nil, err
 	}
 
@@ -198,18 +473,3 @@ func (s *Server) processRequest(jsonReq *types.JsonRequest) ([]byte, error) {
 
 	return byteArr, nil
 }
-
-func (s *Server) getErrorCodeByErr(err error) pb.ErrorCode {
-	if errors.Is(err, errEmptyRequest) ||
-		errors.Is(err, errEmptyRequestId) ||
-		errors.Is(err, errEmptyPayload) ||
-		errors.Is(err, errEmptyPublicKey) ||
-		errors.Is(err, errWrongParamCount) ||
-		errors.Is(err, errInvalidFormatAddress) ||
-		errors.Is(err, errUnrecognizedMethod) {
-
-		return pb.ErrorCode_ERR_INVALID_MESSAGE_FORMAT
-	}
-
-	return pb.ErrorCode_ERR_GRPC_EXECUTION_FAILED
-}
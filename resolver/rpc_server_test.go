@@ -2,20 +2,27 @@ package resolver
 
 import (
 	"context"
-	"crypto"
+	"crypto/ecdsa"
+	"encoding/hex"
 	"encoding/json"
 	"log/slog"
 	"net"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/1inch/p2p-network/internal/encryption"
+	"github.com/1inch/p2p-network/internal/registry"
 	pb "github.com/1inch/p2p-network/proto/resolver"
 	"github.com/1inch/p2p-network/resolver/types"
 	ecies "github.com/ecies/go/v2"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
@@ -29,10 +36,9 @@ type ResolverTestSuite struct {
 	logger *slog.Logger
 	server *grpc.Server
 
-	resolverPrivateKey crypto.PrivateKey
-	resolverPublicKey  *ecies.PublicKey
-	client             pb.ExecuteClient
-	conn               *grpc.ClientConn
+	resolverPublicKey *ecies.PublicKey
+	client            pb.ExecuteClient
+	conn              *grpc.ClientConn
 }
 
 func (s *ResolverTestSuite) SetupTest() {
@@ -48,14 +54,12 @@ func (s *ResolverTestSuite) SetupTest() {
 		return
 	}
 
-	s.resolverPrivateKey = server.privateKey
-
-	eciesKey, ok := s.resolverPrivateKey.(*ecies.PrivateKey)
-	if !ok {
+	resolverPublicKey, err := ecies.NewPublicKeyFromBytes(ethCrypto.FromECDSAPub(server.signer.PublicKey()))
+	if err != nil {
 		s.Fail("incorrect key format")
 	}
 
-	s.resolverPublicKey = eciesKey.PublicKey
+	s.resolverPublicKey = resolverPublicKey
 
 	grpcServer := newGrpcServer(logger, server)
 	go func() {
@@ -90,21 +94,21 @@ func TestResolverTestSuite(t *testing.T) {
 }
 
 func (s *ResolverTestSuite) getWalletBalancePayloadOk() []byte {
-	jsonReq := &types.JsonRequest{Id: "1", Method: "GetWalletBalance", Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"}}
+	jsonReq := &types.JsonRequest{Id: types.StringId("1"), Method: "GetWalletBalance", Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest")}
 	byteArr, _ := json.Marshal(jsonReq)
 
 	return byteArr
 }
 
 func (s *ResolverTestSuite) getWalletBalancePayloadUnrecognizedMethod() []byte {
-	jsonReq := &types.JsonRequest{Id: "1", Method: "UnrecognizedMethod", Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"}}
+	jsonReq := &types.JsonRequest{Id: types.StringId("1"), Method: "UnrecognizedMethod", Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest")}
 	byteArr, _ := json.Marshal(jsonReq)
 
 	return byteArr
 }
 
 func (s *ResolverTestSuite) getWalletBalancePayloadNoParams() []byte {
-	jsonReq := &types.JsonRequest{Id: "1", Method: "GetWalletBalance", Params: []string{}}
+	jsonReq := &types.JsonRequest{Id: types.StringId("1"), Method: "GetWalletBalance", Params: types.Params()}
 	byteArr, _ := json.Marshal(jsonReq)
 
 	return byteArr
@@ -122,7 +126,7 @@ func (s *ResolverTestSuite) TestExecutePositive() {
 	var jsonResp types.JsonResponse
 	err = json.Unmarshal(resp.GetPayload(), &jsonResp)
 	s.Require().NoError(err)
-	s.Require().Equal(jsonResp.Id, req.Id)
+	s.Require().Equal(types.StringId(req.Id), jsonResp.Id)
 	s.Require().Equal(jsonResp.Result, defaultBalance)
 }
 
@@ -155,7 +159,7 @@ func (s *ResolverTestSuite) TestExecuteEncrypted() {
 	var jsonResp types.JsonResponse
 	err = json.Unmarshal(decryptedPayload, &jsonResp)
 	s.Require().NoError(err)
-	s.Require().Equal(jsonResp.Id, req.Id)
+	s.Require().Equal(types.StringId(req.Id), jsonResp.Id)
 	s.Require().Equal(jsonResp.Result.(float64), defaultBalance)
 }
 
@@ -165,13 +169,13 @@ func (s *ResolverTestSuite) TestExecuteNegativeCases() {
 		{
 			Name:            "UnrecognizedMethodParameter",
 			ResolverRequest: &pb.ResolverRequest{Id: "1", Payload: s.getWalletBalancePayloadUnrecognizedMethod(), Encrypted: false},
-			ExpectedCode:    pb.ErrorCode_ERR_INVALID_MESSAGE_FORMAT,
+			ExpectedCode:    pb.ErrorCode_ERR_METHOD_NOT_FOUND,
 			ExpectedError:   errUnrecognizedMethod,
 		},
 		{
 			Name:            "NoParameterInPayload",
 			ResolverRequest: &pb.ResolverRequest{Id: "1", Payload: s.getWalletBalancePayloadNoParams(), Encrypted: false},
-			ExpectedCode:    pb.ErrorCode_ERR_INVALID_MESSAGE_FORMAT,
+			ExpectedCode:    pb.ErrorCode_ERR_INVALID_PARAMS,
 			ExpectedError:   errWrongParamCount,
 		},
 	}
@@ -214,3 +218,80 @@ func (s *ResolverTestSuite) getResolverResponse(statusError *status.Status) *pb.
 
 	return resolverResponse
 }
+
+func (s *ResolverTestSuite) TestHeartbeat() {
+	livenessClient := pb.NewLivenessClient(s.conn)
+	challenge := []byte("challenge")
+
+	resp, err := livenessClient.Heartbeat(context.Background(), &pb.HeartbeatRequest{Challenge: challenge})
+	s.Require().NoError(err)
+	s.Equal(s.resolverPublicKey.Bytes(true), resp.PublicKey)
+	s.InDelta(time.Now().Unix(), resp.Timestamp, 5)
+	s.NoError(registry.VerifyHeartbeat(resp.PublicKey, challenge, resp.Timestamp, resp.Signature))
+	s.ErrorIs(registry.VerifyHeartbeat(resp.PublicKey, []byte("other"), resp.Timestamp, resp.Signature), registry.ErrInvalidHeartbeat)
+
+	_, err = livenessClient.Heartbeat(context.Background(), &pb.HeartbeatRequest{})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ResolverTestSuite) TestListMethods() {
+	resp, err := s.client.ListMethods(context.Background(), &pb.ListMethodsRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Methods, 1)
+	s.Equal("default.GetWalletBalance", resp.Methods[0].Name)
+	s.Equal("default", resp.Methods[0].Handler)
+	s.Equal("GetWalletBalance", resp.Methods[0].Method)
+	s.True(resp.Methods[0].Default)
+}
+
+func TestExecuteWithPreviousKey(t *testing.T) {
+	currentKey, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+	previousKey, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+	unknownKey, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+
+	cfg := &Config{PrivateKey: hex.EncodeToString(ethCrypto.FromECDSA(currentKey))}
+	cfg.Apis.Default.Enabled = true
+	cfg.KeyRotation.PreviousPrivateKey = hex.EncodeToString(ethCrypto.FromECDSA(previousKey))
+	cfg.KeyRotation.GracePeriod = time.Hour
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+
+	relayerKey, err := encryption.GenerateKeyPair()
+	require.NoError(t, err)
+
+	execute := func(key *ecdsa.PrivateKey) *pb.ResolverResponse {
+		publicKey, err := ecies.NewPublicKeyFromBytes(ethCrypto.FromECDSAPub(&key.PublicKey))
+		require.NoError(t, err)
+
+		payload, err := json.Marshal(&types.JsonRequest{
+			Id:     types.StringId("1"),
+			Method: "GetWalletBalance",
+			Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
+		})
+		require.NoError(t, err)
+
+		encryptedPayload, err := encryption.Encrypt(payload, publicKey)
+		require.NoError(t, err)
+
+		resp, err := server.Execute(context.Background(), &pb.ResolverRequest{
+			Id:        "1",
+			Payload:   encryptedPayload,
+			Encrypted: true,
+			PublicKey: relayerKey.PublicKey.Bytes(true),
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	assert.Nil(t, execute(currentKey).GetError(), "request encrypted with current key must be processed")
+	assert.Nil(t, execute(previousKey).GetError(), "request encrypted with previous key must be processed during grace period")
+	assert.NotNil(t, execute(unknownKey).GetError(), "request encrypted with unknown key must be rejected")
+
+	server.previousKeyValidUntil = time.Now().Add(-time.Second)
+	assert.NotNil(t, execute(previousKey).GetError(), "request encrypted with previous key must be rejected after grace period")
+	assert.Nil(t, execute(currentKey).GetError())
+}
@@ -0,0 +1,104 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errPaymentRpcUrlRequired = errors.New("payment rpc url is required for settlement")
+	errPaymentChainMismatch  = errors.New("chain of payment rpc url differs from payment chain id")
+	errVouchersFileRequired  = errors.New("vouchers file is required for settlement")
+)
+
+// Settlement is result of settlement of the latest voucher of payer.
+type Settlement struct {
+	Payer common.Address
+	// Amount is cumulative amount of the voucher in wei
+	Amount *big.Int
+	// Payout is amount in wei which is paid by settlement transaction
+	Payout *big.Int
+	TxHash common.Hash
+	// Err is error of settlement transaction, other settlements are sent regardless of it
+	Err error
+}
+
+// SettleVouchers settles vouchers from vouchers file of payment config with transactions sent by node key.
+// Vouchers whose payout is less than minPayout are kept for later settlement.
+func SettleVouchers(ctx context.Context, logger *slog.Logger, cfg *Config, minPayout *big.Int) ([]Settlement, error) {
+	if cfg.Payment.RpcUrl == "" {
+		return nil, errPaymentRpcUrlRequired
+	}
+	if cfg.Payment.VouchersFile == "" {
+		return nil, errVouchersFileRequired
+	}
+
+	nodeSigner, err := signer.New(cfg.PrivateKey, cfg.Keystore)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, payee, err := paymentDomain(cfg.Payment, nodeSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	vouchers, err := loadVouchers(cfg.Payment.VouchersFile, domain, payee)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := registry.Dial(ctx, &registry.Config{
+		DialURI: cfg.Payment.RpcUrl,
+		Signer:  nodeSigner,
+		Tx:      cfg.Transaction,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	channel, err := client.PaymentChannel(domain.Contract)
+	if err != nil {
+		return nil, err
+	}
+
+	chainDomain, err := channel.Domain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if chainDomain.ChainId.Cmp(domain.ChainId) != 0 {
+		return nil, fmt.Errorf("%w: %s != %s", errPaymentChainMismatch, chainDomain.ChainId, domain.ChainId)
+	}
+
+	var settlements []Settlement
+	for _, voucher := range vouchers {
+		state, err := channel.GetChannel(ctx, voucher.Payer, payee)
+		if err != nil {
+			return settlements, err
+		}
+
+		payout := new(big.Int).Sub(voucher.Amount, state.Settled)
+		if payout.Sign() <= 0 || (minPayout != nil && payout.Cmp(minPayout) < 0) {
+			logger.Debug("voucher is not settled", slog.String("payer", voucher.Payer.Hex()), slog.String("payout", payout.String()))
+			continue
+		}
+
+		settlement := Settlement{Payer: voucher.Payer, Amount: voucher.Amount, Payout: payout}
+		if voucher.Amount.Cmp(state.Deposit) > 0 {
+			settlement.Err = fmt.Errorf("%w: deposit is %s wei", errDepositExceeded, state.Deposit)
+		} else {
+			settlement.TxHash, settlement.Err = channel.Settle(ctx, voucher)
+		}
+		settlements = append(settlements, settlement)
+	}
+
+	return settlements, nil
+}
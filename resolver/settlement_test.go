@@ -0,0 +1,109 @@
+//go:build deploy
+// +build deploy
+
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/1inch/p2p-network/internal/registry"
+	"github.com/1inch/p2p-network/internal/signer"
+	pb "github.com/1inch/p2p-network/proto/resolver"
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rpcURL        = "http://127.0.0.1:8545"
+	privateKeyHex = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+)
+
+func TestSettleVouchers(t *testing.T) {
+	ctx := context.Background()
+	resolverPrivateKey := "5de4111afa1a4b94908f83103eb1f1706367c2e68ca870fc3fb9a804cdab365a"
+	payerPrivateKey := "7c852118294e51e653712a81e05800f419141751be58f605c371e15141b007a6"
+
+	ownerClient, err := registry.Dial(ctx, &registry.Config{DialURI: rpcURL, PrivateKey: privateKeyHex})
+	require.NoError(t, err)
+	channel, err := ownerClient.DeployPaymentChannel(ctx, 0)
+	require.NoError(t, err, "contract deployment failed")
+	domain, err := channel.Domain(ctx)
+	require.NoError(t, err)
+
+	resolverSigner, err := signer.NewFromHex(resolverPrivateKey)
+	require.NoError(t, err)
+	payer, err := signer.NewFromHex(payerPrivateKey)
+	require.NoError(t, err)
+
+	payerClient, err := registry.Dial(ctx, &registry.Config{DialURI: rpcURL, PrivateKey: payerPrivateKey})
+	require.NoError(t, err)
+	payerChannel, err := payerClient.PaymentChannel(channel.Address())
+	require.NoError(t, err)
+	require.NoError(t, payerChannel.Deposit(ctx, resolverSigner.Address(), big.NewInt(1000)))
+
+	cfg := &Config{PrivateKey: resolverPrivateKey}
+	cfg.Apis.Default.Enabled = true
+	cfg.Payment = PaymentConfig{
+		Enabled:      true,
+		ChainId:      domain.ChainId.Uint64(),
+		Contract:     channel.Address().Hex(),
+		RpcUrl:       rpcURL,
+		Price:        "100",
+		VouchersFile: filepath.Join(t.TempDir(), "vouchers.json"),
+	}
+
+	server, err := newServer(cfg)
+	require.NoError(t, err)
+
+	execute := func(amount int64) *types.JsonResponse {
+		request := types.JsonRequest{
+			JsonRpc: types.Version,
+			Id:      types.NumberId(amount),
+			Method:  "GetWalletBalance",
+			Params:  types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
+			Voucher: signTestVoucher(t, server.payments, payer, amount),
+		}
+		payload, err := json.Marshal(request)
+		require.NoError(t, err)
+
+		resp, err := server.Execute(ctx, &pb.ResolverRequest{Id: "1", Payload: payload})
+		require.NoError(t, err)
+
+		var jsonResp types.JsonResponse
+		require.NoError(t, json.Unmarshal(resp.GetPayload(), &jsonResp))
+		return &jsonResp
+	}
+
+	require.Nil(t, execute(100).Error)
+	require.Nil(t, execute(200).Error)
+	require.Equal(t, types.CodePaymentRequired, execute(1100).Error.Code, "voucher exceeds deposit")
+
+	settlements, err := SettleVouchers(ctx, server.logger, cfg, big.NewInt(500))
+	require.NoError(t, err)
+	require.Empty(t, settlements, "payout is less than min payout")
+
+	settlements, err = SettleVouchers(ctx, server.logger, cfg, nil)
+	require.NoError(t, err)
+	require.Len(t, settlements, 1)
+	require.NoError(t, settlements[0].Err)
+	require.Equal(t, payer.Address(), settlements[0].Payer)
+	require.Equal(t, big.NewInt(200), settlements[0].Payout)
+
+	state, err := channel.GetChannel(ctx, payer.Address(), resolverSigner.Address())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(200), state.Settled)
+
+	settlements, err = SettleVouchers(ctx, server.logger, cfg, nil)
+	require.NoError(t, err)
+	require.Empty(t, settlements, "settled voucher isn't settled again")
+
+	require.Nil(t, execute(300).Error)
+	settlements, err = SettleVouchers(ctx, server.logger, cfg, nil)
+	require.NoError(t, err)
+	require.Len(t, settlements, 1)
+	require.Equal(t, big.NewInt(100), settlements[0].Payout, "only difference is paid")
+}
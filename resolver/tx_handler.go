@@ -0,0 +1,326 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	methodSendRawTransaction = "eth_sendRawTransaction"
+	methodSubmitTransaction  = "SubmitTransaction"
+)
+
+const (
+	defaultTxRelayMaxNonceGap                = 16
+	defaultTxRelayTimeout                    = 10 * time.Second
+	defaultTxRelayReceiptTimeout             = 30 * time.Second
+	defaultTxRelayReceiptPollInterval        = time.Second
+	defaultTxRelayMaxGasLimit         uint64 = 30_000_000
+)
+
+var (
+	errNoTxRelayUpstream      = errors.New("tx relay api handler has no upstream")
+	errInvalidTransaction     = errors.New("invalid signed transaction")
+	errUnprotectedTransaction = errors.New("transaction without chain ID is not relayed")
+	errChainIdMismatch        = errors.New("transaction chain ID differs from request chainId")
+	errNonceTooLow            = errors.New("nonce too low")
+	errNonceTooHigh           = errors.New("nonce too far ahead of pending nonce")
+	errGasLimitTooHigh        = errors.New("gas limit exceeds relay cap")
+	errFeeCapTooHigh          = errors.New("max fee per gas exceeds relay cap")
+	errInvalidMaxFeePerGas    = errors.New("invalid max fee per gas of tx relay config")
+)
+
+// knownTransactionErrors are messages of upstream errors returned for transaction which is already in its pool,
+// geth and erigon return "already known", nethermind "AlreadyKnown", older geth versions "known transaction: <hash>"
+var knownTransactionErrors = []string{"already known", "alreadyknown", "known transaction"}
+
+// txUpstream is part of ethclient.Client used by tx relay handler
+type txUpstream interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
+}
+
+// SubmitTransactionResult is result of SubmitTransaction method
+type SubmitTransactionResult struct {
+	Hash common.Hash `json:"hash"`
+	// Receipt is nil if receipt isn't requested or transaction isn't included before receipt timeout
+	Receipt *ethtypes.Receipt `json:"receipt"`
+}
+
+// txRelayApiHandler validates signed raw transactions and submits them to upstream node of transaction chain
+type txRelayApiHandler struct {
+	upstreams           map[uint64]*upstreamPool[txUpstream]
+	chainIds            []uint64
+	maxGasLimit         uint64
+	maxFeePerGas        *big.Int
+	maxNonceGap         uint64
+	receiptTimeout      time.Duration
+	receiptPollInterval time.Duration
+	logger              *slog.Logger
+}
+
+// NewTxRelayApiHandler creates a transaction relay API handler instance
+func NewTxRelayApiHandler(cfg TxRelayApiConfig, logger *slog.Logger) (ApiHandler, error) {
+	return newTxRelayApiHandler(cfg, func(url string) (txUpstream, error) {
+		return ethclient.Dial(url)
+	}, logger)
+}
+
+func newTxRelayApiHandler(cfg TxRelayApiConfig, dial func(url string) (txUpstream, error), logger *slog.Logger) (*txRelayApiHandler, error) {
+	if len(cfg.Upstreams) == 0 {
+		return nil, errNoTxRelayUpstream
+	}
+
+	handler := &txRelayApiHandler{
+		upstreams:           make(map[uint64]*upstreamPool[txUpstream], len(cfg.Upstreams)),
+		maxGasLimit:         cfg.MaxGasLimit,
+		maxNonceGap:         cfg.MaxNonceGap,
+		receiptTimeout:      cfg.ReceiptTimeout,
+		receiptPollInterval: cfg.ReceiptPollInterval,
+		logger:              logger.With("module", "api-tx-relay"),
+	}
+
+	if cfg.MaxFeePerGas != "" {
+		maxFeePerGas, ok := new(big.Int).SetString(cfg.MaxFeePerGas, 10)
+		if !ok || maxFeePerGas.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: %s", errInvalidMaxFeePerGas, cfg.MaxFeePerGas)
+		}
+		handler.maxFeePerGas = maxFeePerGas
+	}
+	if handler.maxGasLimit == 0 {
+		handler.maxGasLimit = defaultTxRelayMaxGasLimit
+	}
+	if handler.maxNonceGap == 0 {
+		handler.maxNonceGap = defaultTxRelayMaxNonceGap
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTxRelayTimeout
+	}
+	if handler.receiptTimeout == 0 {
+		handler.receiptTimeout = defaultTxRelayReceiptTimeout
+	}
+	if handler.receiptPollInterval == 0 {
+		handler.receiptPollInterval = defaultTxRelayReceiptPollInterval
+	}
+
+	for chainId, upstreams := range cfg.Upstreams {
+		pool, err := newUpstreamPool(upstreams, timeout, cfg.Failover, dial, handler.logger.With(slog.Uint64("chain_id", chainId)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create upstreams of chain %d: %w", chainId, err)
+		}
+
+		handler.upstreams[chainId] = pool
+		handler.chainIds = append(handler.chainIds, chainId)
+	}
+	slices.Sort(handler.chainIds)
+
+	return handler, nil
+}
+
+// Process validates and submits transaction, eth_sendRawTransaction returns transaction hash
+// and SubmitTransaction returns hash with receipt when it's requested
+func (h *txRelayApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse, error) {
+	switch req.Method {
+	case methodSendRawTransaction:
+		var rawTx hexutil.Bytes
+		if err := req.BindParams([]string{"rawTx"}, &rawTx); err != nil {
+			return &types.JsonResponse{Id: req.Id, Result: 0}, err
+		}
+
+		tx, err := h.submit(rawTx, req.ChainId)
+		if err != nil {
+			return &types.JsonResponse{Id: req.Id, Result: 0}, err
+		}
+		return &types.JsonResponse{Id: req.Id, Result: tx.Hash()}, nil
+	case methodSubmitTransaction:
+		var rawTx hexutil.Bytes
+		var waitForReceipt bool
+		var receiptTimeout uint64
+		if err := h.bindSubmitParams(req, &rawTx, &waitForReceipt, &receiptTimeout); err != nil {
+			return &types.JsonResponse{Id: req.Id, Result: 0}, err
+		}
+
+		tx, err := h.submit(rawTx, req.ChainId)
+		if err != nil {
+			return &types.JsonResponse{Id: req.Id, Result: 0}, err
+		}
+
+		result := SubmitTransactionResult{Hash: tx.Hash()}
+		if waitForReceipt {
+			result.Receipt = h.waitForReceipt(tx, h.receiptTimeoutOf(receiptTimeout))
+		}
+		return &types.JsonResponse{Id: req.Id, Result: result}, nil
+	default:
+		return &types.JsonResponse{Id: req.Id, Result: 0}, errUnrecognizedMethod
+	}
+}
+
+// Capabilities returns relay methods and chain IDs of upstreams
+func (h *txRelayApiHandler) Capabilities() HandlerCapabilities {
+	return HandlerCapabilities{
+		Methods:  []string{methodSendRawTransaction, methodSubmitTransaction},
+		ChainIds: slices.Clone(h.chainIds),
+	}
+}
+
+// bindSubmitParams decodes SubmitTransaction params, waitForReceipt and receiptTimeout are optional in positional params too
+func (h *txRelayApiHandler) bindSubmitParams(req *types.JsonRequest, rawTx *hexutil.Bytes, waitForReceipt *bool, receiptTimeout *uint64) error {
+	err := req.BindParams([]string{"rawTx", "waitForReceipt", "receiptTimeout"}, rawTx, waitForReceipt, receiptTimeout)
+	if errors.Is(err, types.ErrWrongParamCount) {
+		err = req.BindParams([]string{"rawTx", "waitForReceipt"}, rawTx, waitForReceipt)
+	}
+	if errors.Is(err, types.ErrWrongParamCount) {
+		err = req.BindParams([]string{"rawTx"}, rawTx)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(*rawTx) == 0 {
+		return fmt.Errorf("%w: empty transaction", errInvalidTransaction)
+	}
+	return nil
+}
+
+// submit validates transaction against relay caps and sender nonce and sends it to upstream of transaction chain
+func (h *txRelayApiHandler) submit(rawTx []byte, chainId uint64) (*ethtypes.Transaction, error) {
+	var tx ethtypes.Transaction
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidTransaction, err)
+	}
+
+	if !tx.Protected() || tx.ChainId().Sign() == 0 {
+		return nil, errUnprotectedTransaction
+	}
+	if !tx.ChainId().IsUint64() || (chainId != 0 && tx.ChainId().Uint64() != chainId) {
+		return nil, fmt.Errorf("%w: %s", errChainIdMismatch, tx.ChainId())
+	}
+
+	upstreams, ok := h.upstreams[tx.ChainId().Uint64()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedChainId, tx.ChainId())
+	}
+
+	if tx.Gas() > h.maxGasLimit {
+		return nil, fmt.Errorf("%w: %d > %d", errGasLimitTooHigh, tx.Gas(), h.maxGasLimit)
+	}
+	if h.maxFeePerGas != nil && tx.GasFeeCap().Cmp(h.maxFeePerGas) > 0 {
+		return nil, fmt.Errorf("%w: %s > %s", errFeeCapTooHigh, tx.GasFeeCap(), h.maxFeePerGas)
+	}
+
+	sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), &tx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidTransaction, err)
+	}
+
+	// nonce below mined nonce can't be included, nonce equal to pending one can replace pending transaction
+	var minedNonce, pendingNonce uint64
+	err = upstreams.call(func(ctx context.Context, upstream txUpstream) error {
+		var err error
+		minedNonce, err = upstream.NonceAt(ctx, sender, nil)
+		if err != nil {
+			return err
+		}
+
+		pendingNonce, err = upstream.PendingNonceAt(ctx, sender)
+		return err
+	})
+	if err != nil {
+		return nil, upstreamError(err)
+	}
+
+	if tx.Nonce() < minedNonce {
+		return nil, fmt.Errorf("%w: %d < %d", errNonceTooLow, tx.Nonce(), minedNonce)
+	}
+	if tx.Nonce() > pendingNonce+h.maxNonceGap {
+		return nil, fmt.Errorf("%w: %d > %d + %d", errNonceTooHigh, tx.Nonce(), pendingNonce, h.maxNonceGap)
+	}
+
+	// sending is idempotent, so transaction can be sent to next upstream after failure,
+	// transaction which upstream already has was sent by previous attempt or by other relay and is reported as sent
+	err = upstreams.call(func(ctx context.Context, upstream txUpstream) error {
+		err := upstream.SendTransaction(ctx, &tx)
+		if isKnownTransaction(err) {
+			h.logger.Debug("transaction is already known to upstream", slog.String("hash", tx.Hash().Hex()), slog.Any("err", err))
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		h.logger.Error("failed to send transaction to upstream", slog.String("hash", tx.Hash().Hex()), slog.Any("err", err))
+		return nil, upstreamError(err)
+	}
+
+	h.logger.Info("transaction relayed", slog.String("hash", tx.Hash().Hex()), slog.String("chain_id", tx.ChainId().String()), slog.String("from", sender.Hex()))
+	return &tx, nil
+}
+
+// isKnownTransaction reports whether upstream rejected transaction because it's already in its pool
+func isKnownTransaction(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	return slices.ContainsFunc(knownTransactionErrors, func(known string) bool {
+		return strings.Contains(message, known)
+	})
+}
+
+// receiptTimeoutOf returns receipt timeout requested in seconds, it's capped by configured receipt timeout
+// which is also used when request doesn't set it
+func (h *txRelayApiHandler) receiptTimeoutOf(seconds uint64) time.Duration {
+	if seconds == 0 || seconds >= uint64(h.receiptTimeout/time.Second) {
+		return h.receiptTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// waitForReceipt polls receipt until transaction is included or timeout, nil is returned on timeout
+func (h *txRelayApiHandler) waitForReceipt(tx *ethtypes.Transaction, timeout time.Duration) *ethtypes.Receipt {
+	upstreams := h.upstreams[tx.ChainId().Uint64()]
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(h.receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var receipt *ethtypes.Receipt
+		err := upstreams.call(func(callCtx context.Context, upstream txUpstream) error {
+			var err error
+			receipt, err = upstream.TransactionReceipt(callCtx, tx.Hash())
+			return err
+		})
+		if err == nil {
+			return receipt
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			h.logger.Warn("failed to fetch receipt", slog.String("hash", tx.Hash().Hex()), slog.Any("err", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			h.logger.Warn("transaction isn't included before receipt timeout", slog.String("hash", tx.Hash().Hex()))
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package resolver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// simulatedChainId is chain ID of simulated backend
+var simulatedChainId = params.AllDevChainProtocolChanges.ChainID
+
+func newSignedTx(t *testing.T, key *ecdsa.PrivateKey, chainId *big.Int, nonce, gas uint64, feeCap *big.Int) hexutil.Bytes {
+	to := common.HexToAddress("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324")
+	tx, err := ethtypes.SignNewTx(key, ethtypes.LatestSignerForChainID(chainId), &ethtypes.DynamicFeeTx{
+		ChainID:   chainId,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: feeCap,
+		Gas:       gas,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	require.NoError(t, err)
+
+	rawTx, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	return rawTx
+}
+
+func TestTxRelayApiHandler(t *testing.T) {
+	key, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+	sender := ethCrypto.PubkeyToAddress(key.PublicKey)
+
+	backend := simulated.NewBackend(ethtypes.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}})
+	t.Cleanup(func() { _ = backend.Close() })
+
+	handler, err := newTxRelayApiHandler(TxRelayApiConfig{
+		Upstreams:           map[uint64]UpstreamList{simulatedChainId.Uint64(): {{Url: "simulated"}}},
+		MaxGasLimit:         100_000,
+		MaxFeePerGas:        "100000000000",
+		MaxNonceGap:         2,
+		ReceiptTimeout:      5 * time.Second,
+		ReceiptPollInterval: 10 * time.Millisecond,
+	}, func(url string) (txUpstream, error) {
+		return backend.Client(), nil
+	}, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	feeCap := big.NewInt(10 * params.GWei)
+	firstTx := newSignedTx(t, key, simulatedChainId, 0, 21_000, feeCap)
+
+	resp, err := handler.Process(&types.JsonRequest{Method: methodSendRawTransaction, Params: types.Params(firstTx)})
+	require.NoError(t, err)
+	backend.Commit()
+
+	receipt, err := backend.Client().TransactionReceipt(ctx, resp.Result.(common.Hash))
+	require.NoError(t, err, "relayed transaction must be included")
+	assert.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+
+	otherKey, err := ethCrypto.GenerateKey()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		rawTx hexutil.Bytes
+		err   error
+	}{
+		{name: "Malformed transaction", rawTx: hexutil.Bytes{0x02, 0x01}, err: errInvalidTransaction},
+		{name: "Chain isn't served", rawTx: newSignedTx(t, key, big.NewInt(137), 1, 21_000, feeCap), err: errUnsupportedChainId},
+		{name: "Nonce too low", rawTx: firstTx, err: errNonceTooLow},
+		{name: "Nonce too high", rawTx: newSignedTx(t, key, simulatedChainId, 4, 21_000, feeCap), err: errNonceTooHigh},
+		{name: "Gas limit above cap", rawTx: newSignedTx(t, key, simulatedChainId, 1, 200_000, feeCap), err: errGasLimitTooHigh},
+		{name: "Fee above cap", rawTx: newSignedTx(t, key, simulatedChainId, 1, 21_000, big.NewInt(200*params.GWei)), err: errFeeCapTooHigh},
+		{name: "Upstream rejects transaction", rawTx: newSignedTx(t, otherKey, simulatedChainId, 0, 21_000, feeCap)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := handler.Process(&types.JsonRequest{Method: methodSendRawTransaction, Params: types.Params(testCase.rawTx)})
+			require.Error(t, err)
+			if testCase.err != nil {
+				assert.ErrorIs(t, err, testCase.err)
+			}
+		})
+	}
+
+	_, err = handler.Process(&types.JsonRequest{Method: methodSendRawTransaction, Params: types.Params(newSignedTx(t, key, simulatedChainId, 1, 21_000, feeCap)), ChainId: 1})
+	assert.ErrorIs(t, err, errChainIdMismatch, "transaction chain must match request chain")
+
+	// transaction sent again is already known to upstream and reported as sent
+	secondTx := newSignedTx(t, key, simulatedChainId, 1, 21_000, feeCap)
+	resp, err = handler.Process(&types.JsonRequest{Method: methodSendRawTransaction, Params: types.Params(secondTx)})
+	require.NoError(t, err)
+	resent, err := handler.Process(&types.JsonRequest{Method: methodSendRawTransaction, Params: types.Params(secondTx)})
+	require.NoError(t, err, "known transaction must be reported as sent")
+	assert.Equal(t, resp.Result, resent.Result)
+
+	// receipt is reported after transaction is included
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(50 * time.Millisecond):
+				backend.Commit()
+				if count, err := backend.Client().NonceAt(ctx, sender, nil); err == nil && count == 2 {
+					return
+				}
+			}
+		}
+	}()
+
+	submitParams := json.RawMessage(`{"rawTx":"` + secondTx.String() + `","waitForReceipt":true}`)
+	resp, err = handler.Process(&types.JsonRequest{Method: methodSubmitTransaction, Params: submitParams})
+	require.NoError(t, err)
+	<-done
+
+	result := resp.Result.(SubmitTransactionResult)
+	require.NotNil(t, result.Receipt)
+	assert.Equal(t, result.Hash, result.Receipt.TxHash)
+	assert.Equal(t, ethtypes.ReceiptStatusSuccessful, result.Receipt.Status)
+
+	// requested receipt timeout is shorter than configured one
+	started := time.Now()
+	submitParams = json.RawMessage(`["` + newSignedTx(t, key, simulatedChainId, 2, 21_000, feeCap).String() + `",true,1]`)
+	resp, err = handler.Process(&types.JsonRequest{Method: methodSubmitTransaction, Params: submitParams})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Result.(SubmitTransactionResult).Receipt, "receipt of not included transaction must be null")
+	assert.Less(t, time.Since(started), 4*time.Second)
+}
+
+func TestTxRelayReceiptTimeout(t *testing.T) {
+	handler := &txRelayApiHandler{receiptTimeout: 30 * time.Second}
+
+	assert.Equal(t, 30*time.Second, handler.receiptTimeoutOf(0), "configured timeout is used by default")
+	assert.Equal(t, 5*time.Second, handler.receiptTimeoutOf(5))
+	assert.Equal(t, 30*time.Second, handler.receiptTimeoutOf(600), "requested timeout is capped")
+}
+
+func TestIsKnownTransaction(t *testing.T) {
+	assert.True(t, isKnownTransaction(errors.New("already known")))
+	assert.True(t, isKnownTransaction(errors.New("known transaction: 0x5e2f")))
+	assert.True(t, isKnownTransaction(errors.New("AlreadyKnown")))
+	assert.False(t, isKnownTransaction(errors.New("nonce too low")))
+	assert.False(t, isKnownTransaction(nil))
+}
@@ -1,15 +1,252 @@
 // Package types includes helper type definitions
 package types
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	pb "github.com/1inch/p2p-network/proto/resolver"
+)
+
+// Version is the supported JSON-RPC protocol version
+const Version = "2.0"
+
+// Standard JSON-RPC 2.0 error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Server error codes of requests rejected by client access control
+const (
+	CodeAccessDenied  = -32001
+	CodeLimitExceeded = -32005
+)
+
+// CodePaymentRequired is server error code of requests without sufficient payment voucher
+const CodePaymentRequired = -32010
+
+var (
+	// ErrInvalidParams error represents params which can't be decoded into handler params
+	ErrInvalidParams = errors.New("invalid params")
+	// ErrWrongParamCount error represents count of positional params which differs from count of handler params
+	ErrWrongParamCount = errors.New("wrong number of params")
+	// ErrInvalidId error represents id which is not a string, number or null
+	ErrInvalidId = errors.New("id must be a string, number or null")
+)
+
+// Id is JSON-RPC request id, string, number or null. It's kept as raw JSON, so response has exactly the same id.
+// Nil Id means id is absent and the request is a notification.
+type Id []byte
+
+// StringId returns id of JSON string
+func StringId(id string) Id {
+	raw, _ := json.Marshal(id)
+	return raw
+}
+
+// NumberId returns id of JSON number
+func NumberId(id int64) Id {
+	return Id(strconv.FormatInt(id, 10))
+}
+
+// MarshalJSON writes id as is, absent id is written as null
+func (id Id) MarshalJSON() ([]byte, error) {
+	if id == nil {
+		return []byte("null"), nil
+	}
+	return id, nil
+}
+
+// UnmarshalJSON accepts string, number or null
+func (id *Id) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || (data[0] != '"' && data[0] != 'n' && data[0] != '-' && (data[0] < '0' || data[0] > '9')) {
+		return ErrInvalidId
+	}
+
+	*id = append((*id)[:0], data...)
+	return nil
+}
+
+// String returns id as it's written in JSON
+func (id Id) String() string {
+	return string(id)
+}
+
 // JsonRequest describes payload for JSON-RPC request
 type JsonRequest struct {
-	Id     string   `json:"id"`
-	Method string   `json:"method"`
-	Params []string `json:"params"` // TODO maybe should change this to interface{} to pass parameters with field names
+	// JsonRpc is "2.0" for JSON-RPC 2.0 requests, it's empty for legacy requests which get errors in resolver response
+	JsonRpc string `json:"jsonrpc,omitempty"`
+	Id      Id     `json:"id,omitempty"`
+	Method  string `json:"method"`
+	// Params are positional (array) or named (object) params, use UnmarshalParams or BindParams to decode them
+	Params json.RawMessage `json:"params,omitempty"`
+	// ChainId selects chain of handlers which serve several chains, it's optional if handler serves one chain
+	ChainId uint64 `json:"chainId,omitempty"`
+	// Timestamp is the unix time in milliseconds when the request was created, used for replay protection
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Nonce is a unique random value per request, used for replay protection
+	Nonce string `json:"nonce,omitempty"`
+	// ApiToken is pre-shared token of client, it's sent in encrypted requests to resolvers which limit access
+	ApiToken string `json:"apiToken,omitempty"`
+	// Voucher pays for the request to resolvers which charge for requests
+	Voucher *Voucher `json:"voucher,omitempty"`
+}
+
+// Voucher is EIP-712 signed cumulative amount which payer pays to resolver via payment channel contract
+type Voucher struct {
+	// Payer is address of account which signed the voucher
+	Payer string `json:"payer"`
+	// Amount is decimal total amount in wei of all vouchers of the payer to the resolver
+	Amount string `json:"amount"`
+	// Signature is hex signature in [R || S || V] format
+	Signature string `json:"signature"`
+}
+
+// IsNotification returns true for JSON-RPC 2.0 request without id, it doesn't get response
+func (r *JsonRequest) IsNotification() bool {
+	return r.JsonRpc != "" && r.Id == nil
+}
+
+// UnmarshalParams decodes params into v, positional params are decoded into slice or array, named params into struct or map
+func (r *JsonRequest) UnmarshalParams(v any) error {
+	if len(r.Params) == 0 {
+		return fmt.Errorf("%w: params are missing", ErrInvalidParams)
+	}
+
+	if err := json.Unmarshal(r.Params, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidParams, err)
+	}
+	return nil
+}
+
+// BindParams decodes positional params into values by index or named params into values by names,
+// count of params must be equal to count of values. Missing named params keep zero value.
+func (r *JsonRequest) BindParams(names []string, values ...any) error {
+	if len(names) != len(values) {
+		return fmt.Errorf("%w: %d names for %d values", ErrInvalidParams, len(names), len(values))
+	}
+
+	params := bytes.TrimSpace(r.Params)
+	if len(params) > 0 && params[0] == '{' {
+		var named map[string]json.RawMessage
+		if err := r.UnmarshalParams(&named); err != nil {
+			return err
+		}
+
+		for i, name := range names {
+			param, ok := named[name]
+			if !ok {
+				continue
+			}
+			if err := json.Unmarshal(param, values[i]); err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrInvalidParams, name, err)
+			}
+		}
+		return nil
+	}
+
+	var positional []json.RawMessage
+	if len(params) > 0 {
+		if err := r.UnmarshalParams(&positional); err != nil {
+			return err
+		}
+	}
+
+	if len(positional) != len(values) {
+		return ErrWrongParamCount
+	}
+
+	for i, param := range positional {
+		if err := json.Unmarshal(param, values[i]); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidParams, names[i], err)
+		}
+	}
+	return nil
+}
+
+// JsonError describes JSON-RPC 2.0 error object
+type JsonError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// Error implements error interface
+func (e *JsonError) Error() string {
+	return fmt.Sprintf("json-rpc error %d: %s", e.Code, e.Message)
+}
+
+// HandlerError is error of request processing with resolver error code. It's returned to client
+// as protobuf error of legacy request or as JSON-RPC 2.0 error object.
+type HandlerError struct {
+	Code    pb.ErrorCode
+	Message string
+	// Retryable is true if the same request may succeed later, e.g. after upstream failure or timeout
+	Retryable bool
+	// Details is additional context of the error, it must not contain secrets like upstream urls
+	Details map[string]string
+	// Err is cause of the error
+	Err error
+}
+
+// NewHandlerError returns handler error with message of err
+func NewHandlerError(code pb.ErrorCode, retryable bool, err error) *HandlerError {
+	return &HandlerError{Code: code, Message: err.Error(), Retryable: retryable, Err: err}
+}
+
+// WithDetail sets detail of the error and returns the error
+func (e *HandlerError) WithDetail(key, value string) *HandlerError {
+	if e.Details == nil {
+		e.Details = make(map[string]string)
+	}
+	e.Details[key] = value
+	return e
+}
+
+// Error implements error interface
+func (e *HandlerError) Error() string {
+	if e.Message == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns cause of the error
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorData is data of JSON-RPC 2.0 error object of retryable handler error or handler error with details
+type ErrorData struct {
+	Retryable bool              `json:"retryable"`
+	Details   map[string]string `json:"details,omitempty"`
 }
 
 // JsonResponse describes payload for JSON-RPC response
 type JsonResponse struct {
-	Id     string      `json:"id"`
-	Result interface{} `json:"result"`
+	JsonRpc string `json:"jsonrpc,omitempty"`
+	Id      Id     `json:"id"`
+	// Result is omitted when Error is set
+	Result interface{} `json:"result,omitempty"`
+	Error  *JsonError  `json:"error,omitempty"`
+}
+
+// Params returns positional params of values, it panics if values can't be marshaled, so it's for constant params only
+func Params(values ...any) json.RawMessage {
+	if values == nil {
+		values = []any{}
+	}
+	params, err := json.Marshal(values)
+	if err != nil {
+		panic(err)
+	}
+	return params
 }
@@ -0,0 +1,332 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	"github.com/ethereum/go-ethereum"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultUpstreamMaxFailures = 3
+	defaultUpstreamCooldown    = 30 * time.Second
+)
+
+var (
+	errNoUpstream          = errors.New("no upstream configured")
+	errEmptyUpstreamUrl    = errors.New("upstream url is empty")
+	errAllUpstreamsFailed  = errors.New("all upstreams failed")
+	errInvalidUpstreamYaml = errors.New("upstream must be url, mapping with url or list of them")
+)
+
+// UpstreamConfig describes upstream endpoint of api handler
+type UpstreamConfig struct {
+	Url string `yaml:"url"`
+	// Name of the upstream in logs and errors, host of url by default, so api key in url isn't logged
+	Name string `yaml:"name"`
+	// Weight is share of requests sent to the upstream among healthy upstreams, 1 by default
+	Weight uint `yaml:"weight"`
+	// Timeout of request to the upstream, handler timeout by default
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// UnmarshalYAML accepts url or mapping with url
+func (c *UpstreamConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		c.Url = value.Value
+		return nil
+	}
+
+	type plain UpstreamConfig
+	return value.Decode((*plain)(c))
+}
+
+// UpstreamList is list of upstreams of one chain or api
+type UpstreamList []UpstreamConfig
+
+// UnmarshalYAML accepts one upstream or list of upstreams
+func (l *UpstreamList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode, yaml.MappingNode:
+		var upstream UpstreamConfig
+		if err := value.Decode(&upstream); err != nil {
+			return err
+		}
+		*l = UpstreamList{upstream}
+		return nil
+	case yaml.SequenceNode:
+		var upstreams []UpstreamConfig
+		if err := value.Decode(&upstreams); err != nil {
+			return err
+		}
+		*l = upstreams
+		return nil
+	default:
+		return errInvalidUpstreamYaml
+	}
+}
+
+// FailoverConfig describes health tracking of upstreams
+type FailoverConfig struct {
+	// MaxFailures is count of consecutive failures after which upstream is unhealthy, 3 by default
+	MaxFailures uint `yaml:"max_failures"`
+	// Cooldown is time after which unhealthy upstream gets requests again, 30s by default
+	Cooldown time.Duration `yaml:"cooldown"`
+}
+
+type upstream[T any] struct {
+	url     string
+	name    string
+	client  T
+	weight  uint
+	timeout time.Duration
+
+	// failures is count of consecutive failures
+	failures uint
+	// unhealthyUntil is zero for healthy upstream
+	unhealthyUntil time.Time
+}
+
+// upstreamPool selects upstreams by weight among healthy ones and fails over to next upstream when request fails.
+// Upstream is unhealthy after MaxFailures consecutive failures, it gets requests again after Cooldown
+// and it's healthy after the first successful request. Unhealthy upstreams are tried when no upstream is healthy.
+type upstreamPool[T any] struct {
+	mu          sync.Mutex
+	upstreams   []*upstream[T]
+	maxFailures uint
+	cooldown    time.Duration
+	logger      *slog.Logger
+
+	now  func() time.Time
+	intn func(n int) int
+}
+
+// newUpstreamPool creates clients of upstreams with dial, dial errors are returned, so handler isn't created without upstream
+func newUpstreamPool[T any](configs []UpstreamConfig, defaultTimeout time.Duration, failover FailoverConfig, dial func(url string) (T, error), logger *slog.Logger) (*upstreamPool[T], error) {
+	if len(configs) == 0 {
+		return nil, errNoUpstream
+	}
+
+	pool := &upstreamPool[T]{
+		maxFailures: failover.MaxFailures,
+		cooldown:    failover.Cooldown,
+		logger:      logger,
+		now:         time.Now,
+		intn:        rand.IntN,
+	}
+	if pool.maxFailures == 0 {
+		pool.maxFailures = defaultUpstreamMaxFailures
+	}
+	if pool.cooldown == 0 {
+		pool.cooldown = defaultUpstreamCooldown
+	}
+
+	for _, cfg := range configs {
+		if cfg.Url == "" {
+			return nil, errEmptyUpstreamUrl
+		}
+
+		name := upstreamName(cfg)
+		client, err := dial(cfg.Url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create upstream client %s: %w", name, redact(err, cfg.Url, name))
+		}
+
+		u := &upstream[T]{url: cfg.Url, name: name, client: client, weight: cfg.Weight, timeout: cfg.Timeout}
+		if u.weight == 0 {
+			u.weight = 1
+		}
+		if u.timeout == 0 {
+			u.timeout = defaultTimeout
+		}
+		pool.upstreams = append(pool.upstreams, u)
+	}
+
+	return pool, nil
+}
+
+// call calls upstreams in order of selection until call succeeds or fails with error of upstream response.
+// Retryable handler error with error of the last upstream is returned when all upstreams fail.
+func (p *upstreamPool[T]) call(fn func(ctx context.Context, client T) error) error {
+	var err error
+	for _, u := range p.order() {
+		ctx, cancel := context.WithTimeout(context.Background(), u.timeout)
+		err = fn(ctx, u.client)
+		cancel()
+
+		if err == nil || !isUpstreamFailure(err) {
+			p.succeeded(u)
+			return err
+		}
+
+		err = redact(err, u.url, u.name)
+		p.failed(u, err)
+	}
+
+	return upstreamFailure(fmt.Errorf("%w: %w", errAllUpstreamsFailed, err))
+}
+
+// order returns healthy upstreams shuffled by weight, then unhealthy upstreams which are tried as last resort
+func (p *upstreamPool[T]) order() []*upstream[T] {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var healthy, unhealthy []*upstream[T]
+	for _, u := range p.upstreams {
+		if u.unhealthyUntil.IsZero() || !now.Before(u.unhealthyUntil) {
+			healthy = append(healthy, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+
+	ordered := make([]*upstream[T], 0, len(p.upstreams))
+	for len(healthy) > 0 {
+		var total uint
+		for _, u := range healthy {
+			total += u.weight
+		}
+
+		pick := uint(p.intn(int(total)))
+		for i, u := range healthy {
+			if pick < u.weight {
+				ordered = append(ordered, u)
+				healthy = slices.Delete(healthy, i, i+1)
+				break
+			}
+			pick -= u.weight
+		}
+	}
+
+	slices.SortFunc(unhealthy, func(a, b *upstream[T]) int {
+		return a.unhealthyUntil.Compare(b.unhealthyUntil)
+	})
+	return append(ordered, unhealthy...)
+}
+
+func (p *upstreamPool[T]) succeeded(u *upstream[T]) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !u.unhealthyUntil.IsZero() {
+		p.logger.Info("upstream is healthy", slog.String("upstream", u.name))
+	}
+	u.failures = 0
+	u.unhealthyUntil = time.Time{}
+}
+
+func (p *upstreamPool[T]) failed(u *upstream[T], err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	u.failures++
+	p.logger.Warn("upstream request failed", slog.String("upstream", u.name), slog.Uint64("failures", uint64(u.failures)), slog.Any("err", err))
+	if u.failures >= p.maxFailures {
+		u.unhealthyUntil = p.now().Add(p.cooldown)
+		p.logger.Warn("upstream is unhealthy", slog.String("upstream", u.name), slog.Any("until", u.unhealthyUntil))
+	}
+}
+
+// upstreamName returns configured name of upstream or host of its url
+func upstreamName(cfg UpstreamConfig) string {
+	if cfg.Name != "" {
+		return cfg.Name
+	}
+	if parsed, err := url.Parse(cfg.Url); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return "upstream"
+}
+
+// redactedError is error of upstream request with upstream url replaced by upstream name,
+// it wraps original error, so it's classified as original error
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redact replaces upstream url in err with name, errors of http clients contain requested url with api key
+func redact(err error, upstreamUrl, name string) error {
+	if err == nil || !strings.Contains(err.Error(), upstreamUrl) {
+		return err
+	}
+	return &redactedError{message: strings.ReplaceAll(err.Error(), upstreamUrl, name), err: err}
+}
+
+// upstreamStatusError is error of upstream which responded with error HTTP status, StatusCode is 0 when
+// upstream client doesn't return status of response
+type upstreamStatusError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *upstreamStatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *upstreamStatusError) Unwrap() error {
+	return e.Err
+}
+
+// upstreamStatus returns HTTP status of upstream response of err, ok is false if upstream didn't respond
+func upstreamStatus(err error) (status int, ok bool) {
+	var httpErr gethrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode, true
+	}
+
+	var statusErr *upstreamStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode, true
+	}
+
+	return 0, false
+}
+
+// isRejectedStatus returns true if upstream rejected request with status, it's caused by request or upstream
+// configuration, so another attempt doesn't help. Server errors and 429 are upstream failures.
+func isRejectedStatus(status int) bool {
+	return status < http.StatusInternalServerError && status != http.StatusTooManyRequests
+}
+
+// isUpstreamFailure returns false for JSON-RPC errors, empty results and rejected requests of upstream response,
+// they are returned to client without failover
+func isUpstreamFailure(err error) bool {
+	var rpcErr gethrpc.Error
+	var jsonErr *types.JsonError
+	if errors.As(err, &rpcErr) || errors.As(err, &jsonErr) || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+
+	status, ok := upstreamStatus(err)
+	return !ok || !isRejectedStatus(status)
+}
+
+// upstreamsOf returns configured upstreams or default upstream
+func upstreamsOf(upstreams UpstreamList, defaultUrl string) UpstreamList {
+	if len(upstreams) > 0 {
+		return upstreams
+	}
+	return UpstreamList{{Url: defaultUrl}}
+}
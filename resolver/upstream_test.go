@@ -0,0 +1,261 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/1inch/p2p-network/resolver/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var errUpstreamDown = errors.New("upstream is down")
+
+func newTestUpstreamPool(t *testing.T, configs []UpstreamConfig, failover FailoverConfig) *upstreamPool[string] {
+	pool, err := newUpstreamPool(configs, time.Second, failover, func(url string) (string, error) {
+		return url, nil
+	}, slog.New(slog.NewTextHandler(os.Stdout, nil)))
+	require.NoError(t, err)
+	return pool
+}
+
+// callOrder returns upstreams called by one call of pool, down upstreams fail
+func callOrder(pool *upstreamPool[string], down ...string) ([]string, error) {
+	var called []string
+	err := pool.call(func(ctx context.Context, url string) error {
+		called = append(called, url)
+		for _, d := range down {
+			if d == url {
+				return errUpstreamDown
+			}
+		}
+		return nil
+	})
+	return called, err
+}
+
+func TestUpstreamPoolWeightedOrder(t *testing.T) {
+	pool := newTestUpstreamPool(t, []UpstreamConfig{{Url: "a", Weight: 1}, {Url: "b", Weight: 3}}, FailoverConfig{})
+
+	testCases := []struct {
+		name  string
+		pick  int
+		order []string
+	}{
+		{name: "Pick in weight of first upstream", pick: 0, order: []string{"a", "b"}},
+		{name: "Pick in weight of second upstream", pick: 1, order: []string{"b", "a"}},
+		{name: "Pick at end of weights", pick: 3, order: []string{"b", "a"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pool.intn = func(n int) int { return min(testCase.pick, n-1) }
+
+			called, err := callOrder(pool, "a", "b")
+			assert.ErrorIs(t, err, errAllUpstreamsFailed)
+			assert.ErrorIs(t, err, errUpstreamDown, "error of last upstream is returned")
+			assert.Equal(t, testCase.order, called)
+		})
+	}
+}
+
+func TestUpstreamPoolFailover(t *testing.T) {
+	now := time.Now()
+	pool := newTestUpstreamPool(t, []UpstreamConfig{{Url: "a"}, {Url: "b"}}, FailoverConfig{MaxFailures: 2, Cooldown: time.Minute})
+	pool.now = func() time.Time { return now }
+	pool.intn = func(n int) int { return 0 }
+
+	called, err := callOrder(pool, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, called, "request fails over to next upstream")
+
+	called, err = callOrder(pool, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, called, "upstream is healthy before max failures")
+
+	called, err = callOrder(pool)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, called, "unhealthy upstream is skipped")
+
+	called, err = callOrder(pool, "b")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, called, "unhealthy upstream is tried as last resort")
+
+	called, err = callOrder(pool)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, called, "upstream is healthy after successful request")
+}
+
+func TestUpstreamPoolCooldown(t *testing.T) {
+	now := time.Now()
+	pool := newTestUpstreamPool(t, []UpstreamConfig{{Url: "a"}, {Url: "b"}}, FailoverConfig{MaxFailures: 1, Cooldown: time.Minute})
+	pool.now = func() time.Time { return now }
+	pool.intn = func(n int) int { return 0 }
+
+	_, err := callOrder(pool, "a")
+	require.NoError(t, err)
+
+	called, err := callOrder(pool)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, called)
+
+	now = now.Add(time.Minute)
+	called, err = callOrder(pool)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, called, "upstream gets requests after cooldown")
+}
+
+func TestUpstreamPoolResponseError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{name: "JSON-RPC error", err: &types.JsonError{Code: 3, Message: "execution reverted"}},
+		{name: "Bad request", err: gethrpc.HTTPError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}},
+		{name: "Unauthorized", err: gethrpc.HTTPError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}},
+		{name: "Rejected request without status", err: &upstreamStatusError{Err: errors.New("invalid api key")}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pool := newTestUpstreamPool(t, []UpstreamConfig{{Url: "a"}, {Url: "b"}}, FailoverConfig{MaxFailures: 1})
+			pool.intn = func(n int) int { return 0 }
+
+			var called []string
+			err := pool.call(func(ctx context.Context, url string) error {
+				called = append(called, url)
+				return testCase.err
+			})
+			assert.Equal(t, testCase.err, err)
+			assert.Equal(t, []string{"a"}, called, "error of upstream response isn't failed over")
+
+			called, err = callOrder(pool)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a"}, called, "error of upstream response doesn't make upstream unhealthy")
+		})
+	}
+}
+
+func TestUpstreamPoolServerError(t *testing.T) {
+	pool := newTestUpstreamPool(t, []UpstreamConfig{{Url: "a"}, {Url: "b"}}, FailoverConfig{})
+	pool.intn = func(n int) int { return 0 }
+
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway} {
+		var called []string
+		err := pool.call(func(ctx context.Context, url string) error {
+			called = append(called, url)
+			if url == "a" {
+				return gethrpc.HTTPError{StatusCode: status}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, called, "request fails over after %d status", status)
+	}
+}
+
+func TestUpstreamPoolTimeout(t *testing.T) {
+	pool := newTestUpstreamPool(t, []UpstreamConfig{{Url: "a", Timeout: time.Millisecond}, {Url: "b"}}, FailoverConfig{})
+	pool.intn = func(n int) int { return 0 }
+
+	var called []string
+	err := pool.call(func(ctx context.Context, url string) error {
+		called = append(called, url)
+		if url == "a" {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, called, "request fails over after upstream timeout")
+}
+
+func TestNewUpstreamPoolErrors(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	dial := func(url string) (string, error) {
+		return "", errUpstreamDown
+	}
+
+	_, err := newUpstreamPool(nil, time.Second, FailoverConfig{}, dial, logger)
+	assert.ErrorIs(t, err, errNoUpstream)
+
+	_, err = newUpstreamPool([]UpstreamConfig{{Weight: 1}}, time.Second, FailoverConfig{}, dial, logger)
+	assert.ErrorIs(t, err, errEmptyUpstreamUrl)
+
+	_, err = newUpstreamPool([]UpstreamConfig{{Url: "a"}}, time.Second, FailoverConfig{}, dial, logger)
+	assert.ErrorIs(t, err, errUpstreamDown, "dial error is returned")
+
+	_, err = NewEvmApiHandler(EvmApiConfig{Upstreams: map[uint64]UpstreamList{1: {{Url: "ftp://localhost"}}}}, logger)
+	assert.Error(t, err, "handler isn't created with invalid upstream")
+}
+
+func TestUpstreamPoolRedactsUrl(t *testing.T) {
+	upstreamUrl := "https://mainnet.example.org/v3/secret-api-key"
+	var logs bytes.Buffer
+	pool, err := newUpstreamPool([]UpstreamConfig{{Url: upstreamUrl}, {Url: upstreamUrl, Name: "backup"}}, time.Second, FailoverConfig{MaxFailures: 1}, func(url string) (string, error) {
+		return url, nil
+	}, slog.New(slog.NewTextHandler(&logs, nil)))
+	require.NoError(t, err)
+
+	requestErr := fmt.Errorf("Post %q: %w", upstreamUrl, errUpstreamDown)
+	err = pool.call(func(ctx context.Context, url string) error {
+		return requestErr
+	})
+	assert.ErrorIs(t, err, errUpstreamDown)
+	assert.ErrorIs(t, err, requestErr, "redacted error wraps error of request")
+	assert.NotContains(t, err.Error(), "secret-api-key")
+	assert.NotContains(t, logs.String(), "secret-api-key")
+	assert.Contains(t, logs.String(), "upstream=mainnet.example.org", "host of url is logged by default")
+	assert.Contains(t, logs.String(), "upstream=backup", "configured name is logged")
+
+	_, err = newUpstreamPool([]UpstreamConfig{{Url: upstreamUrl}}, time.Second, FailoverConfig{}, func(url string) (string, error) {
+		return "", fmt.Errorf("dial %s: %w", url, errUpstreamDown)
+	}, slog.New(slog.NewTextHandler(&logs, nil)))
+	assert.ErrorIs(t, err, errUpstreamDown)
+	assert.NotContains(t, err.Error(), "secret-api-key", "dial error doesn't contain url")
+}
+
+func TestUpstreamListYaml(t *testing.T) {
+	testCases := []struct {
+		name      string
+		yaml      string
+		upstreams UpstreamList
+	}{
+		{
+			name:      "Url",
+			yaml:      `http://localhost:8545`,
+			upstreams: UpstreamList{{Url: "http://localhost:8545"}},
+		},
+		{
+			name:      "Mapping",
+			yaml:      `{url: http://localhost:8545, weight: 2, timeout: 3s}`,
+			upstreams: UpstreamList{{Url: "http://localhost:8545", Weight: 2, Timeout: 3 * time.Second}},
+		},
+		{
+			name: "List",
+			yaml: "- http://localhost:8545\n- url: http://localhost:8546\n  weight: 3\n",
+			upstreams: UpstreamList{
+				{Url: "http://localhost:8545"},
+				{Url: "http://localhost:8546", Weight: 3},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var upstreams UpstreamList
+			require.NoError(t, yaml.Unmarshal([]byte(testCase.yaml), &upstreams))
+			assert.Equal(t, testCase.upstreams, upstreams)
+		})
+	}
+}
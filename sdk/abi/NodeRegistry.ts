@@ -41,7 +41,22 @@ export const registryAbi = [
             {
                 internalType: "string",
                 name: "ip",
-                yype: "string"
+                type: "string"
+            },
+            {
+                internalType: "string",
+                name: "region",
+                type: "string"
+            },
+            {
+                internalType: "uint32",
+                name: "capacity",
+                type: "uint32"
+            },
+            {
+                internalType: "bytes",
+                name: "publicKey",
+                type: "bytes"
             }
         ],
         name: "registerRelayer",
@@ -60,11 +75,38 @@ export const registryAbi = [
                 internalType: "bytes",
                 name: "publicKey",
                 type: "bytes"
+            },
+            {
+                components: [
+                    {
+                        internalType: "string[]",
+                        name: "methods",
+                        type: "string[]"
+                    },
+                    {
+                        internalType: "uint256[]",
+                        name: "chainIds",
+                        type: "uint256[]"
+                    },
+                    {
+                        internalType: "uint32",
+                        name: "protocolVersion",
+                        type: "uint32"
+                    },
+                    {
+                        internalType: "bool",
+                        name: "encrypted",
+                        type: "bool"
+                    }
+                ],
+                internalType: "struct NodeRegistry.Capabilities",
+                name: "resolverCapabilities",
+                type: "tuple"
             }
         ],
         name: "registerResolver",
         outputs: [],
-        stateMutability: "nonpayable",
+        stateMutability: "payable",
         type: "function"
     }
 ];
@@ -120,6 +120,7 @@ export class Client {
 
   async execute(req: JsonRequest, shouldEncrypt: boolean = true): Promise<JsonResponse> {
     this.logger.info("Executing request");
+    req = { ...req, Timestamp: req.Timestamp ?? Date.now(), Nonce: req.Nonce ?? generateNonce() };
     const resolverPubKey = this.networkParams?.resolverPubKey || "";
     let payloadBytes: Uint8Array;
     if (shouldEncrypt) {
@@ -358,5 +359,10 @@ function parseProtocol(protocolStr: string) {
   }
 }
 
+function generateNonce() {
+  const bytes = crypto.getRandomValues(new Uint8Array(16));
+  return Array.from(bytes, (b) => b.toString(16).padStart(2, "0")).join("");
+}
+
 const defaultStunServers = ['stun:stun.l.google.com:19302', 'stun:stun.services.mozilla.com'];
 const defaultChannelName = 'default';
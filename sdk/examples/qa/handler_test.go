@@ -29,19 +29,25 @@ func (h *defaultApiHandler) Process(req *types.JsonRequest) (*types.JsonResponse
 	if req.Method != "GetWalletBalance" {
 		return nil, errUnrecognizedMethod
 	}
-	if len(req.Params) != 2 {
+	var params []string
+	if len(req.Params) > 0 {
+		if err := req.UnmarshalParams(&params); err != nil {
+			return nil, err
+		}
+	}
+	if len(params) != 2 {
 		return nil, errWrongParamCount
 	}
-	if req.Params[0] == "" {
+	if params[0] == "" {
 		return nil, errEmptyChainId
 	}
-	if req.Params[1] == "" {
+	if params[1] == "" {
 		return nil, errEmptyAddress
 	}
-	if strings.HasPrefix(req.Params[0], "0x") {
+	if strings.HasPrefix(params[0], "0x") {
 		return nil, errInvalidParamOrder
 	}
-	if !isNumeric(req.Params[0]) {
+	if !isNumeric(params[0]) {
 		return nil, errChainIdNotNumeric
 	}
 
@@ -82,7 +88,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Incorrect params order",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0x123", "1"},
+				Params: types.Params("0x123", "1"),
 			},
 			expectedResult: 0,
 			expectedError:  errInvalidParamOrder,
@@ -91,7 +97,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Unrecognized method name",
 			request: &types.JsonRequest{
 				Method: "UnknownMethod",
-				Params: []string{"1", "0x123"},
+				Params: types.Params("1", "0x123"),
 			},
 			expectedResult: 0,
 			expectedError:  errUnrecognizedMethod,
@@ -100,7 +106,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Valid params",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"1", "0x123"},
+				Params: types.Params("1", "0x123"),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
@@ -109,7 +115,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Wrong number of params - too few",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0x123"},
+				Params: types.Params("0x123"),
 			},
 			expectedResult: 0,
 			expectedError:  errWrongParamCount,
@@ -118,7 +124,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Wrong number of params - too many",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0", "0x123", "extra"},
+				Params: types.Params("0", "0x123", "extra"),
 			},
 			expectedResult: 0,
 			expectedError:  errWrongParamCount,
@@ -127,7 +133,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Empty params",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{},
+				Params: types.Params(),
 			},
 			expectedResult: 0,
 			expectedError:  errWrongParamCount,
@@ -145,7 +151,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Valid params with different address",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0", "0xabc"},
+				Params: types.Params("0", "0xabc"),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
@@ -154,7 +160,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Valid params with different block",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"1", "0x123"},
+				Params: types.Params("1", "0x123"),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
@@ -163,7 +169,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Valid params with numeric block",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"123", "0x123"},
+				Params: types.Params("123", "0x123"),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
@@ -172,7 +178,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Valid params with zero address",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0", "0x0000000000000000000000000000000000000000"},
+				Params: types.Params("0", "0x0000000000000000000000000000000000000000"),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
@@ -181,7 +187,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Count params less than needed",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0x123"},
+				Params: types.Params("0x123"),
 			},
 			expectedResult: 0,
 			expectedError:  errWrongParamCount,
@@ -190,7 +196,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Address param is empty",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"1", ""},
+				Params: types.Params("1", ""),
 			},
 			expectedResult: 0,
 			expectedError:  errEmptyAddress,
@@ -199,7 +205,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "ChainId param is empty",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"", "0x123"},
+				Params: types.Params("", "0x123"),
 			},
 			expectedResult: 0,
 			expectedError:  errEmptyChainId,
@@ -208,7 +214,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "ChainId is not numeric",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"not-numeric", "0x123"},
+				Params: types.Params("not-numeric", "0x123"),
 			},
 			expectedResult: 0,
 			expectedError:  errChainIdNotNumeric,
@@ -217,7 +223,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Very long address",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0", "0x" + strings.Repeat("1", 100)},
+				Params: types.Params("0", "0x"+strings.Repeat("1", 100)),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
@@ -226,7 +232,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Address without 0x prefix",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0", "123abc"},
+				Params: types.Params("0", "123abc"),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
@@ -235,7 +241,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Very large block number",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"999999999999999", "0x123"},
+				Params: types.Params("999999999999999", "0x123"),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
@@ -244,7 +250,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Non-numeric characters in block number",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"12a34", "0x123"},
+				Params: types.Params("12a34", "0x123"),
 			},
 			expectedResult: 0,
 			expectedError:  errChainIdNotNumeric,
@@ -253,7 +259,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Whitespace in parameters",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{" 0 ", " 0x123 "},
+				Params: types.Params(" 0 ", " 0x123 "),
 			},
 			expectedResult: 555,
 			expectedError:  errChainIdNotNumeric,
@@ -262,7 +268,7 @@ func TestDefaultApiHandler_GetWalletBalance(t *testing.T) {
 			name: "Case sensitivity test",
 			request: &types.JsonRequest{
 				Method: "GetWalletBalance",
-				Params: []string{"0", "0xAbC123"},
+				Params: types.Params("0", "0xAbC123"),
 			},
 			expectedResult: 555,
 			expectedError:  nil,
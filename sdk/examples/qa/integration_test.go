@@ -65,9 +65,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedOneInchHandler",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"1", "0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324"},
+				Params: types.Params("1", "0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -79,9 +79,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "GetWalletBalanceForVitalikButerinAddress",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"1", "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"}, // Vitalik Buterin's address
+				Params: types.Params("1", "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"), // Vitalik Buterin's address
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -118,9 +118,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "GetWalletBalanceForUniswapV3Factory",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"1", "0x1F98431c8aD98523631AE4a59f267346ea31F984"}, // Uniswap V3 Factory
+				Params: types.Params("1", "0x1F98431c8aD98523631AE4a59f267346ea31F984"), // Uniswap V3 Factory
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -156,9 +156,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedDefaultHandler",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.Equal(t, 555., result)
@@ -170,9 +170,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedDefaultHandler_ZeroAddress",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0000000000000000000000000000000000000000", "latest"},
+				Params: types.Params("0x0000000000000000000000000000000000000000", "latest"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.Equal(t, 555., result)
@@ -184,9 +184,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedDefaultHandler_EarliestBlock",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "earliest"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "earliest"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.Equal(t, 555., result)
@@ -198,9 +198,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedDefaultHandler_PendingBlock",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "pending"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "pending"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.Equal(t, 555., result)
@@ -212,9 +212,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedDefaultHandler_SpecificBlockNumber",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "1000000"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "1000000"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.Equal(t, 555., result)
@@ -226,9 +226,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedDefaultHandler_InvalidAddress",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0xInvalidAddress", "latest"},
+				Params: types.Params("0xInvalidAddress", "latest"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.Equal(t, 555., result)
@@ -239,9 +239,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedInfuraHandler",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -253,9 +253,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "GetWalletBalanceForVitalikButerinAddress",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045", "latest"}, // Vitalik Buterin's address
+				Params: types.Params("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045", "latest"), // Vitalik Buterin's address
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -271,9 +271,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "GetWalletBalanceForUniswapV3Factory",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x1F98431c8aD98523631AE4a59f267346ea31F984", "latest"}, // Uniswap V3 Factory
+				Params: types.Params("0x1F98431c8aD98523631AE4a59f267346ea31F984", "latest"), // Uniswap V3 Factory
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -289,9 +289,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "GetWalletBalanceForEthereumFoundation",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe", "latest"}, // Ethereum Foundation
+				Params: types.Params("0xde0B295669a9FD93d5F28D9Ec85E40f4cb697BAe", "latest"), // Ethereum Foundation
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -307,9 +307,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "GetWalletBalanceWithPendingBlock",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "pending"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "pending"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -325,9 +325,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "GetWalletBalanceWithEarliestBlock",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "earliest"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "earliest"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -343,9 +343,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "GetWalletBalanceWithSpecificBlockNumber",
 			SessionId: generateSessionID(),
 			JsonRequest: &types.JsonRequest{
-				Id:     generateRequestID(),
+				Id:     types.StringId(generateRequestID()),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "0xE10000"}, // Block 14,680,064
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "0xE10000"), // Block 14,680,064
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -434,7 +434,7 @@ func testWorkFlowAndReturnResponseChan(t *testing.T, logger *slog.Logger, cfg *r
 
 	req := &pbrelayer.IncomingMessage{
 		Request: &pbresolver.ResolverRequest{
-			Id:      jsonReq.Id,
+			Id:      jsonReq.Id.String(),
 			Payload: payload,
 		},
 		PublicKeys: [][]byte{
@@ -581,9 +581,9 @@ func TestSuccess(t *testing.T) {
 		resolverPrivKey := tn.ResolverPrivateKeys[0]
 		relayerAddress := tn.RelayerNodes[0].HTTPServer.Addr()
 		jsonReq := &types.JsonRequest{
-			Id:     "request-id-1",
+			Id:     types.StringId("request-id-1"),
 			Method: "GetWalletBalance",
-			Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"},
+			Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
 		}
 
 		peerConnection, err := webrtc.NewPeerConnection(webrtc.Configuration{})
@@ -624,7 +624,7 @@ func TestSuccess(t *testing.T) {
 
 		msg := &pbrelayer.IncomingMessage{
 			Request: &pbresolver.ResolverRequest{
-				Id:      jsonReq.Id,
+				Id:      jsonReq.Id.String(),
 				Payload: payload,
 			},
 			PublicKeys: [][]byte{
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file relayer.proto.
  */
 export const file_relayer: GenFile = /*@__PURE__*/
-  fileDesc("Cg1yZWxheWVyLnByb3RvEgdyZWxheWVyIjoKBUVycm9yEiAKBGNvZGUYASABKA4yEi5yZWxheWVyLkVycm9yQ29kZRIPCgdtZXNzYWdlGAIgASgJIlEKD0luY29taW5nTWVzc2FnZRISCgpwdWJsaWNLZXlzGAEgAygMEioKB3JlcXVlc3QYAiABKAsyGS5yZXNvbHZlci5SZXNvbHZlclJlcXVlc3QifwoPT3V0Z29pbmdNZXNzYWdlEi4KCHJlc3BvbnNlGAEgASgLMhoucmVzb2x2ZXIuUmVzb2x2ZXJSZXNwb25zZUgAEh8KBWVycm9yGAIgASgLMg4ucmVsYXllci5FcnJvckgAEhEKCXB1YmxpY0tleRgDIAEoDEIICgZyZXN1bHQqswEKCUVycm9yQ29kZRIeChpFUlJfSU5WQUxJRF9NRVNTQUdFX0ZPUk1BVBAAEh4KGkVSUl9SRVNPTFZFUl9MT09LVVBfRkFJTEVEEAESHQoZRVJSX0dSUENfRVhFQ1VUSU9OX0ZBSUxFRBACEiUKIUVSUl9SRVNQT05TRV9TRVJJQUxJWkFUSU9OX0ZBSUxFRBADEiAKHEVSUl9EQVRBX0NIQU5ORUxfU0VORF9GQUlMRUQQBEIsWipnaXRodWIuY29tLzFpbmNoL3AycC1uZXR3b3JrL3Byb3RvL3JlbGF5ZXJiBnByb3RvMw", [file_resolver]);
+  fileDesc("Cg1yZWxheWVyLnByb3RvEgdyZWxheWVyIjoKBUVycm9yEiAKBGNvZGUYASABKA4yEi5yZWxheWVyLkVycm9yQ29kZRIPCgdtZXNzYWdlGAIgASgJIlEKD0luY29taW5nTWVzc2FnZRISCgpwdWJsaWNLZXlzGAEgAygMEioKB3JlcXVlc3QYAiABKAsyGS5yZXNvbHZlci5SZXNvbHZlclJlcXVlc3QifwoPT3V0Z29pbmdNZXNzYWdlEi4KCHJlc3BvbnNlGAEgASgLMhoucmVzb2x2ZXIuUmVzb2x2ZXJSZXNwb25zZUgAEh8KBWVycm9yGAIgASgLMg4ucmVsYXllci5FcnJvckgAEhEKCXB1YmxpY0tleRgDIAEoDEIICgZyZXN1bHQq0AEKCUVycm9yQ29kZRIeChpFUlJfSU5WQUxJRF9NRVNTQUdFX0ZPUk1BVBAAEh4KGkVSUl9SRVNPTFZFUl9MT09LVVBfRkFJTEVEEAESHQoZRVJSX0dSUENfRVhFQ1VUSU9OX0ZBSUxFRBACEiUKIUVSUl9SRVNQT05TRV9TRVJJQUxJWkFUSU9OX0ZBSUxFRBADEiAKHEVSUl9EQVRBX0NIQU5ORUxfU0VORF9GQUlMRUQQBBIbChdFUlJfTk9fQ0FQQUJMRV9SRVNPTFZFUhAFQixaKmdpdGh1Yi5jb20vMWluY2gvcDJwLW5ldHdvcmsvcHJvdG8vcmVsYXllcmIGcHJvdG8z", [file_resolver]);
 
 /**
  * Represents a standard error structure.
@@ -138,6 +138,13 @@ export enum ErrorCode {
    * @generated from enum value: ERR_DATA_CHANNEL_SEND_FAILED = 4;
    */
   ERR_DATA_CHANNEL_SEND_FAILED = 4,
+
+  /**
+   * No resolver can serve the request.
+   *
+   * @generated from enum value: ERR_NO_CAPABLE_RESOLVER = 5;
+   */
+  ERR_NO_CAPABLE_RESOLVER = 5,
 }
 
 /**
@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file resolver.proto.
  */
 export const file_resolver: GenFile = /*@__PURE__*/
-  fileDesc("Cg5yZXNvbHZlci5wcm90bxIIcmVzb2x2ZXIiOwoFRXJyb3ISIQoEY29kZRgBIAEoDjITLnJlc29sdmVyLkVycm9yQ29kZRIPCgdtZXNzYWdlGAIgASgJIlQKD1Jlc29sdmVyUmVxdWVzdBIKCgJpZBgBIAEoCRIRCgllbmNyeXB0ZWQYAiABKAgSDwoHcGF5bG9hZBgDIAEoDBIRCglwdWJsaWNLZXkYBCABKAwicAoQUmVzb2x2ZXJSZXNwb25zZRIKCgJpZBgBIAEoCRIRCgllbmNyeXB0ZWQYAiABKAgSEQoHcGF5bG9hZBgDIAEoDEgAEiAKBWVycm9yGAQgASgLMg8ucmVzb2x2ZXIuRXJyb3JIAEIICgZyZXN1bHQqbgoJRXJyb3JDb2RlEhoKFkVSUl9JTlRFUk5BTF9FWENFUFRJT04QABIeChpFUlJfSU5WQUxJRF9NRVNTQUdFX0ZPUk1BVBABEiUKIUVSUl9SRVNQT05TRV9TRVJJQUxJWkFUSU9OX0ZBSUxFRBACMksKB0V4ZWN1dGUSQAoHRXhlY3V0ZRIZLnJlc29sdmVyLlJlc29sdmVyUmVxdWVzdBoaLnJlc29sdmVyLlJlc29sdmVyUmVzcG9uc2VCLVorZ2l0aHViLmNvbS8xaW5jaC9wMnAtbmV0d29yay9wcm90by9yZXNvbHZlcmIGcHJvdG8z");
+  fileDesc("Cg5yZXNvbHZlci5wcm90bxIIcmVzb2x2ZXIirQEKBUVycm9yEiEKBGNvZGUYASABKA4yEy5yZXNvbHZlci5FcnJvckNvZGUSDwoHbWVzc2FnZRgCIAEoCRIRCglyZXRyeWFibGUYAyABKAgSLQoHZGV0YWlscxgEIAMoCzIcLnJlc29sdmVyLkVycm9yLkRldGFpbHNFbnRyeRouCgxEZXRhaWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJUCg9SZXNvbHZlclJlcXVlc3QSCgoCaWQYASABKAkSEQoJZW5jcnlwdGVkGAIgASgIEg8KB3BheWxvYWQYAyABKAwSEQoJcHVibGljS2V5GAQgASgMInAKEFJlc29sdmVyUmVzcG9uc2USCgoCaWQYASABKAkSEQoJZW5jcnlwdGVkGAIgASgIEhEKB3BheWxvYWQYAyABKAxIABIgCgVlcnJvchgEIAEoCzIPLnJlc29sdmVyLkVycm9ySABCCAoGcmVzdWx0IiUKEEhlYXJ0YmVhdFJlcXVlc3QSEQoJY2hhbGxlbmdlGAEgASgMIkwKEUhlYXJ0YmVhdFJlc3BvbnNlEhEKCXB1YmxpY0tleRgBIAEoDBIRCgl0aW1lc3RhbXAYAiABKAMSEQoJc2lnbmF0dXJlGAMgASgMIhQKEkxpc3RNZXRob2RzUmVxdWVzdCJeCgpNZXRob2RJbmZvEgwKBG5hbWUYASABKAkSDwoHaGFuZGxlchgCIAEoCRIOCgZtZXRob2QYAyABKAkSDwoHZGVmYXVsdBgEIAEoCBIQCghjaGFpbklkcxgFIAMoBCI8ChNMaXN0TWV0aG9kc1Jlc3BvbnNlEiUKB21ldGhvZHMYASADKAsyFC5yZXNvbHZlci5NZXRob2RJbmZvKv8CCglFcnJvckNvZGUSHgoaRVJSX0lOVkFMSURfTUVTU0FHRV9GT1JNQVQQABIaChZFUlJfSU5URVJOQUxfRVhDRVBUSU9OEAESJQohRVJSX1JFU1BPTlNFX1NFUklBTElaQVRJT05fRkFJTEVEEAISGAoURVJSX1JFUExBWUVEX1JFUVVFU1QQAxIVChFFUlJfQUNDRVNTX0RFTklFRBAEEhYKEkVSUl9RVU9UQV9FWENFRURFRBAFEhgKFEVSUl9QQVlNRU5UX1JFUVVJUkVEEAYSGAoURVJSX01FVEhPRF9OT1RfRk9VTkQQBxIWChJFUlJfSU5WQUxJRF9QQVJBTVMQCBIZChVFUlJfVU5TVVBQT1JURURfQ0hBSU4QCRIXChNFUlJfVVBTVFJFQU1fRkFJTEVEEAoSHQoZRVJSX1VQU1RSRUFNX1JBVEVfTElNSVRFRBALEg8KC0VSUl9USU1FT1VUEAwSFgoSRVJSX1VQU1RSRUFNX0VSUk9SEA0ylwEKB0V4ZWN1dGUSQAoHRXhlY3V0ZRIZLnJlc29sdmVyLlJlc29sdmVyUmVxdWVzdBoaLnJlc29sdmVyLlJlc29sdmVyUmVzcG9uc2USSgoLTGlzdE1ldGhvZHMSHC5yZXNvbHZlci5MaXN0TWV0aG9kc1JlcXVlc3QaHS5yZXNvbHZlci5MaXN0TWV0aG9kc1Jlc3BvbnNlMlAKCExpdmVuZXNzEkQKCUhlYXJ0YmVhdBIaLnJlc29sdmVyLkhlYXJ0YmVhdFJlcXVlc3QaGy5yZXNvbHZlci5IZWFydGJlYXRSZXNwb25zZUItWitnaXRodWIuY29tLzFpbmNoL3AycC1uZXR3b3JrL3Byb3RvL3Jlc29sdmVyYgZwcm90bzM");
 
 /**
  * Represents a standard error structure.
@@ -27,6 +27,20 @@ export type Error = Message<"resolver.Error"> & {
    * @generated from field: string message = 2;
    */
   message: string;
+
+  /**
+   * Whether the same request may succeed later, e.g. on upstream failure or timeout.
+   *
+   * @generated from field: bool retryable = 3;
+   */
+  retryable: boolean;
+
+  /**
+   * Additional context of the error, e.g. chain id or upstream status code.
+   *
+   * @generated from field: map<string, string> details = 4;
+   */
+  details: { [key: string]: string };
 };
 
 /**
@@ -107,6 +121,139 @@ export type ResolverResponse = Message<"resolver.ResolverResponse"> & {
 export const ResolverResponseSchema: GenMessage<ResolverResponse> = /*@__PURE__*/
   messageDesc(file_resolver, 2);
 
+/**
+ * Heartbeat request, challenge is random bytes which are signed by resolver, so heartbeat can't be replayed.
+ *
+ * @generated from message resolver.HeartbeatRequest
+ */
+export type HeartbeatRequest = Message<"resolver.HeartbeatRequest"> & {
+  /**
+   * @generated from field: bytes challenge = 1;
+   */
+  challenge: Uint8Array;
+};
+
+/**
+ * Describes the message resolver.HeartbeatRequest.
+ * Use `create(HeartbeatRequestSchema)` to create a new message.
+ */
+export const HeartbeatRequestSchema: GenMessage<HeartbeatRequest> = /*@__PURE__*/
+  messageDesc(file_resolver, 3);
+
+/**
+ * Heartbeat response is liveness attestation signed by resolver key.
+ *
+ * @generated from message resolver.HeartbeatResponse
+ */
+export type HeartbeatResponse = Message<"resolver.HeartbeatResponse"> & {
+  /**
+   * @generated from field: bytes publicKey = 1;
+   */
+  publicKey: Uint8Array;
+
+  /**
+   * Unix time of the heartbeat in seconds.
+   *
+   * @generated from field: int64 timestamp = 2;
+   */
+  timestamp: bigint;
+
+  /**
+   * Signature of keccak256(challenge || timestamp || publicKey).
+   *
+   * @generated from field: bytes signature = 3;
+   */
+  signature: Uint8Array;
+};
+
+/**
+ * Describes the message resolver.HeartbeatResponse.
+ * Use `create(HeartbeatResponseSchema)` to create a new message.
+ */
+export const HeartbeatResponseSchema: GenMessage<HeartbeatResponse> = /*@__PURE__*/
+  messageDesc(file_resolver, 4);
+
+/**
+ * Method listing request, all methods served by resolver are returned.
+ *
+ * @generated from message resolver.ListMethodsRequest
+ */
+export type ListMethodsRequest = Message<"resolver.ListMethodsRequest"> & {
+};
+
+/**
+ * Describes the message resolver.ListMethodsRequest.
+ * Use `create(ListMethodsRequestSchema)` to create a new message.
+ */
+export const ListMethodsRequestSchema: GenMessage<ListMethodsRequest> = /*@__PURE__*/
+  messageDesc(file_resolver, 5);
+
+/**
+ * Method served by resolver api handler.
+ *
+ * @generated from message resolver.MethodInfo
+ */
+export type MethodInfo = Message<"resolver.MethodInfo"> & {
+  /**
+   * Name with handler namespace, e.g. infura.GetWalletBalance.
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * Namespace of the handler which serves the method.
+   *
+   * @generated from field: string handler = 2;
+   */
+  handler: string;
+
+  /**
+   * Method name without namespace, requests with it are routed to the default handler of the method.
+   *
+   * @generated from field: string method = 3;
+   */
+  method: string;
+
+  /**
+   * Whether requests with method name without namespace are routed to this handler.
+   *
+   * @generated from field: bool default = 4;
+   */
+  default: boolean;
+
+  /**
+   * Chain IDs served by the handler, empty if requests are not chain specific.
+   *
+   * @generated from field: repeated uint64 chainIds = 5;
+   */
+  chainIds: bigint[];
+};
+
+/**
+ * Describes the message resolver.MethodInfo.
+ * Use `create(MethodInfoSchema)` to create a new message.
+ */
+export const MethodInfoSchema: GenMessage<MethodInfo> = /*@__PURE__*/
+  messageDesc(file_resolver, 6);
+
+/**
+ * @generated from message resolver.ListMethodsResponse
+ */
+export type ListMethodsResponse = Message<"resolver.ListMethodsResponse"> & {
+  /**
+   * @generated from field: repeated resolver.MethodInfo methods = 1;
+   */
+  methods: MethodInfo[];
+};
+
+/**
+ * Describes the message resolver.ListMethodsResponse.
+ * Use `create(ListMethodsResponseSchema)` to create a new message.
+ */
+export const ListMethodsResponseSchema: GenMessage<ListMethodsResponse> = /*@__PURE__*/
+  messageDesc(file_resolver, 7);
+
 /**
  * Enum to represent standardized error codes.
  *
@@ -114,18 +261,18 @@ export const ResolverResponseSchema: GenMessage<ResolverResponse> = /*@__PURE__*
  */
 export enum ErrorCode {
   /**
-   * gRPC execution failure.
+   * Error in message format.
    *
-   * @generated from enum value: ERR_INTERNAL_EXCEPTION = 0;
+   * @generated from enum value: ERR_INVALID_MESSAGE_FORMAT = 0;
    */
-  ERR_INTERNAL_EXCEPTION = 0,
+  ERR_INVALID_MESSAGE_FORMAT = 0,
 
   /**
-   * Error in message format.
+   * gRPC execution failure, was ERR_GRPC_EXECUTION_FAILED.
    *
-   * @generated from enum value: ERR_INVALID_MESSAGE_FORMAT = 1;
+   * @generated from enum value: ERR_INTERNAL_EXCEPTION = 1;
    */
-  ERR_INVALID_MESSAGE_FORMAT = 1,
+  ERR_INTERNAL_EXCEPTION = 1,
 
   /**
    * Failed to serialize the response.
@@ -133,6 +280,83 @@ export enum ErrorCode {
    * @generated from enum value: ERR_RESPONSE_SERIALIZATION_FAILED = 2;
    */
   ERR_RESPONSE_SERIALIZATION_FAILED = 2,
+
+  /**
+   * Request was already processed or is outside the accepted time window.
+   *
+   * @generated from enum value: ERR_REPLAYED_REQUEST = 3;
+   */
+  ERR_REPLAYED_REQUEST = 3,
+
+  /**
+   * Client is denied, or api token is missing or invalid.
+   *
+   * @generated from enum value: ERR_ACCESS_DENIED = 4;
+   */
+  ERR_ACCESS_DENIED = 4,
+
+  /**
+   * Client exceeded its requests per minute or daily quota.
+   *
+   * @generated from enum value: ERR_QUOTA_EXCEEDED = 5;
+   */
+  ERR_QUOTA_EXCEEDED = 5,
+
+  /**
+   * Payment voucher is missing, invalid or insufficient.
+   *
+   * @generated from enum value: ERR_PAYMENT_REQUIRED = 6;
+   */
+  ERR_PAYMENT_REQUIRED = 6,
+
+  /**
+   * Method is not served by resolver.
+   *
+   * @generated from enum value: ERR_METHOD_NOT_FOUND = 7;
+   */
+  ERR_METHOD_NOT_FOUND = 7,
+
+  /**
+   * Params of the method are missing or invalid.
+   *
+   * @generated from enum value: ERR_INVALID_PARAMS = 8;
+   */
+  ERR_INVALID_PARAMS = 8,
+
+  /**
+   * Chain of the request is not served by resolver.
+   *
+   * @generated from enum value: ERR_UNSUPPORTED_CHAIN = 9;
+   */
+  ERR_UNSUPPORTED_CHAIN = 9,
+
+  /**
+   * All upstreams of the api handler failed.
+   *
+   * @generated from enum value: ERR_UPSTREAM_FAILED = 10;
+   */
+  ERR_UPSTREAM_FAILED = 10,
+
+  /**
+   * Upstream rejected the request because of its rate limit.
+   *
+   * @generated from enum value: ERR_UPSTREAM_RATE_LIMITED = 11;
+   */
+  ERR_UPSTREAM_RATE_LIMITED = 11,
+
+  /**
+   * Upstream didn't respond in time.
+   *
+   * @generated from enum value: ERR_TIMEOUT = 12;
+   */
+  ERR_TIMEOUT = 12,
+
+  /**
+   * Upstream returned error for the request.
+   *
+   * @generated from enum value: ERR_UPSTREAM_ERROR = 13;
+   */
+  ERR_UPSTREAM_ERROR = 13,
 }
 
 /**
@@ -153,6 +377,28 @@ export const Execute: GenService<{
     input: typeof ResolverRequestSchema;
     output: typeof ResolverResponseSchema;
   },
+  /**
+   * @generated from rpc resolver.Execute.ListMethods
+   */
+  listMethods: {
+    methodKind: "unary";
+    input: typeof ListMethodsRequestSchema;
+    output: typeof ListMethodsResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_resolver, 0);
 
+/**
+ * @generated from service resolver.Liveness
+ */
+export const Liveness: GenService<{
+  /**
+   * @generated from rpc resolver.Liveness.Heartbeat
+   */
+  heartbeat: {
+    methodKind: "unary";
+    input: typeof HeartbeatRequestSchema;
+    output: typeof HeartbeatResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_resolver, 1);
@@ -16,14 +16,45 @@ export type NetworkParams = {
 };
 
 export type JsonRequest = {
-  Id: string;
+  // "2.0" for JSON-RPC 2.0 requests, their errors are returned in response error object
+  jsonrpc?: "2.0";
+  Id: string | number;
   Method: string;
-  Params: string[];
+  // positional or named params
+  Params: any[] | Record<string, any>;
+  // chain of handlers which serve several chains, e.g. evm handler
+  chainId?: number;
+  // unix time in milliseconds, used by resolvers for replay protection
+  Timestamp?: number;
+  // unique value per request, used by resolvers for replay protection
+  Nonce?: string;
+  // pre-shared token of resolvers which limit access, send it only in encrypted requests
+  apiToken?: string;
+  // payment for resolvers which charge for requests
+  voucher?: Voucher;
+};
+
+// EIP-712 signed cumulative amount which payer pays to resolver via payment channel contract
+export type Voucher = {
+  payer: string;
+  // decimal total amount in wei of all vouchers of the payer to the resolver
+  amount: string;
+  // hex signature in [R || S || V] format
+  signature: string;
+};
+
+export type JsonRpcError = {
+  code: number;
+  message: string;
+  data?: any;
 };
 
 export type JsonResponse = {
-  id: string;
-  result: any;
+  jsonrpc?: "2.0";
+  id: string | number | null;
+  result?: any;
+  // set instead of result when JSON-RPC 2.0 request fails
+  error?: JsonRpcError;
 };
 
 export type PendingRequest = {
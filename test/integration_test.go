@@ -53,9 +53,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedDefaultHandler",
 			SessionId: "test-session-id-1",
 			JsonRequest: &types.JsonRequest{
-				Id:     "request-id-1",
+				Id:     types.StringId("request-id-1"),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.Equal(t, 555., result)
@@ -66,9 +66,9 @@ func TestPositiveCases(t *testing.T) {
 			Name:      "ResolverUsedInfuraHandler",
 			SessionId: "test-session-id-2",
 			JsonRequest: &types.JsonRequest{
-				Id:     "request-id-2",
+				Id:     types.StringId("request-id-2"),
 				Method: "GetWalletBalance",
-				Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"},
+				Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
 			},
 			FuncCheckActualJsonResponseResult: func(result interface{}) {
 				assert.NotEmpty(t, result)
@@ -153,7 +153,7 @@ func testWorkFlowAndReturnResponseChan(t *testing.T, logger *slog.Logger, cfg *r
 
 	req := &pbrelayer.IncomingMessage{
 		Request: &pbresolver.ResolverRequest{
-			Id:      jsonReq.Id,
+			Id:      jsonReq.Id.String(),
 			Payload: payload,
 		},
 		PublicKeys: [][]byte{
@@ -287,9 +287,9 @@ func TestSuccess(t *testing.T) {
 		resolverPrivKey := tn.ResolverPrivateKeys[0]
 		relayerAddress := tn.RelayerNodes[0].HTTPServer.Addr()
 		jsonReq := &types.JsonRequest{
-			Id:     "request-id-1",
+			Id:     types.StringId("request-id-1"),
 			Method: "GetWalletBalance",
-			Params: []string{"0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"},
+			Params: types.Params("0x0ADfCCa4B2a1132F82488546AcA086D7E24EA324", "latest"),
 		}
 
 		peerConnection, err := webrtc.NewPeerConnection(webrtc.Configuration{})
@@ -330,7 +330,7 @@ func TestSuccess(t *testing.T) {
 
 		msg := &pbrelayer.IncomingMessage{
 			Request: &pbresolver.ResolverRequest{
-				Id:      jsonReq.Id,
+				Id:      jsonReq.Id.String(),
 				Payload: payload,
 			},
 			PublicKeys: [][]byte{